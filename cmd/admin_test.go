@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+func TestAdminRequiresAdminRole(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.Sessions = repositories.NewMemorySessionRepository(
+		testUser(t, "admin@example.com", services.RoleAdmin),
+		testUser(t, "user@example.com", "user"),
+	)
+	router := setupRouter(deps, newServices(deps))
+
+	tests := []struct {
+		email string
+		want  int
+	}{
+		{"admin@example.com", http.StatusOK},
+		{"user@example.com", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/config", nil)
+			req.Header.Set("Authorization", "Bearer "+login(t, router, tt.email))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
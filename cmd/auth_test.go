@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLoginTakesAsLongForUnknownEmails(t *testing.T) {
+	deps := newTestDeps(t)
+	ada := testUser(t, "ada@example.com", "user")
+	// testUser's hashes are cheap; real ones use the default cost.
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ada.PasswordHash = string(hash)
+	deps.Sessions = repositories.NewMemorySessionRepository(ada)
+	api := apiRouter{t: t, router: setupRouter(deps, newServices(deps))}
+
+	timeLogin := func(email string) time.Duration {
+		t.Helper()
+		start := time.Now()
+		wantStatus(t, api.do(http.MethodPost, "/api/v1/login", `{"email": "`+email+`", "password": "wrong password"}`),
+			http.StatusUnauthorized)
+		return time.Since(start)
+	}
+	timeLogin("nobody@example.com") // hashes the dummy password once
+	wrong, unknown := timeLogin("ada@example.com"), timeLogin("nobody@example.com")
+	if unknown < wrong/2 {
+		t.Errorf("login took %v for an unknown email and %v for a wrong password", unknown, wrong)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/pkg/client"
+	"github.com/sampathreddy22/task-management-api/pkg/client/clienttest"
+)
+
+func TestClient(t *testing.T) {
+	clienttest.Tasks(t, newTestServer)
+}
+
+// TestClientRetries checks which failed requests the client repeats, with
+// a server failing the first requests to /api/v1/tasks/ with status.
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+	newClient := func(t *testing.T, status int, failures int32) (*client.Client, *atomic.Int32) {
+		t.Helper()
+		deps := newTestDeps(t)
+		router := setupRouter(deps, newServices(deps))
+		var remaining, requests atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v1/tasks/" {
+				router.ServeHTTP(w, r)
+				return
+			}
+			requests.Add(1)
+			if remaining.Add(-1) >= 0 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				return
+			}
+			router.ServeHTTP(w, r)
+		}))
+		t.Cleanup(srv.Close)
+
+		c, err := client.New(srv.URL, client.WithRetries(2, time.Millisecond, time.Millisecond))
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		clienttest.Login(t, c, "alice@example.com")
+		remaining.Store(failures)
+		return c, &requests
+	}
+
+	t.Run("IdempotentAfterServerError", func(t *testing.T) {
+		c, requests := newClient(t, http.StatusServiceUnavailable, 2)
+		if _, err := c.Tasks.List(ctx, client.ListTasksOptions{}); err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if got := requests.Load(); got != 3 {
+			t.Fatalf("List sent %d requests, want 3", got)
+		}
+	})
+
+	t.Run("GivesUp", func(t *testing.T) {
+		c, requests := newClient(t, http.StatusServiceUnavailable, 5)
+		if _, err := c.Tasks.List(ctx, client.ListTasksOptions{}); client.StatusCode(err) != http.StatusServiceUnavailable {
+			t.Fatalf("List: error = %v, want 503", err)
+		}
+		if got := requests.Load(); got != 3 {
+			t.Fatalf("List sent %d requests, want 3", got)
+		}
+	})
+
+	t.Run("NotCreateAfterServerError", func(t *testing.T) {
+		c, requests := newClient(t, http.StatusServiceUnavailable, 1)
+		if _, err := c.Tasks.Create(ctx, client.CreateTaskInput{Title: "Once"}); client.StatusCode(err) != http.StatusServiceUnavailable {
+			t.Fatalf("Create: error = %v, want 503", err)
+		}
+		if got := requests.Load(); got != 1 {
+			t.Fatalf("Create sent %d requests, want 1", got)
+		}
+	})
+
+	t.Run("CreateAfterRateLimit", func(t *testing.T) {
+		c, requests := newClient(t, http.StatusTooManyRequests, 1)
+		if _, err := c.Tasks.Create(ctx, client.CreateTaskInput{Title: "Later"}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if got := requests.Load(); got != 2 {
+			t.Fatalf("Create sent %d requests, want 2", got)
+		}
+	})
+}
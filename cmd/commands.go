@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/graph"
+	"github.com/sampathreddy22/task-management-api/internal/health"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/metrics"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/openapi"
+	"github.com/sampathreddy22/task-management-api/internal/ratelimit"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/schema"
+	"github.com/sampathreddy22/task-management-api/migrations"
+)
+
+const usage = `usage: task-management-api [command]
+
+Without a command the API server is started.
+
+Commands:
+  migrate up     apply pending database migrations
+  schema check   compare the GORM models with the migrated database schema
+  config print   print the effective configuration with secrets masked
+  openapi check  compare the registered routes with the OpenAPI spec
+`
+
+// runCommand executes a maintenance subcommand and returns the process exit code.
+func runCommand(cfg *config.Config, log *slog.Logger, args []string) int {
+	switch strings.Join(args, " ") {
+	case "migrate up":
+		return runMigrateUp(cfg, log)
+	case "schema check":
+		return runSchemaCheck(cfg, log)
+	case "config print":
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Error("failed to print config", slog.Any("error", err))
+			return 1
+		}
+		if err := config.Validate(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%v\n", err)
+		}
+		return 0
+	case "openapi check":
+		return runOpenAPICheck(cfg, log)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", strings.Join(args, " "), usage)
+		return 2
+	}
+}
+
+func runMigrateUp(cfg *config.Config, log *slog.Logger) int {
+	db, err := config.InitializeDatabase(cfg, logger.NewGormLogger(log))
+	if err != nil {
+		log.Error("failed to initialize database", slog.Any("error", err))
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	applied, err := migrations.Up(ctx, db)
+	for _, m := range applied {
+		fmt.Printf("applied %s\n", m.Name)
+	}
+	if err != nil {
+		log.Error("migration failed", slog.Any("error", err))
+		return 1
+	}
+	if len(applied) == 0 {
+		fmt.Println("database is up to date")
+	}
+	return 0
+}
+
+func runSchemaCheck(cfg *config.Config, log *slog.Logger) int {
+	db, err := config.InitializeDatabase(cfg, logger.NewGormLogger(log))
+	if err != nil {
+		log.Error("failed to initialize database", slog.Any("error", err))
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	issues, err := schema.Check(ctx, db, models.All()...)
+	if err != nil {
+		log.Error("schema check failed", slog.Any("error", err))
+		return 1
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("schema matches models")
+		return 0
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	fmt.Printf("%d issue(s) found\n", len(issues))
+	return 1
+}
+
+// runOpenAPICheck builds the router without external dependencies and fails
+// if its routes and the OpenAPI spec have drifted apart. CI runs it so
+// undocumented routes can't be merged.
+func runOpenAPICheck(cfg *config.Config, log *slog.Logger) int {
+	spec, err := openapi.Load()
+	if err != nil {
+		log.Error("failed to load the OpenAPI spec", slog.Any("error", err))
+		return 1
+	}
+
+	graphSchema, err := graph.NewSchema()
+	if err != nil {
+		log.Error("failed to build the GraphQL schema", slog.Any("error", err))
+		return 1
+	}
+
+	store := ratelimit.NewMemoryStore(time.Minute)
+	defer store.Close(context.Background())
+
+	gin.SetMode(gin.ReleaseMode)
+	deps := routerDeps{
+		Tasks:         repositories.NewMemoryTaskRepository(),
+		Comments:      repositories.NewMemoryCommentRepository(),
+		Board:         repositories.NewMemoryBoardRepository(),
+		TimeEntries:   repositories.NewMemoryTimeEntryRepository(),
+		SavedViews:    repositories.NewMemorySavedViewRepository(),
+		Templates:     repositories.NewMemoryTaskTemplateRepository(),
+		Projects:      repositories.NewMemoryProjectRepository(),
+		Notifications: repositories.NewMemoryNotificationRepository(),
+		InboundEmails: repositories.NewMemoryInboundEmailRepository(),
+		Logger:        log,
+		Metrics:       metrics.New(),
+		Health:        health.NewChecker(),
+		Limiter:       ratelimit.NewLimiter(store, cfg.RateLimit),
+		Config:        config.NewManager(cfg),
+		Spec:          spec,
+		Graph:         graphSchema,
+	}
+	router := setupRouter(deps, newServices(deps))
+
+	if err := spec.CheckRoutes(router.Routes(), routeQueries, swaggerRoute); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	fmt.Println("routes match the OpenAPI spec")
+	return 0
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSignupClosedFlagTakesEffectOnReload(t *testing.T) {
+	t.Setenv("TASKAPI_FEATURES_SIGNUP_CLOSED", "false")
+	deps := newTestDeps(t)
+	api := &apiRouter{t: t, router: setupRouter(deps, newServices(deps))}
+	signup := func(email string) int {
+		t.Helper()
+		return api.do(http.MethodPost, "/api/v1/signup", `{"email": "`+email+`", "password": "`+testPassword+`"}`).Code
+	}
+	reload := func(closed string) {
+		t.Helper()
+		t.Setenv("TASKAPI_FEATURES_SIGNUP_CLOSED", closed)
+		if err := deps.Config.Reload(); err != nil {
+			t.Fatalf("reload: %v", err)
+		}
+	}
+
+	if code := signup("ada@example.com"); code != http.StatusCreated {
+		t.Fatalf("signup while open: status %d", code)
+	}
+	reload("true")
+	wantStatus(t, api.do(http.MethodPost, "/api/v1/signup", `{"email": "bob@example.com", "password": "`+testPassword+`"}`),
+		http.StatusForbidden)
+	login(t, api.router, "ada@example.com")
+	reload("false")
+	if code := signup("bob@example.com"); code != http.StatusCreated {
+		t.Errorf("signup after reopening: status %d", code)
+	}
+}
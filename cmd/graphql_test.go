@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+)
+
+// graphQLResponse is the JSON body of a GraphQL response.
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code   string              `json:"code"`
+			Fields []map[string]string `json:"fields"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// graphQL runs query with variables as the router's user.
+func (r *apiRouter) graphQL(query string, variables map[string]interface{}) graphQLResponse {
+	r.t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	rec := r.do(http.MethodPost, "/api/v1/graphql", string(body))
+	wantStatus(r.t, rec, http.StatusOK)
+	return decode[graphQLResponse](r.t, rec.Body.Bytes())
+}
+
+func TestGraphQLTaskMutationsMatchREST(t *testing.T) {
+	api := newAPIRouter(t)
+	rec := api.do(http.MethodPost, "/api/v1/projects", `{"name": "Acme"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	projectID := decode[models.Project](t, rec.Body.Bytes()).ID.String()
+
+	rec = api.do(http.MethodPost, "/api/v1/tasks/",
+		`{"title": "Ship", "estimate_minutes": 90, "project_id": "`+projectID+`", "labels": ["release", " backend", "release"]}`)
+	wantStatus(t, rec, http.StatusCreated)
+	viaREST := decode[models.Task](t, rec.Body.Bytes())
+
+	resp := api.graphQL(`mutation Create($input: CreateTaskInput!) {
+		createTask(input: $input) { id estimateMinutes projectId labels }
+	}`, map[string]interface{}{"input": map[string]interface{}{
+		"title": "Ship", "estimateMinutes": 90, "projectId": projectID,
+		"labels": []string{"release", " backend", "release"},
+	}})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("createTask: %+v", resp.Errors)
+	}
+	var created struct {
+		CreateTask struct {
+			ID              string   `json:"id"`
+			EstimateMinutes *int     `json:"estimateMinutes"`
+			ProjectID       *string  `json:"projectId"`
+			Labels          []string `json:"labels"`
+		} `json:"createTask"`
+	}
+	if err := json.Unmarshal(resp.Data, &created); err != nil {
+		t.Fatal(err)
+	}
+	rec = api.do(http.MethodGet, "/api/v1/tasks/"+created.CreateTask.ID, "")
+	wantStatus(t, rec, http.StatusOK)
+	viaGraphQL := decode[models.Task](t, rec.Body.Bytes())
+	if *viaGraphQL.EstimateMinutes != *viaREST.EstimateMinutes || *viaGraphQL.ProjectID != *viaREST.ProjectID ||
+		!slices.Equal(viaGraphQL.Labels, viaREST.Labels) {
+		t.Errorf("created through GraphQL as %+v, through REST as %+v", viaGraphQL, viaREST)
+	}
+	if got := created.CreateTask; *got.EstimateMinutes != 90 || *got.ProjectID != projectID ||
+		!slices.Equal(got.Labels, []string(viaREST.Labels)) {
+		t.Errorf("createTask returned %+v", got)
+	}
+
+	resp = api.graphQL(`mutation Update($id: ID!, $input: UpdateTaskInput!) {
+		updateTask(id: $id, input: $input) { estimateMinutes labels }
+	}`, map[string]interface{}{"id": created.CreateTask.ID, "input": map[string]interface{}{
+		"estimateMinutes": 30, "labels": []string{},
+	}})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("updateTask: %+v", resp.Errors)
+	}
+	rec = api.do(http.MethodGet, "/api/v1/tasks/"+created.CreateTask.ID, "")
+	if task := decode[models.Task](t, rec.Body.Bytes()); *task.EstimateMinutes != 30 || len(task.Labels) != 0 ||
+		task.ProjectID == nil {
+		t.Errorf("updated to %+v, want the estimate and labels replaced and the project kept", task)
+	}
+
+	// Both reject the same input with the same code.
+	for _, input := range []struct {
+		rest    string
+		graphQL map[string]interface{}
+	}{
+		{`{"title": "Ship", "project_id": "00000000-0000-0000-0000-000000000001"}`,
+			map[string]interface{}{"title": "Ship", "projectId": "00000000-0000-0000-0000-000000000001"}},
+		{`{"title": "Ship", "labels": [""]}`, map[string]interface{}{"title": "Ship", "labels": []string{""}}},
+		{`{"title": "Ship", "estimate_minutes": -1}`, map[string]interface{}{"title": "Ship", "estimateMinutes": -1}},
+	} {
+		rec := api.do(http.MethodPost, "/api/v1/tasks/", input.rest)
+		wantStatus(t, rec, http.StatusUnprocessableEntity)
+		problem := decode[middleware.Problem](t, rec.Body.Bytes())
+		resp := api.graphQL(`mutation Create($input: CreateTaskInput!) { createTask(input: $input) { id } }`,
+			map[string]interface{}{"input": input.graphQL})
+		if len(resp.Errors) != 1 {
+			t.Errorf("createTask accepted %v: %s", input.graphQL, resp.Data)
+			continue
+		}
+		got := resp.Errors[0].Extensions
+		if got.Code != problem.Code || len(got.Fields) == 0 {
+			t.Errorf("GraphQL rejected %v with %+v, REST with %+v", input.graphQL, got, problem)
+		}
+	}
+}
+
+// countingTasks counts the batch lookups made through a task repository.
+type countingTasks struct {
+	repositories.TaskRepository
+	byIDs, attachments atomic.Int32
+}
+
+func (r *countingTasks) GetByIDs(ctx context.Context, ids []string) ([]models.Task, error) {
+	r.byIDs.Add(1)
+	return r.TaskRepository.GetByIDs(ctx, ids)
+}
+
+func (r *countingTasks) GetAttachments(ctx context.Context, taskIDs []string) ([]models.Attachment, error) {
+	r.attachments.Add(1)
+	return r.TaskRepository.GetAttachments(ctx, taskIDs)
+}
+
+// countingComments counts the lookups of comments by task.
+type countingComments struct {
+	repositories.CommentRepository
+	byTaskIDs atomic.Int32
+}
+
+func (r *countingComments) GetByTaskIDs(ctx context.Context, taskIDs []string) ([]models.Comment, error) {
+	r.byTaskIDs.Add(1)
+	return r.CommentRepository.GetByTaskIDs(ctx, taskIDs)
+}
+
+func TestGraphQLBatchesRelatedRecords(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.Sessions = repositories.NewMemorySessionRepository(testUser(t, "ada@example.com", "user"))
+	tasks := &countingTasks{TaskRepository: deps.Tasks}
+	comments := &countingComments{CommentRepository: deps.Comments}
+	deps.Tasks, deps.Comments = tasks, comments
+	router := setupRouter(deps, newServices(deps))
+	api := &apiRouter{t: t, router: router, token: login(t, router, "ada@example.com")}
+
+	const n = 5
+	for i := range n {
+		rec := api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Task `+string(rune('A'+i))+`"}`)
+		wantStatus(t, rec, http.StatusCreated)
+		taskPath := "/api/v1/tasks/" + decode[models.Task](t, rec.Body.Bytes()).ID.String()
+		for range 2 {
+			wantStatus(t, api.do(http.MethodPost, taskPath+"/comments", `{"content": "Looks good"}`), http.StatusCreated)
+		}
+	}
+	tasks.byIDs.Store(0)
+	tasks.attachments.Store(0)
+	comments.byTaskIDs.Store(0)
+
+	resp := api.graphQL(`{ tasks(limit: 5) { id attachments { id } comments { id task { id title } } } }`, nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("query: %+v", resp.Errors)
+	}
+	var got struct {
+		Tasks []struct {
+			ID       string `json:"id"`
+			Comments []struct {
+				Task struct {
+					ID string `json:"id"`
+				} `json:"task"`
+			} `json:"comments"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(resp.Data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Tasks) != n {
+		t.Fatalf("got %d tasks, want %d", len(got.Tasks), n)
+	}
+	for _, task := range got.Tasks {
+		if len(task.Comments) != 2 || task.Comments[0].Task.ID != task.ID {
+			t.Errorf("task %s has comments %+v", task.ID, task.Comments)
+		}
+	}
+
+	// One lookup per relation, however many tasks were listed.
+	for name, calls := range map[string]int32{
+		"comments":      comments.byTaskIDs.Load(),
+		"attachments":   tasks.attachments.Load(),
+		"comment tasks": tasks.byIDs.Load(),
+	} {
+		if calls != 1 {
+			t.Errorf("%s were looked up %d times, want once", name, calls)
+		}
+	}
+}
+
+// event is a server-sent event.
+type event struct {
+	name, data string
+}
+
+// readEvent reads the next event from r, skipping keep-alive comments.
+func readEvent(t *testing.T, r *bufio.Reader) event {
+	t.Helper()
+	var e event
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading the event stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			e.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		case line == "" && e.name != "":
+			return e
+		}
+	}
+}
+
+func TestGraphQLSubscriptions(t *testing.T) {
+	api := newAPIRouter(t)
+	srv := httptest.NewServer(api.router)
+	t.Cleanup(srv.Close)
+
+	subscribe := func(query string) *bufio.Reader {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		t.Cleanup(cancel)
+		body, _ := json.Marshal(map[string]string{"query": query})
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/api/v1/graphql", strings.NewReader(string(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+api.token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+			t.Fatalf("subscribing: status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		return bufio.NewReader(resp.Body)
+	}
+
+	rec := api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Watched"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	watchedID := decode[models.Task](t, rec.Body.Bytes()).ID.String()
+
+	all := subscribe(`subscription { taskChanged { type taskId task { title } } }`)
+	one := subscribe(`subscription { taskChanged(id: "` + watchedID + `") { type task { title } } }`)
+
+	rec = api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Other"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	otherID := decode[models.Task](t, rec.Body.Bytes()).ID.String()
+	wantStatus(t, api.do(http.MethodPut, "/api/v1/tasks/"+watchedID, `{"title": "Renamed"}`), http.StatusOK)
+	wantStatus(t, api.do(http.MethodDelete, "/api/v1/tasks/"+watchedID, ""), http.StatusNoContent)
+
+	for _, want := range []string{
+		`{"data":{"taskChanged":{"task":{"title":"Other"},"taskId":"` + otherID + `","type":"CREATED"}}}`,
+		`{"data":{"taskChanged":{"task":{"title":"Renamed"},"taskId":"` + watchedID + `","type":"UPDATED"}}}`,
+		`{"data":{"taskChanged":{"task":null,"taskId":"` + watchedID + `","type":"DELETED"}}}`,
+	} {
+		if got := readEvent(t, all); got.name != "next" || got.data != want {
+			t.Errorf("got %s event %s, want %s", got.name, got.data, want)
+		}
+	}
+	// The subscription to one task skips the others.
+	for _, want := range []string{
+		`{"data":{"taskChanged":{"task":{"title":"Renamed"},"type":"UPDATED"}}}`,
+		`{"data":{"taskChanged":{"task":null,"type":"DELETED"}}}`,
+	} {
+		if got := readEvent(t, one); got.name != "next" || got.data != want {
+			t.Errorf("got %s event %s, want %s", got.name, got.data, want)
+		}
+	}
+
+	// Subscriptions need an event stream.
+	resp := api.graphQL(`subscription { taskChanged { type } }`, nil)
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions.Code != "event_stream_required" {
+		t.Errorf("subscribing without an event stream: %+v", resp.Errors)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+)
+
+// apiRouter is the router of an empty server and a token of a user on it.
+type apiRouter struct {
+	t      *testing.T
+	router *gin.Engine
+	token  string
+}
+
+func newAPIRouter(t *testing.T) *apiRouter {
+	t.Helper()
+	deps := newTestDeps(t)
+	deps.Sessions = repositories.NewMemorySessionRepository(testUser(t, "ada@example.com", "user"))
+	router := setupRouter(deps, newServices(deps))
+	return &apiRouter{t: t, router: router, token: login(t, router, "ada@example.com")}
+}
+
+// do sends a request with the user's token and a JSON body, if any.
+func (r *apiRouter) do(method, path, body string) *httptest.ResponseRecorder {
+	r.t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	rec := httptest.NewRecorder()
+	r.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func wantStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status %d, want %d: %s", rec.Code, want, rec.Body)
+	}
+	if want >= http.StatusBadRequest && !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/problem+json") {
+		t.Errorf("error response has content type %q", rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestTaskHandlers(t *testing.T) {
+	api := newAPIRouter(t)
+
+	rec := api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Write release notes", "priority": 2}`)
+	wantStatus(t, rec, http.StatusCreated)
+	var created models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Status != models.TaskStatusTodo || created.Priority != 2 || created.UserID == nil {
+		t.Errorf("created %+v", created)
+	}
+	taskPath := "/api/v1/tasks/" + created.ID.String()
+
+	t.Run("Get", func(t *testing.T) {
+		rec := api.do(http.MethodGet, taskPath, "")
+		wantStatus(t, rec, http.StatusOK)
+		var got models.Task
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != created.ID || got.Title != created.Title {
+			t.Errorf("got %+v, want %+v", got, created)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		rec := api.do(http.MethodPut, taskPath, `{"title": "Publish release notes"}`)
+		wantStatus(t, rec, http.StatusOK)
+		var got models.Task
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Title != "Publish release notes" || got.Priority != 2 {
+			t.Errorf("updated %+v", got)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		rec := api.do(http.MethodGet, "/api/v1/tasks/?status=todo", "")
+		wantStatus(t, rec, http.StatusOK)
+		if !strings.Contains(rec.Body.String(), created.ID.String()) {
+			t.Errorf("list doesn't include the task: %s", rec.Body)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		tests := []struct {
+			name, method, path, body string
+			want                     int
+		}{
+			{"MissingTitle", http.MethodPost, "/api/v1/tasks/", `{"priority": 2}`, http.StatusUnprocessableEntity},
+			{"MalformedBody", http.MethodPost, "/api/v1/tasks/", `{"title": `, http.StatusUnprocessableEntity},
+			{"InvalidID", http.MethodGet, "/api/v1/tasks/not-a-uuid", "", http.StatusUnprocessableEntity},
+			{"UnknownTask", http.MethodGet, "/api/v1/tasks/" + uuid.NewString(), "", http.StatusNotFound},
+			{"UnknownStatus", http.MethodGet, "/api/v1/tasks/?status=blocked", "", http.StatusUnprocessableEntity},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				wantStatus(t, api.do(tt.method, tt.path, tt.body), tt.want)
+			})
+		}
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		anonymous := *api
+		anonymous.token = ""
+		rec := anonymous.do(http.MethodGet, taskPath, "")
+		wantStatus(t, rec, http.StatusUnauthorized)
+		if rec.Header().Get("WWW-Authenticate") == "" {
+			t.Error("401 without a WWW-Authenticate header")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		wantStatus(t, api.do(http.MethodDelete, taskPath, ""), http.StatusNoContent)
+		wantStatus(t, api.do(http.MethodGet, taskPath, ""), http.StatusNotFound)
+	})
+}
+
+func TestWIPLimitsApplyToEveryPathIntoAColumn(t *testing.T) {
+	api := newAPIRouter(t)
+	wantStatus(t, api.do(http.MethodPut, "/api/v1/board/columns/in_progress", `{"wip_limit": 1}`), http.StatusOK)
+
+	rec := api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Started", "status": "in_progress"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	rec = api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Waiting"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	var waiting models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &waiting); err != nil {
+		t.Fatal(err)
+	}
+	rec = api.do(http.MethodPost, "/api/v1/templates", `{"name": "Release", "task": {"title": "Release"}}`)
+	wantStatus(t, rec, http.StatusCreated)
+	var template models.TaskTemplate
+	if err := json.Unmarshal(rec.Body.Bytes(), &template); err != nil {
+		t.Fatal(err)
+	}
+	wantStatus(t, api.do(http.MethodPut, "/api/v1/board/columns/todo", `{"wip_limit": 1}`), http.StatusOK)
+
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"Create", http.MethodPost, "/api/v1/tasks/", `{"title": "Also started", "status": "in_progress"}`},
+		{"UpdateStatus", http.MethodPut, "/api/v1/tasks/" + waiting.ID.String(), `{"status": "in_progress"}`},
+		{"Template", http.MethodPost, "/api/v1/templates/" + template.ID.String() + "/instantiate", `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := api.do(tt.method, tt.path, tt.body)
+			wantStatus(t, rec, http.StatusConflict)
+			if !strings.Contains(rec.Body.String(), "wip_limit_reached") {
+				t.Errorf("conflict isn't about the WIP limit: %s", rec.Body)
+			}
+		})
+	}
+
+	// Edits that leave the status alone aren't limited.
+	wantStatus(t, api.do(http.MethodPut, "/api/v1/tasks/"+waiting.ID.String(), `{"title": "Still waiting"}`), http.StatusOK)
+}
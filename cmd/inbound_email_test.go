@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/inbound"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+)
+
+const inboundSecret = "0123456789abcdef0123456789abcdef"
+
+func TestInboundEmailIsSignedAndAuthenticated(t *testing.T) {
+	t.Setenv("TASKAPI_INBOUND_EMAIL_ENABLED", "true")
+	t.Setenv("TASKAPI_INBOUND_EMAIL_ADDRESS", "tasks@example.com")
+	t.Setenv("TASKAPI_INBOUND_EMAIL_SECRET", inboundSecret)
+	t.Setenv("TASKAPI_INBOUND_EMAIL_AUTHSERV_ID", "mx.example.com")
+	deps := newTestDeps(t)
+	deps.InboundEmails = repositories.NewMemoryInboundEmailRepository(testUser(t, "ada@example.com", "user"))
+	router := setupRouter(deps, newServices(deps))
+
+	post := func(message, signature string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/inbound/email?recipient=tasks%40example.com",
+			strings.NewReader(message))
+		req.Header.Set("Content-Type", "message/rfc822")
+		if signature != "" {
+			req.Header.Set(inbound.SignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	sign := func(query, message string) string {
+		return inbound.Sign(inboundSecret, query, []byte(message))
+	}
+	sent := 0
+	message := func(authResults string) string {
+		sent++
+		return "From: Ada <ada@example.com>\r\nTo: tasks@example.com\r\n" + authResults +
+			"Message-ID: <" + strconv.Itoa(sent) + "@example.com>\r\n" +
+			"Subject: Fix the build\r\n\r\nIt fails on main.\r\n"
+	}
+
+	// Anyone can reach the endpoint, so messages must be signed.
+	authentic := message("Authentication-Results: mx.example.com; spf=pass smtp.mailfrom=ada@example.com\r\n")
+	wantStatus(t, post(authentic, ""), http.StatusUnauthorized)
+	wantStatus(t, post(authentic, sign("recipient=tasks%40example.com", authentic+" ")), http.StatusUnauthorized)
+	wantStatus(t, post(authentic, sign("recipient=ops%40example.com", authentic)), http.StatusUnauthorized)
+
+	// And their senders authenticated by the mail server.
+	for _, authResults := range []string{
+		"",
+		"Authentication-Results: mx.example.com; spf=fail smtp.mailfrom=ada@example.com\r\n",
+		"Authentication-Results: mx.evil.test; dkim=pass header.d=example.com\r\n",
+	} {
+		msg := message(authResults)
+		wantStatus(t, post(msg, sign("recipient=tasks%40example.com", msg)), http.StatusForbidden)
+	}
+
+	rec := post(authentic, sign("recipient=tasks%40example.com", authentic))
+	wantStatus(t, rec, http.StatusCreated)
+	if result := decode[models.InboundEmailResult](t, rec.Body.Bytes()); result.Task == nil ||
+		result.Task.Title != "Fix the build" {
+		t.Errorf("result %+v, want a task named after the subject", result)
+	}
+}
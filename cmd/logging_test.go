@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+)
+
+func TestAccessLogKeepsCredentialsOut(t *testing.T) {
+	var logs bytes.Buffer
+	deps := newTestDeps(t)
+	deps.Logger = logger.NewWithWriter(config.LoggingConfig{Level: "debug", Format: "json"}, &logs)
+	t.Cleanup(func() { logger.SetLevel(deps.Config.Current().Logging.Level) })
+	deps.Sessions = repositories.NewMemorySessionRepository(testUser(t, "ada@example.com", "user"))
+	router := setupRouter(deps, newServices(deps))
+
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send(http.MethodPost, "/api/v1/login", "", `{"email": "ada@example.com", "password": "`+testPassword+`"}`)
+	wantStatus(t, rec, http.StatusOK)
+	var tokens models.TokenPair
+	if err := json.Unmarshal(rec.Body.Bytes(), &tokens); err != nil {
+		t.Fatal(err)
+	}
+	wantStatus(t, send(http.MethodPost, "/api/v1/login", "", `{"email": "ada@example.com", "password": "wrong password"}`),
+		http.StatusUnauthorized)
+	wantStatus(t, send(http.MethodGet, "/api/v1/tasks/?access_token="+tokens.AccessToken, tokens.AccessToken, ""),
+		http.StatusOK)
+	wantStatus(t, send(http.MethodPost, "/api/v1/refresh", "", `{"refresh_token": "`+tokens.RefreshToken+`"}`),
+		http.StatusOK)
+
+	got := logs.String()
+	if strings.Count(got, `"msg":"request completed"`) != 4 {
+		t.Fatalf("want 4 access log lines, got: %s", got)
+	}
+	for name, secret := range map[string]string{
+		"password":       testPassword,
+		"wrong password": "wrong password",
+		"access token":   tokens.AccessToken,
+		"refresh token":  tokens.RefreshToken,
+		"header":         "Bearer ",
+	} {
+		if strings.Contains(got, secret) {
+			t.Errorf("the %s reached the access log: %s", name, got)
+		}
+	}
+}
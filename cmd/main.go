@@ -1,100 +1,639 @@
-// @title Task Management API
-// @version 1.0
-// @description A simple task management API
-// @host localhost:8080
-// @BasePath /api/v1
-// @schemes http https
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	docs "github.com/sampathreddy22/task-management-api/cmd/docs"
+	"github.com/redis/go-redis/v9"
+	"github.com/sampathreddy22/task-management-api/internal/cache"
 	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/graph"
+	"github.com/sampathreddy22/task-management-api/internal/grpcapi"
 	"github.com/sampathreddy22/task-management-api/internal/handlers"
+	"github.com/sampathreddy22/task-management-api/internal/health"
+	"github.com/sampathreddy22/task-management-api/internal/inbound"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/metrics"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/openapi"
+	"github.com/sampathreddy22/task-management-api/internal/ratelimit"
+	"github.com/sampathreddy22/task-management-api/internal/replica"
 	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/server"
 	"github.com/sampathreddy22/task-management-api/internal/services"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
-	"gorm.io/gorm"
+	"github.com/sampathreddy22/task-management-api/internal/storage"
+	"github.com/sampathreddy22/task-management-api/internal/tracing"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
 )
 
-func setupRouter(db *gorm.DB) *gin.Engine {
+// routerDeps are the collaborators setupRouter wires into the handlers.
+// Repositories are taken as interfaces so tests can pass in-memory ones
+// and drive the router with httptest.
+type routerDeps struct {
+	Tasks       repositories.TaskRepository
+	Users       repositories.UserRepository
+	Attachments repositories.AttachmentRepository
+	Comments    repositories.CommentRepository
+	Board       repositories.BoardRepository
+	TimeEntries repositories.TimeEntryRepository
+	SavedViews  repositories.SavedViewRepository
+	Templates   repositories.TaskTemplateRepository
+	Projects    repositories.ProjectRepository
+	// Notifications also looks up the users named by mentions.
+	Notifications repositories.NotificationRepository
+	// InboundEmails also looks up the senders of inbound email.
+	InboundEmails repositories.InboundEmailRepository
+	// Sessions also creates the users who sign up.
+	Sessions repositories.SessionRepository
+	// Storage keeps uploaded files and those attached to inbound email.
+	Storage storage.Store
 
-	router := gin.Default()
-	docs.SwaggerInfo.BasePath = "/api/v1"
-	api := router.Group("/api/v1")
+	Logger  *slog.Logger
+	Metrics *metrics.Metrics
+	Health  *health.Checker
+	Limiter *ratelimit.Limiter
+	Config  *config.Manager
+	Spec    *openapi.Spec
+	Graph   *graph.Schema
+	// Replicas is nil unless read replicas are configured.
+	Replicas *replica.Router
+	// Stopping is closed when the server shuts down, to end event streams.
+	Stopping <-chan struct{}
+}
 
-	//Initialize repositories and services
-	taskRepo := repositories.NewTaskRepository(db)
-	taskService := services.NewTaskService(taskRepo)
-	taskHandler := handlers.NewTaskHandler(taskService)
+// apiServices are shared by the REST, GraphQL and gRPC APIs, so task events
+// reach subscribers whichever API made the change.
+type apiServices struct {
+	Auth        *services.AuthService
+	Tasks       *services.TaskService
+	Users       *services.UserService
+	Attachments *services.AttachmentService
+	Comments    *services.CommentService
+	TimeEntries *services.TimeEntryService
+	SavedViews  *services.SavedViewService
+	Templates   *services.TaskTemplateService
+	Projects    *services.ProjectService
+	// Notifications hears of the changes made through Tasks and Comments.
+	Notifications *services.NotificationService
+	// InboundEmail is nil when the email gateway is disabled.
+	InboundEmail *services.InboundEmailService
+	// AttachmentFiles uploads and downloads the files of attachments.
+	AttachmentFiles *services.AttachmentFileService
+}
 
-	userRepo := repositories.NewUserRepository(db)
-	userService := services.NewUserService(userRepo)
-	userHandler := handlers.NewUserHandler(userService)
+func newServices(deps routerDeps) apiServices {
+	notifications := services.NewNotificationService(deps.Notifications, deps.Tasks, deps.Comments)
+	tasks := services.NewTaskService(deps.Tasks, deps.Board, deps.Projects, notifications)
+	svc := apiServices{
+		Auth:          services.NewAuthService(deps.Sessions, deps.Config.Current().Auth),
+		Tasks:         tasks,
+		Users:         services.NewUserService(deps.Users),
+		Attachments:   services.NewAttachmentService(deps.Attachments),
+		Comments:      services.NewCommentService(deps.Comments, notifications),
+		TimeEntries:   services.NewTimeEntryService(deps.TimeEntries, deps.Tasks),
+		SavedViews:    services.NewSavedViewService(deps.SavedViews, deps.Projects, tasks),
+		Templates:     services.NewTaskTemplateService(deps.Templates, tasks),
+		Projects:      services.NewProjectService(deps.Projects),
+		Notifications: notifications,
+	}
+	svc.AttachmentFiles = services.NewAttachmentFileService(svc.Attachments, tasks, deps.Storage)
+	if cfg := deps.Config.Current().InboundEmail; cfg.Enabled {
+		svc.InboundEmail = services.NewInboundEmailService(deps.InboundEmails, tasks, svc.Comments, svc.Attachments,
+			deps.Storage, cfg.Address, cfg.AuthservID)
+	}
+	return svc
+}
 
-	attachmentRepo := repositories.NewAttachmentRepository(db)
-	attachmentService := services.NewAttachmentService(attachmentRepo)
-	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+// inboundReply describes what the email gateway made of a message, for
+// the SMTP client.
+func inboundReply(result *models.InboundEmailResult) string {
+	switch {
+	case result.Duplicate:
+		return "already received, task " + result.Task.ID.String()
+	case result.Comment != nil:
+		return "added comment " + result.Comment.ID.String() + " to task " + result.Task.ID.String()
+	default:
+		return "created task " + result.Task.ID.String() + ", replies to " + result.ReplyTo
+	}
+}
+
+// grpcAuthenticator checks access tokens for the gRPC API.
+func grpcAuthenticator(auth *services.AuthService) grpcapi.Authenticator {
+	return func(ctx context.Context, token string) (string, error) {
+		caller, err := auth.Authenticate(ctx, token)
+		if err != nil {
+			return "", err
+		}
+		return caller.UserID.String(), nil
+	}
+}
+
+// verifier checks access tokens for middleware.Authenticate.
+func verifier(auth *services.AuthService) middleware.Verifier {
+	return func(ctx context.Context, token string) (middleware.Identity, error) {
+		caller, err := auth.Authenticate(ctx, token)
+		if err != nil {
+			return middleware.Identity{}, err
+		}
+		return middleware.Identity{
+			UserID:    caller.UserID.String(),
+			Role:      caller.Role,
+			SessionID: caller.SessionID.String(),
+		}, nil
+	}
+}
+
+// swaggerRoute serves Swagger UI. It isn't part of the API, so the OpenAPI
+// route check skips it.
+const swaggerRoute = "/swagger/*any"
+
+// routeQueries lists the query parameters each handler reads, for the
+// OpenAPI route check to compare with the documented ones.
+var routeQueries = openapi.Queries{
+	"GET /api/v1/tasks/":                 openapi.FormFields(models.TaskListQuery{}),
+	"GET /api/v1/tasks/:id/time-entries": openapi.FormFields(models.TimeEntryListQuery{}),
+	"GET /api/v1/board":                  openapi.FormFields(models.BoardQuery{}),
+	"GET /api/v1/board/columns/:status":  openapi.FormFields(models.BoardQuery{}),
+	"GET /api/v1/reports/time":           openapi.FormFields(models.TimeReportQuery{}),
+	"GET /api/v1/views/:id/tasks":        openapi.FormFields(models.SavedViewRunQuery{}),
+	"GET /api/v1/templates":              openapi.FormFields(models.TemplateListQuery{}),
+	"GET /api/v1/projects":               openapi.FormFields(models.ProjectListQuery{}),
+	"GET /api/v1/notifications":          openapi.FormFields(models.NotificationListQuery{}),
+	"POST /api/v1/inbound/email":         {"recipient"},
+}
+
+func setupRouter(deps routerDeps, svc apiServices) *gin.Engine {
+	log, m, checker, limiter, configManager := deps.Logger, deps.Metrics, deps.Health, deps.Limiter, deps.Config
+
+	router := gin.New()
+	// gin trusts every proxy unless told otherwise, which would let clients
+	// set their own address with X-Forwarded-For.
+	if err := router.SetTrustedProxies(configManager.Current().Server.TrustedProxies); err != nil {
+		log.Error("ignoring server.trusted_proxies", slog.Any("error", err))
+		router.SetTrustedProxies(nil)
+	}
+	router.Use(middleware.RequestID(log), middleware.Tracing(), middleware.AccessLog(), m.Middleware())
+	if configManager.Current().Server.OpenAPIValidation {
+		router.Use(deps.Spec.Middleware())
+	}
+	router.Use(middleware.Errors(), middleware.Recovery(),
+		middleware.CORS(func() []string { return configManager.Current().CORS.AllowedOrigins }))
+	// Read-your-writes windows are per user, so on authenticated routes the
+	// replica middleware runs after authentication.
+	var replicaMiddleware []gin.HandlerFunc
+	if deps.Replicas != nil {
+		replicaMiddleware = append(replicaMiddleware, deps.Replicas.Middleware())
+	}
+	// Only signing up and in, and the email gateway, work without an access
+	// token.
+	public := router.Group("/api/v1", replicaMiddleware...)
+	api := router.Group("/api/v1", append([]gin.HandlerFunc{middleware.Authenticate(verifier(svc.Auth))}, replicaMiddleware...)...)
+
+	//Initialize handlers
+	authHandler := handlers.NewAuthHandler(svc.Auth, func() bool { return configManager.Feature(config.FeatureSignupClosed) })
+	taskHandler := handlers.NewTaskHandler(svc.Tasks)
+	userHandler := handlers.NewUserHandler(svc.Users)
+	attachmentHandler := handlers.NewAttachmentHandler(svc.Attachments, svc.AttachmentFiles,
+		configManager.Current().Storage.MaxFileSize)
+	commentHandler := handlers.NewCommentHandler(svc.Comments, svc.Tasks)
+	boardHandler := handlers.NewBoardHandler(svc.Tasks)
+	timeEntryHandler := handlers.NewTimeEntryHandler(svc.TimeEntries)
+	savedViewHandler := handlers.NewSavedViewHandler(svc.SavedViews)
+	templateHandler := handlers.NewTaskTemplateHandler(svc.Templates)
+	projectHandler := handlers.NewProjectHandler(svc.Projects)
+	notificationHandler := handlers.NewNotificationHandler(svc.Notifications, deps.Stopping)
+	inboundConfig := configManager.Current().InboundEmail
+	inboundEmailHandler := handlers.NewInboundEmailHandler(svc.InboundEmail, inboundConfig.MaxSize, inboundConfig.Secret)
+	adminHandler := handlers.NewAdminHandler(configManager)
+
+	graphHandler := graph.NewHandler(deps.Graph, graph.Services{
+		Tasks:       svc.Tasks,
+		Comments:    svc.Comments,
+		Attachments: svc.Attachments,
+		Users:       deps.Users,
+	}, configManager.Current().GraphQL, deps.Stopping)
 
 	//Setup routes
-	tasks := api.Group("/tasks")
+	authRoutes := public.Group("", limiter.Middleware("auth"))
+	{
+		authRoutes.POST("/signup", authHandler.Signup)
+		authRoutes.POST("/login", authHandler.Login)
+		authRoutes.POST("/refresh", authHandler.Refresh)
+	}
+	api.POST("/logout", limiter.Middleware("auth"), authHandler.Logout)
+
+	tasks := api.Group("/tasks", limiter.Middleware("tasks"))
 	{
 		tasks.POST("/", taskHandler.CreateTask)
 		tasks.GET("/:id", taskHandler.GetTaskByID)
 		tasks.PUT("/:id", taskHandler.UpdateTask)
 		tasks.DELETE("/:id", taskHandler.DeleteTask)
 		tasks.GET("/", taskHandler.GetTasks)
+		tasks.POST("/:id/move", taskHandler.MoveTask)
+		tasks.POST("/:id/timer/start", timeEntryHandler.StartTimer)
+		tasks.POST("/:id/time-entries", timeEntryHandler.CreateTimeEntry)
+		tasks.GET("/:id/time-entries", timeEntryHandler.GetTaskTimeEntries)
+		tasks.POST("/:id/comments", commentHandler.CreateComment)
+		tasks.GET("/:id/comments", commentHandler.GetTaskComments)
+		tasks.POST("/:id/attachments", limiter.Middleware("attachments"), attachmentHandler.UploadAttachment)
+	}
+
+	api.DELETE("/comments/:id", limiter.Middleware("tasks"), commentHandler.DeleteComment)
+
+	board := api.Group("/board", limiter.Middleware("board"))
+	{
+		board.GET("", boardHandler.GetBoard)
+		board.GET("/columns/:status", boardHandler.GetColumn)
+		board.PUT("/columns/:status", boardHandler.UpdateColumn)
+	}
+
+	timer := api.Group("/timer", limiter.Middleware("time"))
+	{
+		timer.GET("", timeEntryHandler.GetTimer)
+		timer.POST("/stop", timeEntryHandler.StopTimer)
+	}
+
+	timeEntries := api.Group("/time-entries", limiter.Middleware("time"))
+	{
+		timeEntries.GET("/:id", timeEntryHandler.GetTimeEntry)
+		timeEntries.PUT("/:id", timeEntryHandler.UpdateTimeEntry)
+		timeEntries.DELETE("/:id", timeEntryHandler.DeleteTimeEntry)
 	}
 
-	users := api.Group("/users")
+	api.GET("/reports/time", limiter.Middleware("time"), timeEntryHandler.GetTimeReport)
+
+	views := api.Group("/views", limiter.Middleware("views"))
+	{
+		views.GET("", savedViewHandler.GetViews)
+		views.POST("", savedViewHandler.CreateView)
+		views.GET("/counts", savedViewHandler.GetViewCounts)
+		views.GET("/default", savedViewHandler.GetDefaultView)
+		views.PUT("/default", savedViewHandler.SetDefaultView)
+		views.DELETE("/default", savedViewHandler.ClearDefaultView)
+		views.GET("/:id", savedViewHandler.GetView)
+		views.PUT("/:id", savedViewHandler.UpdateView)
+		views.DELETE("/:id", savedViewHandler.DeleteView)
+		views.GET("/:id/tasks", savedViewHandler.GetViewTasks)
+	}
+
+	templates := api.Group("/templates", limiter.Middleware("templates"))
+	{
+		templates.GET("", templateHandler.GetTemplates)
+		templates.POST("", templateHandler.CreateTemplate)
+		templates.GET("/:id", templateHandler.GetTemplate)
+		templates.PUT("/:id", templateHandler.UpdateTemplate)
+		templates.DELETE("/:id", templateHandler.DeleteTemplate)
+		templates.POST("/:id/instantiate", templateHandler.InstantiateTemplate)
+	}
+
+	projects := api.Group("/projects", limiter.Middleware("projects"))
+	{
+		projects.GET("", projectHandler.GetProjects)
+		projects.POST("", projectHandler.CreateProject)
+		projects.GET("/:id", projectHandler.GetProject)
+		projects.PUT("/:id", projectHandler.UpdateProject)
+		projects.DELETE("/:id", projectHandler.DeleteProject)
+		projects.GET("/:id/members", projectHandler.GetMembers)
+		projects.PUT("/:id/members/:userId", projectHandler.AddMember)
+		projects.DELETE("/:id/members/:userId", projectHandler.RemoveMember)
+	}
+
+	notifications := api.Group("/notifications", limiter.Middleware("notifications"))
+	{
+		notifications.GET("", notificationHandler.GetNotifications)
+		notifications.GET("/unread-count", notificationHandler.GetUnreadCount)
+		notifications.POST("/read-all", notificationHandler.MarkAllRead)
+		notifications.GET("/stream", notificationHandler.StreamNotifications)
+		notifications.GET("/preferences", notificationHandler.GetPreferences)
+		notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		notifications.POST("/:id/read", notificationHandler.MarkRead)
+	}
+
+	users := api.Group("/users", limiter.Middleware("users"))
 	{
 		users.POST("/", userHandler.CreateUser)
 		users.GET("/:id", userHandler.GetUserByID)
 	}
 
-	attachments := api.Group("/attachments")
+	attachments := api.Group("/attachments", limiter.Middleware("attachments"))
 	{
 		attachments.POST("/", attachmentHandler.CreateAttachment)
 		attachments.GET("/:id", attachmentHandler.GetAttachment)
+		attachments.GET("/:id/content", attachmentHandler.DownloadAttachment)
 		attachments.GET("/task/:taskId", attachmentHandler.GetTaskAttachments)
 		attachments.PUT("/:id", attachmentHandler.UpdateAttachment)
 		attachments.DELETE("/:id", attachmentHandler.DeleteAttachment)
 	}
 
-	//Add swagger documentation
-	router.GET("/swagger/*any",
-		ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.DocExpansion("none")))
+	public.POST("/inbound/email", limiter.Middleware("inbound"), inboundEmailHandler.ReceiveEmail)
+
+	api.POST("/graphql", limiter.Middleware("graphql"), graphHandler.Serve)
+
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+	router.GET("/healthz", checker.Liveness)
+	router.GET("/readyz", checker.Readiness)
+
+	admin := api.Group("/admin", middleware.RequireRole(services.RoleAdmin))
+	{
+		admin.GET("/config", adminHandler.GetConfigVersion)
+	}
+
+	//Add API documentation
+	router.GET("/openapi.json", deps.Spec.Handler)
+	router.GET(swaggerRoute, openapi.SwaggerUI("/openapi.json"))
 
 	return router
 
 }
 
 func main() {
-	//Load configuration
-	cfg, err := config.Load()
+	//Load configuration. config print shows the settings as loaded, so that
+	// an invalid configuration can be inspected.
+	load := config.Load
+	if strings.Join(os.Args[1:], " ") == "config print" {
+		load = config.Read
+	}
+	cfg, err := load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	appLogger := logger.New(cfg.Logging)
+	slog.SetDefault(appLogger)
+
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(cfg, appLogger, os.Args[1:]))
+	}
+
+	if err := validation.Register(); err != nil {
+		appLogger.Error("failed to register validators", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		appLogger.Error("failed to load the OpenAPI spec", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	graphSchema, err := graph.NewSchema()
+	if err != nil {
+		appLogger.Error("failed to build the GraphQL schema", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	//Initialize database
-	db, err := config.InitializeDatabase(cfg)
+	db, err := config.InitializeDatabase(cfg, logger.NewGormLogger(appLogger))
+	if err != nil {
+		appLogger.Error("failed to initialize database", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		appLogger.Error("failed to initialize tracing", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	appMetrics := metrics.New()
+	if err := appMetrics.InstrumentDB(db, cfg.Database.Name); err != nil {
+		appLogger.Error("failed to instrument database", slog.Any("error", err))
+		os.Exit(1)
 	}
+	if err := appMetrics.RegisterTaskCollector(db); err != nil {
+		appLogger.Error("failed to register task metrics", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	dbSystem := "postgresql"
+	if cfg.Database.Driver == config.DriverSQLite {
+		dbSystem = "sqlite"
+	}
+	if err := tracing.InstrumentDB(db, dbSystem); err != nil {
+		appLogger.Error("failed to instrument database for tracing", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	var replicas *replica.Router
+	if len(cfg.Database.Replicas) > 0 {
+		conns, err := config.OpenReplicas(cfg)
+		if err != nil {
+			appLogger.Error("failed to initialize replicas", slog.Any("error", err))
+			os.Exit(1)
+		}
+		members := make([]replica.Replica, len(conns))
+		for i, conn := range conns {
+			r := cfg.Database.Replicas[i]
+			members[i] = replica.Replica{Name: r.Host + ":" + r.Port, Conn: conn}
+			if err := appMetrics.InstrumentPool(conn, cfg.Database.Name+"@"+members[i].Name); err != nil {
+				appLogger.Error("failed to instrument replica", slog.Any("error", err))
+				os.Exit(1)
+			}
+		}
+		replicas, err = replica.Setup(db, members, replica.Options{
+			ReadYourWrites: cfg.Database.ReadYourWrites,
+			CheckInterval:  cfg.Database.ReplicaCheckInterval,
+		}, appLogger)
+		if err != nil {
+			appLogger.Error("failed to set up replica routing", slog.Any("error", err))
+			os.Exit(1)
+		}
+		if err := tracing.AnnotatePool(db, replicas.PoolName); err != nil {
+			appLogger.Error("failed to instrument replicas for tracing", slog.Any("error", err))
+			os.Exit(1)
+		}
+		go replicas.Run()
+	}
+
+	checker := health.NewChecker()
+	checker.Register("database", health.DatabaseCheck(db))
+	checker.Register("migrations", health.MigrationCheck(db))
+	if cfg.Storage.Driver == "local" {
+		checker.Register("storage", health.DirectoryCheck(cfg.Storage.Path))
+	}
+
+	var redisClient *redis.Client
+	if cfg.UsesRedis() {
+		redisClient, err = config.InitializeRedis(cfg)
+		if err != nil {
+			appLogger.Error("failed to initialize redis", slog.Any("error", err))
+			os.Exit(1)
+		}
+		checker.Register("redis", func(ctx context.Context) error { return redisClient.Ping(ctx).Err() })
+	}
+
+	var rateLimitStore ratelimit.Store
+	var closeRateLimitStore func(context.Context) error
+	if cfg.RateLimit.UsesRedis() {
+		rateLimitStore = ratelimit.NewRedisStore(redisClient)
+	} else {
+		memoryStore := ratelimit.NewMemoryStore(10 * time.Minute)
+		rateLimitStore = memoryStore
+		closeRateLimitStore = memoryStore.Close
+	}
+	limiter := ratelimit.NewLimiter(rateLimitStore, cfg.RateLimit)
 
-	// setup router with database connectivity
-	router := setupRouter(db)
+	taskRepo := repositories.NewTaskRepository(db)
+	if cfg.Cache.Enabled {
+		var taskCache cache.Cache
+		if cfg.Cache.Store == "redis" {
+			taskCache = cache.NewRedis(redisClient)
+		} else {
+			taskCache = cache.NewLRU(cfg.Cache.MaxEntries)
+		}
+		cacheMetrics, err := cache.NewMetrics(appMetrics.Registerer())
+		if err != nil {
+			appLogger.Error("failed to register cache metrics", slog.Any("error", err))
+			os.Exit(1)
+		}
+		taskRepo = repositories.NewCachedTaskRepository(taskRepo, taskCache, cfg.Cache.TTL, cacheMetrics)
+	}
+
+	configManager := config.NewManager(cfg)
+	configManager.Subscribe(func(c *config.Config) {
+		logger.SetLevel(c.Logging.Level)
+		limiter.SetRules(c.RateLimit)
+	})
+	if _, err := configManager.Watch(); err != nil {
+		appLogger.Error("failed to watch the config files, reloading is off", slog.Any("error", err))
+	}
+
+	stopping := make(chan struct{})
+	deps := routerDeps{
+		Tasks:         taskRepo,
+		Users:         repositories.NewUserRepository(db),
+		Attachments:   repositories.NewAttachmentRepository(db),
+		Comments:      repositories.NewCommentRepository(db),
+		Board:         repositories.NewBoardRepository(db),
+		TimeEntries:   repositories.NewTimeEntryRepository(db),
+		SavedViews:    repositories.NewSavedViewRepository(db),
+		Templates:     repositories.NewTaskTemplateRepository(db),
+		Projects:      repositories.NewProjectRepository(db),
+		Notifications: repositories.NewNotificationRepository(db),
+		InboundEmails: repositories.NewInboundEmailRepository(db),
+		Sessions:      repositories.NewSessionRepository(db),
+		Storage:       storage.NewLocal(cfg.Storage.Path),
+		Logger:        appLogger,
+		Metrics:       appMetrics,
+		Health:        checker,
+		Limiter:       limiter,
+		Config:        configManager,
+		Spec:          spec,
+		Graph:         graphSchema,
+		Replicas:      replicas,
+		Stopping:      stopping,
+	}
+	svc := newServices(deps)
+	router := setupRouter(deps, svc)
 
-	// Start the server
-	port := cfg.Server.Port
-	if port == "" {
-		port = "8080"
+	srv := server.New(cfg.Server, router, appLogger)
+	srv.OnStop(func() { close(stopping) })
+	srv.OnShutdown("database", func(context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	})
+	if replicas != nil {
+		srv.OnShutdown("replicas", replicas.Close)
+	}
+	srv.OnShutdown("tracing", shutdownTracing)
+	if redisClient != nil {
+		srv.OnShutdown("redis", func(context.Context) error { return redisClient.Close() })
+	}
+	if closeRateLimitStore != nil {
+		srv.OnShutdown("rate limit store", closeRateLimitStore)
+	}
+	dueSoonCtx, stopDueSoon := context.WithCancel(context.Background())
+	dueSoonDone := make(chan struct{})
+	go func() {
+		defer close(dueSoonDone)
+		svc.Notifications.RunDueSoon(dueSoonCtx, cfg.Notifications.DueSoon, cfg.Notifications.ScanInterval)
+	}()
+	srv.OnShutdown("due soon notifications", func(ctx context.Context) error {
+		stopDueSoon()
+		select {
+		case <-dueSoonDone:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	// Registered last so calls have finished before the database and stores
+	// they use are closed.
+	if cfg.GRPC.Enabled {
+		addr := net.JoinHostPort(cfg.Server.Host, cfg.GRPC.Port)
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			appLogger.Error("failed to listen for gRPC", slog.Any("error", err))
+			os.Exit(1)
+		}
+		grpcServer := grpcapi.New(grpcapi.Services{
+			Tasks:       svc.Tasks,
+			Comments:    svc.Comments,
+			Attachments: svc.Attachments,
+			Users:       deps.Users,
+		}, grpcapi.Options{Logger: appLogger, Limiter: limiter, Authenticate: grpcAuthenticator(svc.Auth)})
+		go func() {
+			appLogger.Info("starting gRPC server", slog.String("addr", addr))
+			if err := grpcServer.Serve(lis); err != nil {
+				appLogger.Error("gRPC server stopped", slog.Any("error", err))
+			}
+		}()
+		srv.OnShutdown("grpc", grpcServer.Shutdown)
 	}
 
-	router.Run(fmt.Sprintf(":%s", port))
+	if cfg.InboundEmail.Enabled && cfg.InboundEmail.SMTPPort != "" {
+		addr := net.JoinHostPort(cfg.Server.Host, cfg.InboundEmail.SMTPPort)
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			appLogger.Error("failed to listen for SMTP", slog.Any("error", err))
+			os.Exit(1)
+		}
+		relays, err := inbound.ParseRelays(cfg.InboundEmail.SMTPRelays)
+		if err != nil {
+			appLogger.Error("invalid inbound_email.smtp_relays", slog.Any("error", err))
+			os.Exit(1)
+		}
+		smtpServer := inbound.NewServer(func(ctx context.Context, from string, to []string, data []byte) (string, error) {
+			result, err := svc.InboundEmail.Receive(ctx, data, to)
+			if err != nil {
+				return "", err
+			}
+			return inboundReply(result), nil
+		}, inbound.Options{
+			Logger:   appLogger,
+			Hostname: cfg.InboundEmail.Address[strings.LastIndexByte(cfg.InboundEmail.Address, '@')+1:],
+			MaxSize:  cfg.InboundEmail.MaxSize,
+			Timeout:  cfg.Server.Timeout,
+			Accept:   svc.InboundEmail.Accepts,
+			Relays:   relays,
+		})
+		go func() {
+			appLogger.Info("starting SMTP server", slog.String("addr", addr))
+			if err := smtpServer.Serve(lis); err != nil {
+				appLogger.Error("SMTP server stopped", slog.Any("error", err))
+			}
+		}()
+		srv.OnShutdown("smtp", smtpServer.Shutdown)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	srv.OnDrain(checker.SetShuttingDown)
 
+	if err := srv.Run(ctx); err != nil {
+		appLogger.Error("server stopped", slog.Any("error", err))
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/graph"
+	"github.com/sampathreddy22/task-management-api/internal/health"
+	"github.com/sampathreddy22/task-management-api/internal/metrics"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/openapi"
+	"github.com/sampathreddy22/task-management-api/internal/ratelimit"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/storage"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testPassword is the password of the users made by testUser.
+const testPassword = "correct horse battery"
+
+// newTestDeps returns the dependencies of an empty server: in-memory
+// repositories and the test profile's configuration.
+func newTestDeps(t *testing.T) routerDeps {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("TASKAPI_PROFILE", "test")
+	// The repositories are in memory; sqlite only spares the Postgres
+	// credentials.
+	t.Setenv("TASKAPI_DATABASE_DRIVER", "sqlite")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	if err := validation.Register(); err != nil {
+		t.Fatalf("validation.Register: %v", err)
+	}
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("openapi.Load: %v", err)
+	}
+	schema, err := graph.NewSchema()
+	if err != nil {
+		t.Fatalf("graph.NewSchema: %v", err)
+	}
+	store := ratelimit.NewMemoryStore(time.Minute)
+	t.Cleanup(func() { store.Close(context.Background()) })
+
+	return routerDeps{
+		Tasks:         repositories.NewMemoryTaskRepository(),
+		Comments:      repositories.NewMemoryCommentRepository(),
+		Board:         repositories.NewMemoryBoardRepository(),
+		TimeEntries:   repositories.NewMemoryTimeEntryRepository(),
+		SavedViews:    repositories.NewMemorySavedViewRepository(),
+		Templates:     repositories.NewMemoryTaskTemplateRepository(),
+		Projects:      repositories.NewMemoryProjectRepository(),
+		Notifications: repositories.NewMemoryNotificationRepository(),
+		InboundEmails: repositories.NewMemoryInboundEmailRepository(),
+		Sessions:      repositories.NewMemorySessionRepository(),
+		Storage:       storage.NewLocal(t.TempDir()),
+		Logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Metrics:       metrics.New(),
+		Health:        health.NewChecker(),
+		Limiter:       ratelimit.NewLimiter(store, cfg.RateLimit),
+		Config:        config.NewManager(cfg),
+		Spec:          spec,
+		Graph:         schema,
+	}
+}
+
+// newTestServer serves an empty server over HTTP and returns its URL.
+func newTestServer(t *testing.T) string {
+	t.Helper()
+	deps := newTestDeps(t)
+	srv := httptest.NewServer(setupRouter(deps, newServices(deps)))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// testUser returns a user with testPassword, to seed a session repository.
+func testUser(t *testing.T, email, role string) models.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	return models.User{
+		ID: uuid.New(), Email: email, PasswordHash: string(hash), Role: role,
+		CreatedAt: now, UpdatedAt: now,
+	}
+}
+
+// login returns an access token for the user with the email address.
+func login(t *testing.T, router http.Handler, email string) string {
+	t.Helper()
+	body, _ := json.Marshal(models.Credentials{Email: email, Password: testPassword})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login as %s: status %d: %s", email, rec.Code, rec.Body)
+	}
+	var tokens models.TokenPair
+	if err := json.Unmarshal(rec.Body.Bytes(), &tokens); err != nil {
+		t.Fatal(err)
+	}
+	return tokens.AccessToken
+}
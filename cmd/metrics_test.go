@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+func TestMetricsUseRouteTemplates(t *testing.T) {
+	api := newAPIRouter(t)
+	rec := api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Write release notes"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	id := decode[models.Task](t, rec.Body.Bytes()).ID.String()
+	missing := uuid.NewString()
+	wantStatus(t, api.do(http.MethodGet, "/api/v1/tasks/"+id, ""), http.StatusOK)
+	wantStatus(t, api.do(http.MethodGet, "/api/v1/tasks/"+id, ""), http.StatusOK)
+	wantStatus(t, api.do(http.MethodGet, "/api/v1/tasks/"+missing, ""), http.StatusNotFound)
+	if rec := api.do(http.MethodGet, "/no/such/"+missing, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("unmatched path: status %d", rec.Code)
+	}
+
+	rec = api.do(http.MethodGet, "/metrics", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics: status %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`taskapi_http_requests_total{method="POST",route="/api/v1/tasks/",status="201"} 1`,
+		`taskapi_http_requests_total{method="GET",route="/api/v1/tasks/:id",status="200"} 2`,
+		`taskapi_http_requests_total{method="GET",route="/api/v1/tasks/:id",status="404"} 1`,
+		`taskapi_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`taskapi_http_request_duration_seconds_count{method="GET",route="/api/v1/tasks/:id"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics lacks %s", want)
+		}
+	}
+	for _, raw := range []string{id, missing} {
+		if strings.Contains(body, raw) {
+			t.Errorf("/metrics labels a raw path with %s", raw)
+		}
+	}
+}
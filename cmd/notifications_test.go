@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+)
+
+// failingNotifications fails to store the notifications of one task, and to
+// look up the preferences of one user.
+type failingNotifications struct {
+	repositories.NotificationRepository
+	taskID, userID uuid.UUID
+}
+
+func (r *failingNotifications) CreateOnce(ctx context.Context, n *models.Notification) (bool, error) {
+	if n.TaskID == r.taskID {
+		return false, errors.New("disk full")
+	}
+	return r.NotificationRepository.CreateOnce(ctx, n)
+}
+
+func (r *failingNotifications) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	if userID == r.userID.String() {
+		return nil, errors.New("connection reset")
+	}
+	return r.NotificationRepository.GetPreferences(ctx, userID)
+}
+
+// newNotifiedRouters returns routers for two users of the same server, who
+// can mention each other and carol, and the server's notification repository.
+func newNotifiedRouters(t *testing.T) (ada, bob *apiRouter, svc apiServices, notifications *failingNotifications) {
+	t.Helper()
+	users := []models.User{testUser(t, "ada@example.com", "user"), testUser(t, "bob@example.com", "user"),
+		testUser(t, "carol@example.com", "user")}
+	deps := newTestDeps(t)
+	deps.Sessions = repositories.NewMemorySessionRepository(users...)
+	notifications = &failingNotifications{NotificationRepository: repositories.NewMemoryNotificationRepository(users...)}
+	deps.Notifications = notifications
+	svc = newServices(deps)
+	router := setupRouter(deps, svc)
+	return &apiRouter{t: t, router: router, token: login(t, router, "ada@example.com")},
+		&apiRouter{t: t, router: router, token: login(t, router, "bob@example.com")}, svc, notifications
+}
+
+func TestNotificationsAreTheCallers(t *testing.T) {
+	ada, bob, _, _ := newNotifiedRouters(t)
+	rec := bob.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Bob's"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	bobID := *decode[models.Task](t, rec.Body.Bytes()).UserID
+
+	// Ada isn't notified of her own change, even where she mentions herself.
+	rec = ada.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Review"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	taskPath := "/api/v1/tasks/" + decode[models.Task](t, rec.Body.Bytes()).ID.String()
+	wantStatus(t, ada.do(http.MethodPut, taskPath,
+		`{"user_id": "`+bobID.String()+`", "description": "@ada and @bob, please look"}`), http.StatusOK)
+	rec = ada.do(http.MethodGet, "/api/v1/notifications", "")
+	wantStatus(t, rec, http.StatusOK)
+	if got := decode[[]models.Notification](t, rec.Body.Bytes()); len(got) != 0 {
+		t.Errorf("the actor was notified: %+v", got)
+	}
+	rec = bob.do(http.MethodGet, "/api/v1/notifications", "")
+	wantStatus(t, rec, http.StatusOK)
+	got := decode[[]models.Notification](t, rec.Body.Bytes())
+	if len(got) != 1 || got[0].Type != models.NotificationAssigned || got[0].ActorID == nil || *got[0].ActorID == bobID {
+		t.Fatalf("Bob got %+v, want one assigned notification from Ada", got)
+	}
+	readPath := "/api/v1/notifications/" + got[0].ID.String() + "/read"
+
+	// Naming another user doesn't reach their notifications.
+	rec = ada.do(http.MethodGet, "/api/v1/notifications?user_id="+bobID.String(), "")
+	wantStatus(t, rec, http.StatusOK)
+	if got := decode[[]models.Notification](t, rec.Body.Bytes()); len(got) != 0 {
+		t.Errorf("user_id listed another user's notifications: %+v", got)
+	}
+	wantStatus(t, ada.do(http.MethodPost, readPath, ""), http.StatusForbidden)
+	wantStatus(t, ada.do(http.MethodPost, "/api/v1/notifications/read-all", ""), http.StatusOK)
+	anonymous := *bob
+	anonymous.token = ""
+	wantStatus(t, anonymous.do(http.MethodGet, "/api/v1/notifications", ""), http.StatusUnauthorized)
+
+	rec = bob.do(http.MethodGet, "/api/v1/notifications/unread-count", "")
+	wantStatus(t, rec, http.StatusOK)
+	if count := decode[models.NotificationCount](t, rec.Body.Bytes()); count.Count != 1 {
+		t.Errorf("Bob has %d unread notifications after Ada read hers, want 1", count.Count)
+	}
+	wantStatus(t, bob.do(http.MethodPost, readPath, ""), http.StatusOK)
+	rec = bob.do(http.MethodGet, "/api/v1/notifications/unread-count", "")
+	wantStatus(t, rec, http.StatusOK)
+	if count := decode[models.NotificationCount](t, rec.Body.Bytes()); count.Count != 0 {
+		t.Errorf("Bob has %d unread notifications, want 0", count.Count)
+	}
+}
+
+func TestDueSoonSkipsNotificationsThatFail(t *testing.T) {
+	ada, _, svc, notifications := newNotifiedRouters(t)
+	due := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	var tasks []models.Task
+	for _, title := range []string{"Fails", "Sent"} {
+		rec := ada.do(http.MethodPost, "/api/v1/tasks/", `{"title": "`+title+`", "due_date": "`+due+`"}`)
+		wantStatus(t, rec, http.StatusCreated)
+		tasks = append(tasks, decode[models.Task](t, rec.Body.Bytes()))
+	}
+	notifications.taskID = tasks[0].ID
+
+	var logs bytes.Buffer
+	ctx := logger.WithContext(context.Background(), slog.New(slog.NewTextHandler(&logs, nil)))
+	n, err := svc.Notifications.NotifyDueSoon(ctx, 24*time.Hour)
+	if err != nil || n != 1 {
+		t.Fatalf("NotifyDueSoon = %d, %v; want 1 notified despite the failure", n, err)
+	}
+	if !strings.Contains(logs.String(), tasks[0].ID.String()) {
+		t.Errorf("the failure wasn't logged: %q", logs.String())
+	}
+	rec := ada.do(http.MethodGet, "/api/v1/notifications", "")
+	wantStatus(t, rec, http.StatusOK)
+	if got := decode[[]models.Notification](t, rec.Body.Bytes()); len(got) != 1 || got[0].TaskID != tasks[1].ID {
+		t.Errorf("notifications %+v, want one due soon for %s", got, tasks[1].ID)
+	}
+
+	// The failed one is sent on the next scan.
+	notifications.taskID = uuid.Nil
+	if n, err := svc.Notifications.NotifyDueSoon(ctx, 24*time.Hour); err != nil || n != 1 {
+		t.Errorf("second NotifyDueSoon = %d, %v; want the failed one sent", n, err)
+	}
+}
+
+func TestNotificationsReachTheOtherRecipientsWhenOneFails(t *testing.T) {
+	ada, bob, _, notifications := newNotifiedRouters(t)
+	rec := bob.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Bob's"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	bobID := *decode[models.Task](t, rec.Body.Bytes()).UserID
+	carolIDs, err := notifications.MentionedUsers(context.Background(), []string{"carol"})
+	if err != nil || len(carolIDs) != 1 {
+		t.Fatalf("MentionedUsers = %v, %v", carolIDs, err)
+	}
+	notifications.userID = bobID
+
+	rec = ada.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Review"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	task := decode[models.Task](t, rec.Body.Bytes())
+	wantStatus(t, ada.do(http.MethodPut, "/api/v1/tasks/"+task.ID.String(),
+		`{"user_id": "`+bobID.String()+`", "description": "@carol, please help"}`), http.StatusOK)
+
+	got, err := notifications.GetByUserID(context.Background(), carolIDs[0].String(), false, 0, 10)
+	if err != nil || len(got) != 1 || got[0].Type != models.NotificationMentioned || got[0].TaskID != task.ID {
+		t.Errorf("Carol got %+v, %v; want the mention despite Bob's failure", got, err)
+	}
+	if got, err := notifications.GetByUserID(context.Background(), bobID.String(), false, 0, 10); err != nil || len(got) != 0 {
+		t.Errorf("Bob got %+v, %v; want nothing", got, err)
+	}
+}
@@ -0,0 +1,11 @@
+package main
+
+import "testing"
+
+func TestRoutesMatchOpenAPI(t *testing.T) {
+	deps := newTestDeps(t)
+	router := setupRouter(deps, newServices(deps))
+	if err := deps.Spec.CheckRoutes(router.Routes(), routeQueries, swaggerRoute); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/grpcapi"
+	"github.com/sampathreddy22/task-management-api/internal/grpcapi/paritytest"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+)
+
+func TestRESTAndGRPCParity(t *testing.T) {
+	paritytest.Tasks(t, func(t *testing.T) paritytest.Harness {
+		deps := newTestDeps(t)
+		deps.Sessions = repositories.NewMemorySessionRepository(testUser(t, "ada@example.com", "user"))
+		svc := newServices(deps)
+		router := setupRouter(deps, svc)
+		srv := grpcapi.New(grpcapi.Services{
+			Tasks:       svc.Tasks,
+			Comments:    svc.Comments,
+			Attachments: svc.Attachments,
+			Users:       deps.Users,
+		}, grpcapi.Options{Logger: deps.Logger, Authenticate: grpcAuthenticator(svc.Auth)})
+		return paritytest.Harness{
+			REST:  router,
+			GRPC:  paritytest.Dial(t, srv),
+			Token: login(t, router, "ada@example.com"),
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// loginFrom tries to log in with a wrong password from the test client's
+// address, 192.0.2.1, claiming to forward for the given address.
+func loginFrom(router http.Handler, forwardedFor string) int {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login",
+		strings.NewReader(`{"email": "ada@example.com", "password": "not the password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	t.Setenv("TASKAPI_RATE_LIMIT_ENABLED", "true")
+	deps := newTestDeps(t)
+	router := setupRouter(deps, newServices(deps))
+
+	burst := deps.Config.Current().RateLimit.Groups["auth"].Burst
+	for i := 0; i < burst; i++ {
+		if code := loginFrom(router, "198.51.100."+strconv.Itoa(i)); code != http.StatusUnauthorized {
+			t.Fatalf("login %d: status %d, want 401", i, code)
+		}
+	}
+	if code := loginFrom(router, "198.51.100.250"); code != http.StatusTooManyRequests {
+		t.Errorf("a new X-Forwarded-For reset the quota: status %d, want 429", code)
+	}
+}
+
+func TestRateLimitTrustsConfiguredProxies(t *testing.T) {
+	t.Setenv("TASKAPI_RATE_LIMIT_ENABLED", "true")
+	t.Setenv("TASKAPI_SERVER_TRUSTED_PROXIES", "192.0.2.0/24")
+	deps := newTestDeps(t)
+	router := setupRouter(deps, newServices(deps))
+
+	burst := deps.Config.Current().RateLimit.Groups["auth"].Burst
+	for i := 0; i <= burst; i++ {
+		if code := loginFrom(router, "198.51.100."+strconv.Itoa(i)); code != http.StatusUnauthorized {
+			t.Fatalf("login %d behind the proxy: status %d, want 401", i, code)
+		}
+	}
+	for i := 0; i < burst; i++ {
+		loginFrom(router, "198.51.100.250")
+	}
+	if code := loginFrom(router, "198.51.100.250"); code != http.StatusTooManyRequests {
+		t.Errorf("the client behind the proxy wasn't limited: status %d, want 429", code)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/server"
+)
+
+func TestShutdownEndsEventStreams(t *testing.T) {
+	deps := newTestDeps(t)
+	deps.Sessions = repositories.NewMemorySessionRepository(testUser(t, "ada@example.com", "user"))
+	stopping := make(chan struct{})
+	deps.Stopping = stopping
+	router := setupRouter(deps, newServices(deps))
+	token := login(t, router, "ada@example.com")
+
+	const timeout = 5 * time.Second
+	srv := server.New(config.ServerConfig{Timeout: timeout}, router, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	srv.OnStop(func() { close(stopping) })
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, lis) }()
+
+	// Streams that outlive shutdown fail the test rather than hang it.
+	client := &http.Client{Timeout: 2 * timeout}
+	open := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, "http://"+lis.Addr().String()+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", "text/event-stream")
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s %s: status %d", method, path, resp.StatusCode)
+		}
+		return resp
+	}
+	streams := map[string]*http.Response{
+		"notifications": open(http.MethodGet, "/api/v1/notifications/stream", ""),
+		"subscription": open(http.MethodPost, "/api/v1/graphql",
+			`{"query": "subscription { taskChanged { type taskId } }"}`),
+	}
+
+	start := time.Now()
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatalf("Serve: %v", err)
+		}
+	case <-time.After(2 * timeout):
+		t.Fatal("Serve didn't return")
+	}
+	if took := time.Since(start); took >= timeout {
+		t.Errorf("shutdown took %v, waiting out the timeout for open streams", took)
+	}
+	for name, resp := range streams {
+		if _, err := io.ReadAll(resp.Body); err != nil {
+			t.Errorf("the %s stream didn't end cleanly: %v", name, err)
+		}
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newAttachCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "attach",
+		Aliases: []string{"attachment"},
+		Short:   "Upload and download task attachments",
+	}
+	cmd.AddCommand(newAttachUploadCommand(a), newAttachDownloadCommand(a))
+	return cmd
+}
+
+func newAttachUploadCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "upload TASK_ID FILE",
+		Short: "Upload a file as an attachment of a task",
+		Args:  cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return a.completeTaskIDs(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveDefault
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			taskID, path := args[0], args[1]
+			if err := validID(taskID); err != nil {
+				return err
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			attachment, err := a.client.Attachments.Upload(cmd.Context(), taskID, path, f)
+			if err != nil {
+				return err
+			}
+			return a.print(cmd.OutOrStdout(), attachment, func() table {
+				return table{
+					header: []string{"ID", "TASK", "FILE", "UPLOADED"},
+					rows: [][]string{{
+						attachment.ID, attachment.TaskID,
+						attachment.FileName, formatTime(&attachment.UploadedAt),
+					}},
+				}
+			})
+		},
+	}
+}
+
+func newAttachDownloadCommand(a *app) *cobra.Command {
+	var dest string
+	cmd := &cobra.Command{
+		Use:   "download ATTACHMENT_ID",
+		Short: "Download an attachment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			if err := validID(id); err != nil {
+				return err
+			}
+			if dest == "-" {
+				_, err := a.client.Attachments.Download(cmd.Context(), id, cmd.OutOrStdout())
+				return err
+			}
+
+			// The server names the file, so download next to the destination
+			// first and move it into place once the name is known.
+			dir := "."
+			if dest != "" {
+				dir = filepath.Dir(dest)
+			}
+			f, err := os.CreateTemp(dir, ".taskctl-download-*")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(f.Name())
+
+			name, err := a.client.Attachments.Download(cmd.Context(), id, f)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+			if dest == "" {
+				dest = downloadName(name, id)
+			}
+			if _, err := os.Stat(dest); err == nil {
+				return fmt.Errorf("%s already exists", dest)
+			}
+			if err := os.Rename(f.Name(), dest); err != nil {
+				return err
+			}
+			if info, err := os.Stat(dest); err == nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Saved %s (%d bytes)\n", dest, info.Size())
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&dest, "file", "f", "", `destination file, "-" for standard output (default: the uploaded file name)`)
+	return cmd
+}
+
+// downloadName uses the file name the server suggested, falling back to the
+// attachment ID. Directory parts are dropped so a server can't choose where
+// the file is written.
+func downloadName(suggested, id string) string {
+	if name := filepath.Base(suggested); suggested != "" && name != "." && name != ".." && name != "/" {
+		return name
+	}
+	return id
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sampathreddy22/task-management-api/pkg/client"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newSignupCommand(a *app) *cobra.Command {
+	var email string
+	var passwordStdin bool
+	cmd := &cobra.Command{
+		Use:   "signup",
+		Short: "Create an account, then log in with it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if email == "" {
+				return errors.New("--email is required")
+			}
+			password, err := readPassword(cmd, passwordStdin)
+			if err != nil {
+				return err
+			}
+			user, err := a.client.Auth.Signup(cmd.Context(), client.CreateUserInput{Email: email, Password: password})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created account %s (%s)\n", user.Email, user.ID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "account email")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "read the password from standard input")
+	return cmd
+}
+
+func newLoginCommand(a *app) *cobra.Command {
+	var email string
+	var passwordStdin bool
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Authenticate and store credentials in the config file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if email == "" {
+				email = a.cfg.Email
+			}
+			if email == "" {
+				return errors.New("--email is required")
+			}
+
+			password, err := readPassword(cmd, passwordStdin)
+			if err != nil {
+				return err
+			}
+			if _, err := a.client.Auth.Login(cmd.Context(), email, password); err != nil {
+				return err
+			}
+
+			a.cfg.Email = email
+			if err := a.cfg.save(a.configPath); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Logged in to %s as %s\n", a.cfg.Server, email)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&email, "email", "", "account email (defaults to the last one used)")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "read the password from standard input")
+	return cmd
+}
+
+func newLogoutCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Invalidate the session and remove stored tokens",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if a.cfg.AccessToken != "" {
+				// Best effort: the tokens are dropped locally either way.
+				if err := a.client.Auth.Logout(cmd.Context()); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), "warning: server logout failed:", err)
+				}
+			}
+			a.cfg.clearTokens()
+			return a.cfg.save(a.configPath)
+		},
+	}
+}
+
+func readPassword(cmd *cobra.Command, fromStdin bool) (string, error) {
+	if fromStdin || !term.IsTerminal(int(os.Stdin.Fd())) {
+		line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(cmd.ErrOrStderr(), "Password: ")
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(cmd.ErrOrStderr())
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newCommentCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment",
+		Short: "Comment on tasks",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:               "add TASK_ID TEXT...",
+		Short:             "Add a comment to a task",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validID(args[0]); err != nil {
+				return err
+			}
+			comment, err := a.client.Comments.Create(cmd.Context(), args[0], strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+			return a.print(cmd.OutOrStdout(), comment, func() table {
+				return table{
+					header: []string{"ID", "TASK", "CREATED", "CONTENT"},
+					rows: [][]string{{
+						comment.ID, comment.TaskID,
+						formatTime(&comment.CreatedAt), truncate(comment.Content, 60),
+					}},
+				}
+			})
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// cliConfig is persisted between runs. It holds tokens, so it is written
+// readable by the owner only.
+type cliConfig struct {
+	Server       string    `yaml:"server"`
+	Email        string    `yaml:"email,omitempty"`
+	AccessToken  string    `yaml:"access_token,omitempty"`
+	RefreshToken string    `yaml:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `yaml:"expires_at,omitempty"`
+}
+
+// defaultConfigPath honours TASKCTL_CONFIG, then the user config directory.
+func defaultConfigPath() string {
+	if path := os.Getenv("TASKCTL_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".taskctl.yaml"
+	}
+	return filepath.Join(dir, "taskctl", "config.yaml")
+}
+
+// loadConfig reads the config file; a missing file yields the defaults.
+func loadConfig(path string) (*cliConfig, error) {
+	cfg := &cliConfig{Server: defaultServer}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.Server == "" {
+		cfg.Server = defaultServer
+	}
+	return cfg, nil
+}
+
+func (c *cliConfig) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *cliConfig) clearTokens() {
+	c.AccessToken = ""
+	c.RefreshToken = ""
+	c.ExpiresAt = time.Time{}
+}
@@ -0,0 +1,114 @@
+// Command taskctl manages tasks through the Task Management API.
+//
+// Run "taskctl signup" to create an account and "taskctl login" once to
+// store credentials, then for example:
+//
+//	taskctl task list --status todo -o yaml
+//	taskctl task create --title "Write release notes" --priority 2
+//	taskctl task done 6f1c...
+//
+// Shell completion scripts are printed by "taskctl completion <shell>".
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/sampathreddy22/task-management-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// app holds the state shared by every command.
+type app struct {
+	configPath string
+	server     string
+	output     string
+
+	cfg    *cliConfig
+	client *client.Client
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := newRootCommand().ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		if client.StatusCode(err) == http.StatusUnauthorized {
+			fmt.Fprintln(os.Stderr, `run "taskctl login" to authenticate`)
+		}
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	a := &app{}
+	root := &cobra.Command{
+		Use:           "taskctl",
+		Short:         "Manage tasks from the command line",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return a.init()
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.configPath, "config", defaultConfigPath(), "credentials and settings file")
+	flags.StringVar(&a.server, "server", "", "API base URL, e.g. http://localhost:8080 (overrides the config file)")
+	flags.StringVarP(&a.output, "output", "o", "table", "output format: table, json or yaml")
+	root.RegisterFlagCompletionFunc("output", fixedCompletions(outputFormats...))
+
+	root.AddCommand(
+		newSignupCommand(a),
+		newLoginCommand(a),
+		newLogoutCommand(a),
+		newTaskCommand(a),
+		newCommentCommand(a),
+		newAttachCommand(a),
+	)
+	return root
+}
+
+func (a *app) init() error {
+	if !validOutput(a.output) {
+		return fmt.Errorf("unknown output format %q, use one of %v", a.output, outputFormats)
+	}
+	cfg, err := loadConfig(a.configPath)
+	if err != nil {
+		return err
+	}
+	if a.server != "" {
+		cfg.Server = a.server
+	}
+	a.cfg = cfg
+
+	opts := []client.Option{
+		client.WithUserAgent("taskctl"),
+		client.WithTokenRefreshed(func(token client.Token) {
+			cfg.AccessToken, cfg.RefreshToken, cfg.ExpiresAt = token.AccessToken, token.RefreshToken, token.Expiry
+			if err := cfg.save(a.configPath); err != nil {
+				fmt.Fprintln(os.Stderr, "warning:", err)
+			}
+		}),
+	}
+	if cfg.AccessToken != "" {
+		opts = append(opts, client.WithToken(client.Token{
+			AccessToken:  cfg.AccessToken,
+			RefreshToken: cfg.RefreshToken,
+			Expiry:       cfg.ExpiresAt,
+		}))
+	}
+	a.client, err = client.New(cfg.Server, opts...)
+	return err
+}
+
+// fixedCompletions completes a flag or argument from a fixed list.
+func fixedCompletions(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func newTaskCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "task",
+		Aliases: []string{"tasks"},
+		Short:   "List, show and change tasks",
+	}
+	cmd.AddCommand(
+		newTaskListCommand(a),
+		newTaskShowCommand(a),
+		newTaskCreateCommand(a),
+		newTaskEditCommand(a),
+		newTaskDoneCommand(a),
+		newTaskDeleteCommand(a),
+	)
+	return cmd
+}
+
+func newTaskListCommand(a *app) *cobra.Command {
+	var opts client.ListTasksOptions
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tasks, optionally filtered like GET /tasks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tasks, err := a.client.Tasks.List(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			return a.print(cmd.OutOrStdout(), tasks, func() table { return taskTable(tasks...) })
+		},
+	}
+
+	// One flag per GET /tasks query parameter.
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Status, "status", "", "only tasks with this status")
+	flags.IntVar(&opts.Priority, "priority", 0, "only tasks with this priority (1-5)")
+	flags.StringVar(&opts.UserID, "user-id", "", "only tasks owned by this user (user_id)")
+	flags.StringVarP(&opts.Query, "query", "q", "", "search titles and descriptions (q)")
+	flags.StringVar(&opts.Sort, "sort", "", "field to sort by, descending with a - prefix, such as -due_date")
+	flags.IntVar(&opts.Page, "page", 1, "page number")
+	flags.IntVar(&opts.Limit, "limit", 20, "tasks per page (1-100)")
+	cmd.RegisterFlagCompletionFunc("status", fixedCompletions(models.TaskStatuses...))
+	cmd.RegisterFlagCompletionFunc("priority", fixedCompletions("1", "2", "3", "4", "5"))
+	sorts := slices.Clone(models.TaskSorts)
+	for _, field := range models.TaskSorts {
+		sorts = append(sorts, "-"+field)
+	}
+	cmd.RegisterFlagCompletionFunc("sort", fixedCompletions(sorts...))
+	return cmd
+}
+
+func newTaskShowCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "show TASK_ID",
+		Short:             "Show a task",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			task, err := a.getTask(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return a.print(cmd.OutOrStdout(), task, func() table { return taskDetail(task) })
+		},
+	}
+}
+
+func newTaskCreateCommand(a *app) *cobra.Command {
+	var input client.CreateTaskInput
+	var due string
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a task",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if due != "" {
+				t, err := parseDue(due)
+				if err != nil {
+					return err
+				}
+				input.DueDate = &t
+			}
+
+			task, err := a.client.Tasks.Create(cmd.Context(), input)
+			if err != nil {
+				return err
+			}
+			return a.print(cmd.OutOrStdout(), task, func() table { return taskTable(*task) })
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&input.Title, "title", "", "task title (required)")
+	flags.StringVar(&input.Description, "description", "", "task description")
+	flags.StringVar(&input.Status, "status", "", "initial status (default todo)")
+	flags.IntVar(&input.Priority, "priority", 0, "priority from 1 (highest) to 5 (default 3)")
+	flags.StringVar(&due, "due", "", "due date, RFC 3339 or YYYY-MM-DD")
+	cmd.MarkFlagRequired("title")
+	cmd.RegisterFlagCompletionFunc("status", fixedCompletions(models.TaskStatuses...))
+	cmd.RegisterFlagCompletionFunc("priority", fixedCompletions("1", "2", "3", "4", "5"))
+	return cmd
+}
+
+func newTaskEditCommand(a *app) *cobra.Command {
+	var title, description, status, due string
+	var priority int
+	cmd := &cobra.Command{
+		Use:               "edit TASK_ID",
+		Short:             "Change the given fields of a task",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Only flags given on the command line are sent, so the others stay unchanged.
+			var input client.UpdateTaskInput
+			flags := cmd.Flags()
+			if flags.Changed("title") {
+				input.Title = &title
+			}
+			if flags.Changed("description") {
+				input.Description = &description
+			}
+			if flags.Changed("status") {
+				input.Status = &status
+			}
+			if flags.Changed("priority") {
+				input.Priority = &priority
+			}
+			if flags.Changed("due") {
+				t, err := parseDue(due)
+				if err != nil {
+					return err
+				}
+				input.DueDate = &t
+			}
+			if flags.NFlag() == 0 {
+				return fmt.Errorf("nothing to change, pass at least one of --title, --description, --status, --priority or --due")
+			}
+
+			task, err := a.updateTask(cmd.Context(), args[0], input)
+			if err != nil {
+				return err
+			}
+			return a.print(cmd.OutOrStdout(), task, func() table { return taskTable(*task) })
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&title, "title", "", "new title")
+	flags.StringVar(&description, "description", "", "new description")
+	flags.StringVar(&status, "status", "", "new status")
+	flags.IntVar(&priority, "priority", 0, "new priority (1-5)")
+	flags.StringVar(&due, "due", "", "new due date, RFC 3339 or YYYY-MM-DD")
+	cmd.RegisterFlagCompletionFunc("status", fixedCompletions(models.TaskStatuses...))
+	cmd.RegisterFlagCompletionFunc("priority", fixedCompletions("1", "2", "3", "4", "5"))
+	return cmd
+}
+
+func newTaskDoneCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "done TASK_ID...",
+		Short:             "Mark tasks as done",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			done := client.StatusDone
+			tasks := make([]client.Task, 0, len(args))
+			for _, id := range args {
+				task, err := a.updateTask(cmd.Context(), id, client.UpdateTaskInput{Status: &done})
+				if err != nil {
+					return fmt.Errorf("task %s: %w", id, err)
+				}
+				tasks = append(tasks, *task)
+			}
+			return a.print(cmd.OutOrStdout(), tasks, func() table { return taskTable(tasks...) })
+		},
+	}
+}
+
+func newTaskDeleteCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete TASK_ID...",
+		Aliases:           []string{"rm"},
+		Short:             "Delete tasks",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: a.completeTaskIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, id := range args {
+				if err := validID(id); err != nil {
+					return err
+				}
+				if err := a.client.Tasks.Delete(cmd.Context(), id); err != nil {
+					return fmt.Errorf("task %s: %w", id, err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Deleted task %s\n", id)
+			}
+			return nil
+		},
+	}
+}
+
+func (a *app) getTask(ctx context.Context, id string) (*client.Task, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	return a.client.Tasks.Get(ctx, id)
+}
+
+func (a *app) updateTask(ctx context.Context, id string, input client.UpdateTaskInput) (*client.Task, error) {
+	if err := validID(id); err != nil {
+		return nil, err
+	}
+	return a.client.Tasks.Update(ctx, id, input)
+}
+
+// completeTaskIDs offers the IDs of the first page of tasks, described by
+// their titles.
+func (a *app) completeTaskIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if err := a.init(); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tasks, err := a.client.Tasks.List(ctx, client.ListTasksOptions{Limit: 100})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	completions := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		completions = append(completions, task.ID+"\t"+truncate(task.Title, 40))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func validID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("%q is not a valid ID", id)
+	}
+	return nil
+}
+
+// parseDue accepts a full RFC 3339 timestamp or a date, which means the end
+// of that day in local time.
+func parseDue(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date %q, use RFC 3339 or YYYY-MM-DD", s)
+	}
+	return d.Add(24*time.Hour - time.Second), nil
+}
+
+func taskTable(tasks ...client.Task) table {
+	t := table{header: []string{"ID", "TITLE", "STATUS", "PRIORITY", "DUE"}}
+	for _, task := range tasks {
+		t.rows = append(t.rows, []string{
+			task.ID,
+			truncate(task.Title, 50),
+			task.Status,
+			strconv.Itoa(task.Priority),
+			formatTime(task.DueDate),
+		})
+	}
+	return t
+}
+
+func taskDetail(task *client.Task) table {
+	owner := "-"
+	if task.UserID != nil {
+		owner = *task.UserID
+	}
+	return table{
+		header: []string{"FIELD", "VALUE"},
+		rows: [][]string{
+			{"ID", task.ID},
+			{"Title", task.Title},
+			{"Status", task.Status},
+			{"Priority", strconv.Itoa(task.Priority)},
+			{"Due", formatTime(task.DueDate)},
+			{"Owner", owner},
+			{"Created", formatTime(&task.CreatedAt)},
+			{"Updated", formatTime(&task.UpdatedAt)},
+			{"Description", truncate(task.Description, 200)},
+			{"Comments", strconv.Itoa(len(task.Comments))},
+			{"Attachments", strconv.Itoa(len(task.Attachments))},
+		},
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/openapi"
+)
+
+const (
+	taskID       = "6f1c0e8a-3a43-4c51-9f0e-2f4d1b7c9a01"
+	attachmentID = "0b9d7c1e-5f2a-4e36-8d4b-7a1c2e3f4a5b"
+)
+
+// fakeAPI serves the endpoints taskctl calls behind the OpenAPI request
+// validation, so requests the document doesn't describe fail the test.
+type fakeAPI struct {
+	mu     sync.Mutex
+	called []string // operation IDs
+	sort   string   // of the last listTasks call
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, string) {
+	t.Helper()
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gin.SetMode(gin.TestMode)
+	api := &fakeAPI{}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		op, ok := spec.Operation(c.Request.Method, c.FullPath())
+		if !ok {
+			t.Errorf("%s %s is not a documented operation", c.Request.Method, c.Request.URL.Path)
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		api.mu.Lock()
+		api.called = append(api.called, op.ID)
+		api.mu.Unlock()
+		c.Next()
+		if c.Writer.Status() >= http.StatusBadRequest {
+			t.Errorf("%s %s: status %d", c.Request.Method, c.Request.URL.Path, c.Writer.Status())
+		}
+	}, spec.Middleware())
+
+	v1 := router.Group("/api/v1")
+	v1.POST("/signup", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{
+			"id": "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", "email": "ada@example.com", "role": "user",
+			"created_at": time.Now(), "updated_at": time.Now(),
+		})
+	})
+	v1.POST("/login", api.tokens)
+	v1.POST("/refresh", api.tokens)
+	v1.POST("/logout", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	v1.GET("/tasks/", func(c *gin.Context) {
+		api.mu.Lock()
+		api.sort = c.Query("sort")
+		api.mu.Unlock()
+		c.JSON(http.StatusOK, []gin.H{{
+			"id": taskID, "title": "Write release notes", "status": "todo", "priority": 2, "labels": []string{},
+			"created_at": time.Now(), "updated_at": time.Now(),
+		}})
+	})
+	v1.POST("/tasks/:id/comments", func(c *gin.Context) {
+		var in struct {
+			Content string `json:"content"`
+		}
+		if err := c.ShouldBindJSON(&in); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{
+			"id": "1d2c3b4a-5f6e-4d7c-8b9a-0f1e2d3c4b5a", "task_id": c.Param("id"),
+			"content": in.Content, "created_at": time.Now(),
+		})
+	})
+	v1.POST("/tasks/:id/attachments", func(c *gin.Context) {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{
+			"id": attachmentID, "task_id": c.Param("id"), "file_name": file.Filename,
+			"file_path": "uploads/" + file.Filename, "content_type": "text/plain", "uploaded_at": time.Now(),
+		})
+	})
+	v1.GET("/attachments/:id/content", func(c *gin.Context) {
+		c.Header("Content-Disposition", `attachment; filename="../notes.txt"`)
+		c.Data(http.StatusOK, "text/plain", []byte("release notes\n"))
+	})
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return api, srv.URL
+}
+
+func (api *fakeAPI) tokens(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": "access", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 900,
+	})
+}
+
+func (api *fakeAPI) calls() []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]string(nil), api.called...)
+}
+
+// run executes taskctl with args against the server and returns its
+// standard output.
+func run(t *testing.T, server, configPath, stdin string, args ...string) string {
+	t.Helper()
+	root := newRootCommand()
+	var stdout, stderr bytes.Buffer
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs(append([]string{"--server", server, "--config", configPath}, args...))
+	if err := root.Execute(); err != nil {
+		t.Fatalf("taskctl %s: %v\n%s", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestCommands(t *testing.T) {
+	api, server := newFakeAPI(t)
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+
+	run(t, server, configPath, "correct horse battery\n", "signup", "--email", "ada@example.com", "--password-stdin")
+	run(t, server, configPath, "correct horse battery\n", "login", "--email", "ada@example.com", "--password-stdin")
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AccessToken != "access" || cfg.RefreshToken != "refresh" || cfg.Email != "ada@example.com" {
+		t.Fatalf("login stored %+v", cfg)
+	}
+
+	if out := run(t, server, configPath, "", "task", "list", "--sort", "-due_date"); !strings.Contains(out, "Write release notes") {
+		t.Errorf("task list printed %q", out)
+	}
+	api.mu.Lock()
+	if api.sort != "-due_date" {
+		t.Errorf("task list sent sort %q, want -due_date", api.sort)
+	}
+	api.mu.Unlock()
+
+	if out := run(t, server, configPath, "", "comment", "add", taskID, "ship", "it"); !strings.Contains(out, "ship it") {
+		t.Errorf("comment add printed %q", out)
+	}
+
+	upload := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(upload, []byte("release notes\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if out := run(t, server, configPath, "", "attach", "upload", taskID, upload); !strings.Contains(out, attachmentID) {
+		t.Errorf("attach upload printed %q", out)
+	}
+
+	// The server's file name is used without its directory part.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	if err := os.Remove(upload); err != nil {
+		t.Fatal(err)
+	}
+	run(t, server, configPath, "", "attach", "download", attachmentID)
+	if data, err := os.ReadFile(upload); err != nil || string(data) != "release notes\n" {
+		t.Errorf("downloaded %q, %v", data, err)
+	}
+	if out := run(t, server, configPath, "", "attach", "download", attachmentID, "-f", "-"); out != "release notes\n" {
+		t.Errorf("download to stdout printed %q", out)
+	}
+
+	run(t, server, configPath, "", "logout")
+	if cfg, err = loadConfig(configPath); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AccessToken != "" || cfg.RefreshToken != "" {
+		t.Errorf("logout kept tokens %+v", cfg)
+	}
+
+	want := []string{
+		"signup", "login", "listTasks", "createComment", "uploadAttachment",
+		"downloadAttachment", "downloadAttachment", "logout",
+	}
+	if got := api.calls(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("called %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+// createTemplate creates a template and returns the path instantiating it.
+func createTemplate(t *testing.T, api *apiRouter, body string) string {
+	t.Helper()
+	rec := api.do(http.MethodPost, "/api/v1/templates", body)
+	wantStatus(t, rec, http.StatusCreated)
+	return "/api/v1/templates/" + decode[models.TaskTemplate](t, rec.Body.Bytes()).ID.String() + "/instantiate"
+}
+
+// taskTitles returns the titles of every task.
+func taskTitles(t *testing.T, api *apiRouter) []string {
+	t.Helper()
+	rec := api.do(http.MethodGet, "/api/v1/tasks/?limit=100", "")
+	wantStatus(t, rec, http.StatusOK)
+	var titles []string
+	for _, task := range decode[[]models.Task](t, rec.Body.Bytes()) {
+		titles = append(titles, task.Title)
+	}
+	return titles
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	api := newAPIRouter(t)
+	path := createTemplate(t, api, `{"name": "Release", "task": {
+		"title": "Release {{version}}", "description": "Ship {{ version }} to {{env}}.", "due_offset_days": 7,
+		"subtasks": [
+			{"title": "Freeze {{version}}", "due_offset_days": -2, "estimate_minutes": 30},
+			{"title": "QA", "due_offset_days": 3, "subtasks": [{"title": "Smoke test {{env}}", "priority": 1}]}
+		]}}`)
+
+	rec := api.do(http.MethodPost, path, `{"variables": {"version": "1.2"}}`)
+	wantStatus(t, rec, http.StatusUnprocessableEntity)
+	if problem := decode[middleware.Problem](t, rec.Body.Bytes()); len(problem.Errors) != 1 ||
+		problem.Errors[0].Field != "variables.env" {
+		t.Errorf("missing variable reported as %+v", problem.Errors)
+	}
+
+	anchor := time.Now().UTC().Truncate(time.Second).AddDate(0, 1, 0)
+	rec = api.do(http.MethodPost, path,
+		`{"variables": {"version": "1.2", "env": "production"}, "anchor": "`+anchor.Format(time.RFC3339)+`"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	tasks := decode[[]models.Task](t, rec.Body.Bytes())
+
+	type want struct {
+		title, description string
+		parent             int // index of the parent task, -1 for the root
+		dueOffsetDays      *int
+		priority           int
+	}
+	days := func(n int) *int { return &n }
+	wants := []want{
+		{"Release 1.2", "Ship 1.2 to production.", -1, days(7), models.DefaultTaskPriority},
+		{"Freeze 1.2", "", 0, days(-2), models.DefaultTaskPriority},
+		{"QA", "", 0, days(3), models.DefaultTaskPriority},
+		{"Smoke test production", "", 2, nil, 1},
+	}
+	if len(tasks) != len(wants) {
+		t.Fatalf("created %d tasks, want %d", len(tasks), len(wants))
+	}
+	for i, w := range wants {
+		task := tasks[i]
+		if task.Title != w.title || task.Description != w.description || task.Priority != w.priority {
+			t.Errorf("task %d is %q %q with priority %d, want %+v", i, task.Title, task.Description, task.Priority, w)
+		}
+		var wantParent *uuid.UUID
+		if w.parent >= 0 {
+			wantParent = &tasks[w.parent].ID
+		}
+		if (task.ParentID == nil) != (wantParent == nil) || task.ParentID != nil && *task.ParentID != *wantParent {
+			t.Errorf("task %q has parent %v, want %v", task.Title, task.ParentID, wantParent)
+		}
+		switch {
+		case w.dueOffsetDays == nil && task.DueDate != nil:
+			t.Errorf("task %q is due %v, want no due date", task.Title, task.DueDate)
+		case w.dueOffsetDays != nil && (task.DueDate == nil || !task.DueDate.Equal(anchor.AddDate(0, 0, *w.dueOffsetDays))):
+			t.Errorf("task %q is due %v, want %d days from %v", task.Title, task.DueDate, *w.dueOffsetDays, anchor)
+		}
+	}
+	if estimate := tasks[1].EstimateMinutes; estimate == nil || *estimate != 30 {
+		t.Errorf("the freeze has estimate %v, want 30 minutes", estimate)
+	}
+
+	// The tasks were stored as returned.
+	for _, task := range tasks {
+		rec := api.do(http.MethodGet, "/api/v1/tasks/"+task.ID.String(), "")
+		wantStatus(t, rec, http.StatusOK)
+		got := decode[models.Task](t, rec.Body.Bytes())
+		if got.Title != task.Title || (got.ParentID == nil) != (task.ParentID == nil) {
+			t.Errorf("stored %+v, returned %+v", got, task)
+		}
+	}
+}
+
+func TestInstantiateTemplateCreatesAllTasksOrNone(t *testing.T) {
+	api := newAPIRouter(t)
+	wantStatus(t, api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Existing"}`), http.StatusCreated)
+	path := createTemplate(t, api, `{"name": "Onboarding", "task": {"title": "Onboard {{name}}", "subtasks": [
+		{"title": "Laptop for {{name}}", "due_offset_days": 1},
+		{"title": "{{buddy}}", "subtasks": [{"title": "Lunch"}]}
+	]}}`)
+
+	tests := []struct {
+		name, body string
+		status     int
+		field      string
+	}{
+		{"a child's title is empty once filled in", `{"variables": {"name": "Ada", "buddy": " "}}`,
+			http.StatusUnprocessableEntity, "task.subtasks[1].title"},
+		{"a child is due in the past", `{"variables": {"name": "Ada", "buddy": "Bob"}, "anchor": "` +
+			time.Now().AddDate(0, 0, -2).Format(time.RFC3339) + `"}`,
+			http.StatusUnprocessableEntity, "task.subtasks[0].due_offset_days"},
+		// The column has room for the first three of the four tasks.
+		{"the last child goes past the WIP limit", `{"variables": {"name": "Ada", "buddy": "Bob"}}`,
+			http.StatusConflict, ""},
+	}
+	wantStatus(t, api.do(http.MethodPut, "/api/v1/board/columns/todo", `{"wip_limit": 4}`), http.StatusOK)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := api.do(http.MethodPost, path, tt.body)
+			wantStatus(t, rec, tt.status)
+			if problem := decode[middleware.Problem](t, rec.Body.Bytes()); tt.field != "" &&
+				(len(problem.Errors) != 1 || problem.Errors[0].Field != tt.field) {
+				t.Errorf("rejected with %+v, want an error on %s", problem.Errors, tt.field)
+			}
+			if titles := taskTitles(t, api); len(titles) != 1 {
+				t.Errorf("tasks %s were left behind", strings.Join(titles, ", "))
+			}
+		})
+	}
+
+	wantStatus(t, api.do(http.MethodPut, "/api/v1/board/columns/todo", `{"wip_limit": 5}`), http.StatusOK)
+	wantStatus(t, api.do(http.MethodPost, path, `{"variables": {"name": "Ada", "buddy": "Bob"}}`), http.StatusCreated)
+	if titles := taskTitles(t, api); len(titles) != 5 {
+		t.Errorf("tasks %s, want the existing one and four from the template", strings.Join(titles, ", "))
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+)
+
+// newAPIRouters returns routers for two users of the same server.
+func newAPIRouters(t *testing.T) (ada, bob *apiRouter) {
+	t.Helper()
+	deps := newTestDeps(t)
+	deps.Sessions = repositories.NewMemorySessionRepository(
+		testUser(t, "ada@example.com", "user"),
+		testUser(t, "bob@example.com", "user"),
+	)
+	router := setupRouter(deps, newServices(deps))
+	return &apiRouter{t: t, router: router, token: login(t, router, "ada@example.com")},
+		&apiRouter{t: t, router: router, token: login(t, router, "bob@example.com")}
+}
+
+func decode[T any](t *testing.T, body []byte) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(body, &v); err != nil {
+		t.Fatalf("decoding %s: %v", body, err)
+	}
+	return v
+}
+
+func TestTimeTrackingIsTheCallers(t *testing.T) {
+	ada, bob := newAPIRouters(t)
+	rec := ada.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Review"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	task := decode[models.Task](t, rec.Body.Bytes())
+
+	rec = ada.do(http.MethodPost, "/api/v1/tasks/"+task.ID.String()+"/timer/start", "")
+	wantStatus(t, rec, http.StatusCreated)
+	entry := decode[models.TimeEntry](t, rec.Body.Bytes())
+
+	// Bob has no timer of his own, and can't stop or edit Ada's.
+	wantStatus(t, bob.do(http.MethodGet, "/api/v1/timer", ""), http.StatusNotFound)
+	wantStatus(t, bob.do(http.MethodPost, "/api/v1/timer/stop", ""), http.StatusNotFound)
+	entryPath := "/api/v1/time-entries/" + entry.ID.String()
+	wantStatus(t, bob.do(http.MethodPut, entryPath, `{"note": "mine now"}`), http.StatusForbidden)
+	wantStatus(t, bob.do(http.MethodDelete, entryPath, ""), http.StatusForbidden)
+
+	anonymous := *ada
+	anonymous.token = ""
+	wantStatus(t, anonymous.do(http.MethodGet, "/api/v1/timer", ""), http.StatusUnauthorized)
+
+	rec = ada.do(http.MethodPost, "/api/v1/timer/stop", "")
+	wantStatus(t, rec, http.StatusOK)
+	if stopped := decode[models.TimeEntry](t, rec.Body.Bytes()); stopped.ID != entry.ID || stopped.Running() {
+		t.Errorf("stopped %+v, want entry %s ended", stopped, entry.ID)
+	}
+}
+
+func TestTimeReportByProjectAndLabel(t *testing.T) {
+	api := newAPIRouter(t)
+	rec := api.do(http.MethodPost, "/api/v1/projects", `{"name": "Acme"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	project := decode[models.Project](t, rec.Body.Bytes())
+
+	tasks := map[string]string{
+		"design": `{"title": "Design", "project_id": "` + project.ID.String() + `", "labels": ["billable", "ux"]}`,
+		"build":  `{"title": "Build", "project_id": "` + project.ID.String() + `", "labels": [" billable "]}`,
+		"admin":  `{"title": "Admin"}`,
+	}
+	start := time.Now().UTC().Add(-24 * time.Hour).Truncate(time.Hour)
+	hours := map[string]int{"design": 1, "build": 2, "admin": 3}
+	for name, body := range tasks {
+		rec := api.do(http.MethodPost, "/api/v1/tasks/", body)
+		wantStatus(t, rec, http.StatusCreated)
+		task := decode[models.Task](t, rec.Body.Bytes())
+		end := start.Add(time.Duration(hours[name]) * time.Hour)
+		wantStatus(t, api.do(http.MethodPost, "/api/v1/tasks/"+task.ID.String()+"/time-entries",
+			`{"started_at": "`+start.Format(time.RFC3339)+`", "ended_at": "`+end.Format(time.RFC3339)+`"}`),
+			http.StatusCreated)
+	}
+
+	report := func(groupBy string) map[string]int64 {
+		t.Helper()
+		rec := api.do(http.MethodGet, "/api/v1/reports/time?group_by="+groupBy+
+			"&from="+start.Add(-time.Hour).Format(time.RFC3339)+"&to="+start.Add(time.Hour).Format(time.RFC3339), "")
+		wantStatus(t, rec, http.StatusOK)
+		got := decode[models.TimeReport](t, rec.Body.Bytes())
+		if got.TotalSeconds != 6*3600 {
+			t.Errorf("%s report total %d, want %d", groupBy, got.TotalSeconds, 6*3600)
+		}
+		seconds := map[string]int64{}
+		for _, row := range got.Rows {
+			seconds[row.Key] = row.Seconds
+		}
+		return seconds
+	}
+
+	byProject := report("project")
+	if byProject[project.ID.String()] != 3*3600 || byProject[""] != 3*3600 || len(byProject) != 2 {
+		t.Errorf("by project %v", byProject)
+	}
+	byLabel := report("label")
+	if byLabel["billable"] != 3*3600 || byLabel["ux"] != 3600 || byLabel[""] != 3*3600 || len(byLabel) != 3 {
+		t.Errorf("by label %v", byLabel)
+	}
+
+	t.Run("UnknownProject", func(t *testing.T) {
+		rec := api.do(http.MethodPost, "/api/v1/tasks/", `{"title": "Lost", "project_id": "`+models.Task{}.ID.String()+`"}`)
+		wantStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestOnlyOwnersChangeProjects(t *testing.T) {
+	ada, bob := newAPIRouters(t)
+	rec := ada.do(http.MethodPost, "/api/v1/projects", `{"name": "Acme"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	projectPath := "/api/v1/projects/" + decode[models.Project](t, rec.Body.Bytes()).ID.String()
+
+	wantStatus(t, bob.do(http.MethodPost, "/api/v1/projects", `{"name": "Acme"}`), http.StatusConflict)
+	wantStatus(t, bob.do(http.MethodPut, projectPath, `{"name": "Bobco"}`), http.StatusForbidden)
+	wantStatus(t, bob.do(http.MethodDelete, projectPath, ""), http.StatusForbidden)
+	wantStatus(t, bob.do(http.MethodGet, projectPath, ""), http.StatusOK)
+
+	wantStatus(t, ada.do(http.MethodPut, projectPath, `{"name": "Acme Corp"}`), http.StatusOK)
+	wantStatus(t, ada.do(http.MethodDelete, projectPath, ""), http.StatusNoContent)
+	wantStatus(t, ada.do(http.MethodGet, projectPath, ""), http.StatusNotFound)
+}
+
+func TestProjectListsHoldTheCallersProjects(t *testing.T) {
+	ada, bob := newAPIRouters(t)
+	rec := ada.do(http.MethodPost, "/api/v1/projects", `{"name": "Acme"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	projectPath := "/api/v1/projects/" + decode[models.Project](t, rec.Body.Bytes()).ID.String()
+	rec = bob.do(http.MethodPost, "/api/v1/projects", `{"name": "Bobco"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	bobID := decode[models.Project](t, rec.Body.Bytes()).OwnerID
+
+	listed := func(api *apiRouter) []string {
+		t.Helper()
+		rec := api.do(http.MethodGet, "/api/v1/projects", "")
+		wantStatus(t, rec, http.StatusOK)
+		var names []string
+		for _, project := range decode[[]models.Project](t, rec.Body.Bytes()) {
+			names = append(names, project.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+	if got := listed(ada); !slices.Equal(got, []string{"Acme"}) {
+		t.Errorf("ada lists %v", got)
+	}
+	if got := listed(bob); !slices.Equal(got, []string{"Bobco"}) {
+		t.Errorf("bob lists %v before joining Acme", got)
+	}
+
+	wantStatus(t, ada.do(http.MethodPut, projectPath+"/members/"+bobID.String(), ""), http.StatusNoContent)
+	if got := listed(bob); !slices.Equal(got, []string{"Acme", "Bobco"}) {
+		t.Errorf("bob lists %v as a member of Acme", got)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+func TestViewsAreSharedWithProjectMembers(t *testing.T) {
+	ada, bob := newAPIRouters(t)
+	rec := ada.do(http.MethodPost, "/api/v1/projects", `{"name": "Acme"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	project := decode[models.Project](t, rec.Body.Bytes())
+	projectPath := "/api/v1/projects/" + project.ID.String()
+
+	rec = ada.do(http.MethodPost, "/api/v1/views", `{"name": "Mine", "query": "status=todo"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	private := decode[models.SavedView](t, rec.Body.Bytes())
+	rec = ada.do(http.MethodPost, "/api/v1/views",
+		`{"name": "Team", "query": "sort=-priority", "shared": true, "project_id": "`+project.ID.String()+`"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	shared := decode[models.SavedView](t, rec.Body.Bytes())
+	rec = bob.do(http.MethodPost, "/api/v1/views", `{"name": "Bob's"}`)
+	wantStatus(t, rec, http.StatusCreated)
+	bobID := decode[models.SavedView](t, rec.Body.Bytes()).UserID
+
+	visible := func(api *apiRouter) map[string]bool {
+		t.Helper()
+		rec := api.do(http.MethodGet, "/api/v1/views", "")
+		wantStatus(t, rec, http.StatusOK)
+		names := map[string]bool{}
+		for _, view := range decode[[]models.SavedView](t, rec.Body.Bytes()) {
+			names[view.Name] = true
+		}
+		return names
+	}
+
+	// Shared views need a project of the owner's.
+	wantStatus(t, ada.do(http.MethodPost, "/api/v1/views", `{"name": "Everyone", "shared": true}`),
+		http.StatusUnprocessableEntity)
+	wantStatus(t, bob.do(http.MethodPost, "/api/v1/views",
+		`{"name": "Theirs", "shared": true, "project_id": "`+project.ID.String()+`"}`), http.StatusUnprocessableEntity)
+
+	// Until Ada adds Bob to the project, he sees neither of her views.
+	for _, view := range []models.SavedView{private, shared} {
+		wantStatus(t, bob.do(http.MethodGet, "/api/v1/views/"+view.ID.String(), ""), http.StatusNotFound)
+		wantStatus(t, bob.do(http.MethodGet, "/api/v1/views/"+view.ID.String()+"/tasks", ""), http.StatusNotFound)
+	}
+	if names := visible(bob); len(names) != 1 || !names["Bob's"] {
+		t.Errorf("Bob sees %v before joining", names)
+	}
+
+	memberPath := projectPath + "/members/" + bobID.String()
+	wantStatus(t, bob.do(http.MethodPut, memberPath, ""), http.StatusForbidden)
+	wantStatus(t, ada.do(http.MethodPut, memberPath, ""), http.StatusNoContent)
+	wantStatus(t, ada.do(http.MethodPut, memberPath, ""), http.StatusNoContent)
+	rec = bob.do(http.MethodGet, projectPath+"/members", "")
+	wantStatus(t, rec, http.StatusOK)
+	if members := decode[[]models.ProjectMember](t, rec.Body.Bytes()); len(members) != 2 ||
+		members[0].UserID != project.OwnerID || members[1].UserID != bobID {
+		t.Errorf("members %+v, want Ada then Bob", members)
+	}
+
+	wantStatus(t, bob.do(http.MethodGet, "/api/v1/views/"+shared.ID.String(), ""), http.StatusOK)
+	wantStatus(t, bob.do(http.MethodGet, "/api/v1/views/"+private.ID.String(), ""), http.StatusNotFound)
+	if names := visible(bob); len(names) != 2 || !names["Team"] || names["Mine"] {
+		t.Errorf("Bob sees %v as a member", names)
+	}
+	wantStatus(t, bob.do(http.MethodPut, "/api/v1/views/"+shared.ID.String(), `{"name": "Bob's team"}`),
+		http.StatusForbidden)
+	wantStatus(t, bob.do(http.MethodPut, "/api/v1/views/default", `{"view_id": "`+shared.ID.String()+`"}`),
+		http.StatusOK)
+
+	// The owner can't leave, and members can only remove themselves.
+	ownerPath := projectPath + "/members/" + project.OwnerID.String()
+	wantStatus(t, ada.do(http.MethodDelete, ownerPath, ""), http.StatusConflict)
+	wantStatus(t, bob.do(http.MethodDelete, ownerPath, ""), http.StatusConflict)
+	wantStatus(t, bob.do(http.MethodDelete, memberPath, ""), http.StatusNoContent)
+	wantStatus(t, bob.do(http.MethodDelete, memberPath, ""), http.StatusNotFound)
+
+	wantStatus(t, bob.do(http.MethodGet, "/api/v1/views/"+shared.ID.String(), ""), http.StatusNotFound)
+	wantStatus(t, bob.do(http.MethodGet, "/api/v1/views/default", ""), http.StatusNotFound)
+}
+
+func TestViewsRequireAuthentication(t *testing.T) {
+	api := newAPIRouter(t)
+	anonymous := *api
+	anonymous.token = ""
+	wantStatus(t, anonymous.do(http.MethodGet, "/api/v1/views", ""), http.StatusUnauthorized)
+	wantStatus(t, anonymous.do(http.MethodPost, "/api/v1/views", `{"name": "Mine"}`), http.StatusUnauthorized)
+}
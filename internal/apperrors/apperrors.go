@@ -0,0 +1,126 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kind classifies an error and determines the HTTP status it maps to.
+type Kind string
+
+const (
+	KindInternal           Kind = "internal"
+	KindNotFound           Kind = "not_found"
+	KindConflict           Kind = "conflict"
+	KindValidation         Kind = "validation_failed"
+	KindUnauthorized       Kind = "unauthorized"
+	KindForbidden          Kind = "forbidden"
+	KindPreconditionFailed Kind = "precondition_failed"
+	KindTooManyRequests    Kind = "too_many_requests"
+)
+
+// FieldError describes why a single request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is the domain error returned by repositories and services. Code is a
+// stable identifier clients can switch on; Message is safe to show to them.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Status returns the HTTP status code for the error kind.
+func (e *Error) Status() int {
+	switch e.Kind {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusUnprocessableEntity
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	case KindTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// NotFound reports that the named resource doesn't exist.
+func NotFound(resource string) *Error {
+	return &Error{Kind: KindNotFound, Code: resource + "_not_found", Message: resource + " not found"}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: string(KindValidation), Message: message, Fields: fields}
+}
+
+// Unauthorized reports that the request carries no valid credentials.
+func Unauthorized(message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: string(KindUnauthorized), Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Code: string(KindForbidden), Message: message}
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Code: code, Message: message}
+}
+
+func TooManyRequests(message string) *Error {
+	return &Error{Kind: KindTooManyRequests, Code: "rate_limited", Message: message}
+}
+
+// Internal wraps an unexpected error. Its cause is logged but never sent to clients.
+func Internal(err error) *Error {
+	return &Error{Kind: KindInternal, Code: string(KindInternal), Message: "internal server error", Err: err}
+}
+
+// Wrap attaches the underlying cause to a domain error.
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// As returns the domain error in err's chain, treating anything else as internal.
+func As(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Internal(err)
+}
+
+// Is reports whether err carries a domain error of the given kind.
+func Is(err error, kind Kind) bool {
+	var appErr *Error
+	return errors.As(err, &appErr) && appErr.Kind == kind
+}
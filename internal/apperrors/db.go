@@ -0,0 +1,30 @@
+package apperrors
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// FromDB translates GORM errors for the named resource into domain errors.
+// It relies on gorm.Config.TranslateError mapping driver specific codes,
+// such as Postgres unique and foreign key violations, to GORM sentinels.
+func FromDB(err error, resource string) error {
+	var appErr *Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &appErr):
+		return err
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFound(resource).Wrap(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return Conflict(resource+"_already_exists", resource+" already exists").Wrap(err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return Conflict(resource+"_reference_violation", resource+" references a missing or still referenced resource").Wrap(err)
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return Validation(resource + " violates a data constraint").Wrap(err)
+	default:
+		return Internal(err)
+	}
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Cache is a byte oriented key/value store with per-entry expiry.
+type Cache interface {
+	// Get returns the cached value and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Incr atomically increments a counter, creating it when missing.
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// Metrics counts cache hits and misses per cached operation.
+type Metrics struct {
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+}
+
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "taskapi",
+			Subsystem: "cache",
+			Name:      "hits_total",
+			Help:      "Number of reads served from the cache.",
+		}, []string{"operation"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "taskapi",
+			Subsystem: "cache",
+			Name:      "misses_total",
+			Help:      "Number of reads that fell through to the database.",
+		}, []string{"operation"}),
+	}
+	if err := reg.Register(m.hits); err != nil {
+		return nil, err
+	}
+	if err := reg.Register(m.misses); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Metrics) Hit(operation string) {
+	m.hits.WithLabelValues(operation).Inc()
+}
+
+func (m *Metrics) Miss(operation string) {
+	m.misses.WithLabelValues(operation).Inc()
+}
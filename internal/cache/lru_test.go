@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2)
+	ctx := context.Background()
+
+	c.Set(ctx, "a", []byte("1"), 0)
+	c.Set(ctx, "b", []byte("2"), 0)
+	c.Get(ctx, "a")
+	c.Set(ctx, "c", []byte("3"), 0)
+
+	if _, found, _ := c.Get(ctx, "b"); found {
+		t.Error("b should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, found, _ := c.Get(ctx, key); !found {
+			t.Errorf("%s should still be cached", key)
+		}
+	}
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	c := NewLRU(10)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	now = now.Add(2 * time.Minute)
+	if _, found, _ := c.Get(ctx, "a"); found {
+		t.Error("expired entry was returned")
+	}
+}
+
+func TestLRUNeverEvictsCounters(t *testing.T) {
+	c := NewLRU(2)
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := c.Incr(ctx, "generation"); err != nil {
+			t.Fatalf("incr: %v", err)
+		}
+	}
+	for i := range 10 {
+		c.Set(ctx, "entry:"+strconv.Itoa(i), []byte("x"), 0)
+	}
+
+	value, found, _ := c.Get(ctx, "generation")
+	if !found || string(value) != "3" {
+		t.Fatalf("counter = %q (found %v), want 3", value, found)
+	}
+	if n, _ := c.Incr(ctx, "generation"); n != 4 {
+		t.Errorf("Incr after eviction pressure = %d, want 4", n)
+	}
+
+	c.Delete(ctx, "generation")
+	if _, found, _ := c.Get(ctx, "generation"); found {
+		t.Error("deleted counter is still present")
+	}
+}
@@ -1,21 +1,75 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	Logging  LoggingConfig
+	Profile       string // "dev", "test" or "prod"
+	Database      DatabaseConfig
+	Server        ServerConfig
+	Auth          AuthConfig
+	Logging       LoggingConfig
+	Tracing       TracingConfig
+	Storage       StorageConfig
+	Redis         RedisConfig
+	RateLimit     RateLimitConfig `mapstructure:"rate_limit"`
+	Cache         CacheConfig
+	CORS          CORSConfig
+	GraphQL       GraphQLConfig
+	GRPC          GRPCConfig
+	Notifications NotificationsConfig
+	InboundEmail  InboundEmailConfig `mapstructure:"inbound_email"`
+	Features      map[string]bool    // feature flags, reloadable at runtime
+
+	// Version is incremented each time a reload is applied.
+	Version  int64     `mapstructure:"-"`
+	LoadedAt time.Time `mapstructure:"-"`
+
+	// settings holds the effective key/value pairs for Masked.
+	settings map[string]interface{}
+	// dir is the directory config.yaml was read from, watched for reloads.
+	dir string
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
+	Port    string
+	Host    string
+	Timeout time.Duration // applied to read, write and idle timeouts, to shutdown draining and to each shutdown hook
+	// DrainDelay is how long the server keeps accepting requests after a
+	// shutdown signal while /readyz fails, so that load balancers stop
+	// routing to it before connections are refused.
+	DrainDelay time.Duration `mapstructure:"drain_delay"`
+	// TrustedProxies are the addresses or CIDR ranges of the proxies whose
+	// X-Forwarded-For and X-Real-IP headers name the client, for rate
+	// limits and logs. Other clients are known by their own address.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+	// OpenAPIValidation checks requests and responses against the OpenAPI
+	// spec. It is meant for development and tests.
+	OpenAPIValidation bool `mapstructure:"openapi_validation"`
+}
+
+// AuthConfig controls sign in. Access tokens are JWTs signed with Secret;
+// refresh tokens renew them until the session they belong to expires or is
+// logged out.
+type AuthConfig struct {
+	Secret     string
+	AccessTTL  time.Duration `mapstructure:"access_ttl"`
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl"` // session lifetime
+}
+
+type StorageConfig struct {
+	Driver      string // only "local" is supported
+	Path        string
+	MaxFileSize int64 `mapstructure:"max_file_size"` // bytes, per uploaded file
 }
 
 type LoggingConfig struct {
@@ -23,40 +77,153 @@ type LoggingConfig struct {
 	Format string
 }
 
+type TracingConfig struct {
+	Enabled     bool
+	Exporter    string // "otlp" or "stdout"
+	Endpoint    string // OTLP/HTTP collector address, e.g. "localhost:4318"
+	Insecure    bool
+	ServiceName string  `mapstructure:"service_name"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	Store   string // "memory" or "redis"
+	Default RateLimitRule
+	Groups  map[string]RateLimitRule // keyed by route group, e.g. "tasks"
+}
+
+// UsesRedis reports whether rate limit buckets are kept in Redis.
+func (c RateLimitConfig) UsesRedis() bool {
+	return c.Enabled && c.Store == "redis"
+}
+
+// RateLimitRule allows Requests per Period on average, with bursts of up to Burst requests.
+type RateLimitRule struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+type CacheConfig struct {
+	Enabled    bool
+	Store      string // "memory" or "redis"
+	TTL        time.Duration
+	MaxEntries int `mapstructure:"max_entries"` // memory store only
+}
+
+// UsesRedis reports whether cached entries are kept in Redis.
+func (c CacheConfig) UsesRedis() bool {
+	return c.Enabled && c.Store == "redis"
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `mapstructure:"allowed_origins"` // "*" allows any origin
+}
+
+// GraphQLConfig limits the queries accepted by the GraphQL endpoint.
+type GraphQLConfig struct {
+	MaxDepth      int `mapstructure:"max_depth"`      // nesting levels of fields
+	MaxComplexity int `mapstructure:"max_complexity"` // estimated fields resolved, lists counted by their limit
+}
+
+// GRPCConfig controls the gRPC server, which listens on its own port next
+// to the HTTP server.
+type GRPCConfig struct {
+	Enabled bool
+	Port    string
+}
+
+// NotificationsConfig controls the due soon notifications, sent once per
+// assigned task when its due date comes within DueSoon.
+type NotificationsConfig struct {
+	DueSoon      time.Duration `mapstructure:"due_soon"`
+	ScanInterval time.Duration `mapstructure:"scan_interval"` // how often tasks are checked
+}
+
+// InboundEmailConfig controls the email gateway, which turns messages sent
+// to Address into tasks. Messages are posted to the HTTP API, signed with
+// Secret, or received by an SMTP listener on its own port next to the HTTP
+// server when SMTPPort is set. Either way, their sender is checked with
+// the Authentication-Results headers of the mail server AuthservID names,
+// so the listener only accepts connections from SMTPRelays.
+type InboundEmailConfig struct {
+	Enabled    bool
+	Address    string // e.g. "support@tasks.example.com"
+	Secret     string
+	AuthservID string   `mapstructure:"authserv_id"` // the Address's domain when empty
+	SMTPPort   string   `mapstructure:"smtp_port"`
+	SMTPRelays []string `mapstructure:"smtp_relays"` // addresses or CIDR ranges of the mail servers
+	MaxSize    int64    `mapstructure:"max_size"`    // bytes, attachments included
+}
+
+// EnvPrefix prefixes the environment variables that override settings,
+// e.g. TASKAPI_DATABASE_HOST overrides database.host.
+const EnvPrefix = "TASKAPI"
+
+// Load reads the configuration like Read and validates the result.
 func Load() (*Config, error) {
+	cfg, err := Read()
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Read reads config/config.yaml, merges the profile specific
+// config/config.<profile>.yaml on top and applies environment overrides,
+// without validating the result. The profile is taken from TASKAPI_PROFILE
+// and defaults to "prod", so that a deployment missing it doesn't run with
+// development settings.
+func Read() (*Config, error) {
+	// Load environment variables from .env file when there is one
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error loading .env file: %w", err)
+	}
 
 	v := viper.New()
-	v.SetConfigName("config")
 	v.SetConfigType("yaml")
 	v.AddConfigPath("./config")
 	v.AddConfigPath("../config")
 	v.AddConfigPath("../../config")
 
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	setDefaults(v)
+	// Keep honouring the variables used by docker-compose and older deployments.
+	if err := v.BindEnv("database.user", EnvPrefix+"_DATABASE_USER", "DB_USER"); err != nil {
+		return nil, err
+	}
+	if err := v.BindEnv("database.password", EnvPrefix+"_DATABASE_PASSWORD", "DB_PASSWORD"); err != nil {
+		return nil, err
+	}
+
+	v.SetConfigName("config")
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
-	// Load environment variables from .env file
+	dir := filepath.Dir(v.ConfigFileUsed())
 
-	if err := godotenv.Load(); err != nil {
-		return nil, fmt.Errorf("error loading .env file: %w", err)
+	profile := v.GetString("profile")
+	v.SetConfigName("config." + profile)
+	if err := v.MergeInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to read %s profile: %w", profile, err)
+		}
 	}
 
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
-
+	cfg.settings = v.AllSettings()
+	cfg.dir = dir
+	cfg.Version = 1
+	cfg.LoadedAt = time.Now()
 	return &cfg, nil
-
-}
-
-func Validate(cfg *Config) error {
-	if cfg.Database.DBUser == "" || cfg.Database.DBPassword == "" || cfg.Database.Host == "" || cfg.Database.Name == "" {
-		return fmt.Errorf("database configuration is missing")
-	}
-
-	if cfg.Server.Port == "" {
-		return fmt.Errorf("server port is required")
-	}
-	return nil
 }
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfileDefaultsToProd(t *testing.T) {
+	t.Setenv(EnvPrefix+"_PROFILE", "")
+	cfg, err := Read()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if cfg.Profile != "prod" {
+		t.Errorf("profile = %q, want prod", cfg.Profile)
+	}
+	if cfg.Database.SSLMode != "require" {
+		t.Errorf("database.sslmode = %q, want the prod override require", cfg.Database.SSLMode)
+	}
+}
+
+func TestReadDoesNotValidate(t *testing.T) {
+	t.Setenv(EnvPrefix+"_PROFILE", "dev")
+	t.Setenv(EnvPrefix+"_SERVER_PORT", "not-a-port")
+
+	cfg, err := Read()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if cfg.Server.Port != "not-a-port" {
+		t.Errorf("server.port = %q, want the override", cfg.Server.Port)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("Load accepted an invalid server.port")
+	}
+}
+
+func TestInboundEmailNeedsASecret(t *testing.T) {
+	t.Setenv(EnvPrefix+"_PROFILE", "dev")
+	t.Setenv(EnvPrefix+"_INBOUND_EMAIL_ENABLED", "true")
+	t.Setenv(EnvPrefix+"_INBOUND_EMAIL_ADDRESS", "tasks@example.com")
+	t.Setenv(EnvPrefix+"_INBOUND_EMAIL_SECRET", "too short")
+
+	cfg, err := Read()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "inbound_email.secret") {
+		t.Errorf("Validate = %v, want the short inbound_email.secret rejected", err)
+	}
+	cfg.InboundEmail.Secret = strings.Repeat("s", minSecretLength)
+	if err := Validate(cfg); err != nil && strings.Contains(err.Error(), "inbound_email") {
+		t.Errorf("Validate rejected a long enough secret: %v", err)
+	}
+}
+
+func TestInboundEmailListensToRelays(t *testing.T) {
+	t.Setenv(EnvPrefix+"_PROFILE", "dev")
+	t.Setenv(EnvPrefix+"_INBOUND_EMAIL_ENABLED", "true")
+	t.Setenv(EnvPrefix+"_INBOUND_EMAIL_ADDRESS", "tasks@example.com")
+	t.Setenv(EnvPrefix+"_INBOUND_EMAIL_SECRET", strings.Repeat("s", minSecretLength))
+	t.Setenv(EnvPrefix+"_INBOUND_EMAIL_SMTP_PORT", "2525")
+
+	cfg, err := Read()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if err := Validate(cfg); err != nil && strings.Contains(err.Error(), "inbound_email") {
+		t.Fatalf("Validate rejected the default relays: %v", err)
+	}
+	for _, relays := range [][]string{nil, {"mx.example.com"}} {
+		cfg.InboundEmail.SMTPRelays = relays
+		if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "inbound_email.smtp_relays") {
+			t.Errorf("Validate = %v, want smtp_relays %q rejected", err, relays)
+		}
+	}
+}
+
+// inConfigDir runs the test from a directory holding a copy of
+// config/config.yaml and no profile files, and returns its config directory.
+func inConfigDir(t *testing.T) string {
+	t.Helper()
+	base, err := os.ReadFile("../../config/config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	dir := filepath.Join(root, "config")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), base, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+func TestWatchReloadsProfileFilesCreatedLater(t *testing.T) {
+	dir := inConfigDir(t)
+	t.Setenv(EnvPrefix+"_PROFILE", "test")
+	t.Setenv(EnvPrefix+"_DATABASE_DRIVER", DriverSQLite)
+	t.Setenv(EnvPrefix+"_AUTH_SECRET", strings.Repeat("s", minSecretLength))
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	m := NewManager(cfg)
+	stop, err := m.Watch()
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer stop()
+	eventually := func(want bool) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if m.Feature(FeatureSignupClosed) == want {
+				return
+			}
+		}
+		t.Fatalf("%s = %v, want %v", FeatureSignupClosed, !want, want)
+	}
+	if m.Feature(FeatureSignupClosed) {
+		t.Fatalf("%s is set before the profile file exists", FeatureSignupClosed)
+	}
+
+	profile := filepath.Join(dir, "config.test.yaml")
+	if err := os.WriteFile(profile, []byte("features:\n  signup_closed: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	eventually(true)
+	if err := os.Remove(profile); err != nil {
+		t.Fatal(err)
+	}
+	eventually(false)
+}
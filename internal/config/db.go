@@ -1,31 +1,128 @@
 package config
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the pgx database/sql driver
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Database drivers selectable with database.driver.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
 )
 
 type DatabaseConfig struct {
+	Driver          string // "postgres" or "sqlite"
+	Path            string // database file, sqlite only
 	Host            string
 	Port            string
 	Name            string
-	DBUser          string `mapstructure:"DB_USER"`
-	DBPassword      string `mapstructure:"DB_PASSWORD"`
+	User            string
+	Password        string
+	SSLMode         string `mapstructure:"sslmode"` // disable, allow, prefer, require, verify-ca or verify-full
+	SSLRootCert     string `mapstructure:"sslrootcert"`
+	SSLCert         string `mapstructure:"sslcert"`
+	SSLKey          string `mapstructure:"sslkey"`
 	MaxConnections  int    `mapstructure:"max_connections"`
 	IdleConnections int    `mapstructure:"idle_connections"`
+
+	// Replicas receive read queries. They share the primary's name,
+	// credentials and TLS settings.
+	Replicas             []ReplicaConfig
+	ReadYourWrites       time.Duration `mapstructure:"read_your_writes"`       // reads stay on the primary this long after a client's write
+	ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"` // how often replicas are pinged
 }
 
-func InitializeDatabase(cfg *Config) (*gorm.DB, error) {
-	fmt.Printf("DB_USER: %s\n", os.Getenv("DB_USER"))
-	fmt.Printf("DB_PASSWORD: %s\n", os.Getenv("DB_PASSWORD"))
+type ReplicaConfig struct {
+	Host string
+	Port string
+}
+
+// DSN builds a libpq keyword/value connection string. Values are quoted so
+// passwords containing spaces or quotes survive.
+func (c DatabaseConfig) DSN() string {
+	params := []struct{ key, value string }{
+		{"host", c.Host},
+		{"port", c.Port},
+		{"user", c.User},
+		{"password", c.Password},
+		{"dbname", c.Name},
+		{"sslmode", c.SSLMode},
+		{"sslrootcert", c.SSLRootCert},
+		{"sslcert", c.SSLCert},
+		{"sslkey", c.SSLKey},
+	}
+
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.value == "" {
+			continue
+		}
+		value := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(p.value)
+		parts = append(parts, fmt.Sprintf("%s='%s'", p.key, value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Dialector returns the GORM dialector for the configured driver.
+func (c DatabaseConfig) Dialector() gorm.Dialector {
+	if c.Driver == DriverSQLite {
+		return newSQLiteDialector(c.Path)
+	}
+	return postgres.Open(c.DSN())
+}
+
+// OpenReplicas opens a connection pool per configured replica, sized like
+// the primary's pool. The pools are plain database/sql pools: replica reads
+// are issued through the primary's *gorm.DB, so they go through its
+// logger, metrics and tracing callbacks.
+func OpenReplicas(cfg *Config) ([]*sql.DB, error) {
+	replicas := make([]*sql.DB, 0, len(cfg.Database.Replicas))
+	for _, replica := range cfg.Database.Replicas {
+		dbConfig := cfg.Database
+		dbConfig.Host, dbConfig.Port = replica.Host, replica.Port
+
+		sqlDB, err := sql.Open("pgx", dbConfig.DSN())
+		if err == nil {
+			err = sqlDB.Ping()
+		}
+		if err != nil {
+			if sqlDB != nil {
+				sqlDB.Close()
+			}
+			closeAll(replicas)
+			return nil, fmt.Errorf("failed to connect to replica %s:%s: %w", replica.Host, replica.Port, err)
+		}
+		sqlDB.SetMaxOpenConns(cfg.Database.MaxConnections)
+		sqlDB.SetMaxIdleConns(cfg.Database.IdleConnections)
+		replicas = append(replicas, sqlDB)
+	}
+	return replicas, nil
+}
+
+func closeAll(dbs []*sql.DB) {
+	for _, db := range dbs {
+		db.Close()
+	}
+}
+
+func InitializeDatabase(cfg *Config, logger gormlogger.Interface) (*gorm.DB, error) {
+	if cfg.Database.Driver == DriverSQLite {
+		if err := os.MkdirAll(filepath.Dir(cfg.Database.Path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
 
-	cfg.Database.DBUser = os.Getenv("DB_USER")
-	cfg.Database.DBPassword = os.Getenv("DB_PASSWORD")
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", cfg.Database.Host, cfg.Database.Port, cfg.Database.DBUser, cfg.Database.DBPassword, cfg.Database.Name)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(cfg.Database.Dialector(), &gorm.Config{Logger: logger, TranslateError: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
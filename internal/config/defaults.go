@@ -0,0 +1,90 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// setDefaults registers every setting with viper. Besides providing
+// fallbacks, this is what lets AutomaticEnv override keys that are absent
+// from the config files.
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("profile", "prod")
+
+	v.SetDefault("server.host", "0.0.0.0")
+	v.SetDefault("server.port", "8080")
+	v.SetDefault("server.timeout", 30*time.Second)
+	v.SetDefault("server.drain_delay", 5*time.Second)
+	v.SetDefault("server.trusted_proxies", []string{})
+	v.SetDefault("server.openapi_validation", false)
+
+	v.SetDefault("auth.secret", "")
+	v.SetDefault("auth.access_ttl", 15*time.Minute)
+	v.SetDefault("auth.refresh_ttl", 30*24*time.Hour)
+
+	v.SetDefault("database.driver", DriverPostgres)
+	v.SetDefault("database.path", "./data/taskmanager.db")
+	v.SetDefault("database.host", "localhost")
+	v.SetDefault("database.port", "5432")
+	v.SetDefault("database.name", "taskmanager")
+	v.SetDefault("database.user", "")
+	v.SetDefault("database.password", "")
+	v.SetDefault("database.sslmode", "disable")
+	v.SetDefault("database.sslrootcert", "")
+	v.SetDefault("database.sslcert", "")
+	v.SetDefault("database.sslkey", "")
+	v.SetDefault("database.max_connections", 100)
+	v.SetDefault("database.idle_connections", 10)
+	v.SetDefault("database.replicas", []map[string]interface{}{})
+	v.SetDefault("database.read_your_writes", 5*time.Second)
+	v.SetDefault("database.replica_check_interval", 10*time.Second)
+
+	v.SetDefault("storage.driver", "local")
+	v.SetDefault("storage.path", "./uploads")
+	v.SetDefault("storage.max_file_size", 10<<20)
+
+	v.SetDefault("redis.addr", "localhost:6379")
+	v.SetDefault("redis.password", "")
+	v.SetDefault("redis.db", 0)
+
+	v.SetDefault("rate_limit.enabled", true)
+	v.SetDefault("rate_limit.store", "memory")
+	v.SetDefault("rate_limit.default.requests", 100)
+	v.SetDefault("rate_limit.default.period", time.Minute)
+	v.SetDefault("rate_limit.default.burst", 20)
+
+	v.SetDefault("cache.enabled", true)
+	v.SetDefault("cache.store", "memory")
+	v.SetDefault("cache.ttl", 30*time.Second)
+	v.SetDefault("cache.max_entries", 10000)
+
+	v.SetDefault("graphql.max_depth", 8)
+	v.SetDefault("graphql.max_complexity", 500)
+
+	v.SetDefault("grpc.enabled", false)
+	v.SetDefault("grpc.port", "9090")
+
+	v.SetDefault("notifications.due_soon", 24*time.Hour)
+	v.SetDefault("notifications.scan_interval", 5*time.Minute)
+
+	v.SetDefault("inbound_email.enabled", false)
+	v.SetDefault("inbound_email.address", "")
+	v.SetDefault("inbound_email.secret", "")
+	v.SetDefault("inbound_email.authserv_id", "")
+	v.SetDefault("inbound_email.smtp_port", "")
+	v.SetDefault("inbound_email.smtp_relays", []string{"127.0.0.1", "::1"})
+	v.SetDefault("inbound_email.max_size", 10<<20)
+
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.format", "json")
+
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.exporter", "stdout")
+	v.SetDefault("tracing.endpoint", "localhost:4318")
+	v.SetDefault("tracing.insecure", false)
+	v.SetDefault("tracing.service_name", "task-management-api")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	v.SetDefault("features."+FeatureSignupClosed, false)
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const maskedValue = "********"
+
+// secretKeys are setting names whose values are masked when printed.
+var secretKeys = []string{"password", "secret", "token"}
+
+// Masked returns the effective settings with secret values replaced.
+func (c *Config) Masked() map[string]interface{} {
+	return maskSecrets(c.settings)
+}
+
+// Print writes the effective configuration as YAML with secrets masked.
+func (c *Config) Print(w io.Writer) error {
+	out, err := yaml.Marshal(c.Masked())
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+func maskSecrets(settings map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			masked[key] = maskSecrets(v)
+		default:
+			if isSecret(key) && fmt.Sprint(v) != "" {
+				masked[key] = maskedValue
+			} else {
+				masked[key] = v
+			}
+		}
+	}
+	return masked
+}
+
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
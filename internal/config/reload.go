@@ -0,0 +1,160 @@
+package config
+
+import (
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// FeatureSignupClosed turns new signups away; existing users can still log
+// in.
+const FeatureSignupClosed = "signup_closed"
+
+// Manager holds the active configuration and reloads its runtime tunable
+// parts (log level, rate limits, feature flags and CORS origins) when the
+// config files change. Everything else needs a restart to take effect.
+type Manager struct {
+	current atomic.Pointer[Config]
+
+	mu          sync.Mutex // serializes reloads and guards subscribers
+	subscribers []func(*Config)
+}
+
+func NewManager(cfg *Config) *Manager {
+	m := &Manager{}
+	m.current.Store(cfg)
+	return m
+}
+
+// Current returns the active configuration. Callers must not modify it.
+func (m *Manager) Current() *Config {
+	return m.current.Load()
+}
+
+// Subscribe registers fn to be called with the new configuration after each applied reload.
+func (m *Manager) Subscribe(fn func(*Config)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscribers = append(m.subscribers, fn)
+}
+
+// Feature reports whether the named feature flag is enabled.
+func (m *Manager) Feature(name string) bool {
+	return m.Current().Features[name]
+}
+
+// Watch reloads the configuration whenever config.yaml or the profile's
+// file next to it is written, created or removed, so that a profile file
+// added after startup is picked up too. The directory is watched rather
+// than the files, which also follows Kubernetes ConfigMap updates swapping
+// its "..data" symlink. It returns an error if the directory can't be
+// watched; stop stops watching.
+func (m *Manager) Watch() (stop func(), err error) {
+	cfg := m.Current()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(cfg.dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	names := map[string]bool{"config.yaml": true, "config." + cfg.Profile + ".yaml": true, "..data": true}
+	go func() {
+		for {
+			select {
+			case e, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if names[filepath.Base(e.Name)] && e.Op != fsnotify.Chmod {
+					slog.Info("config file changed", slog.String("file", e.Name))
+					m.Reload()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Error("failed to watch the config files", slog.Any("error", err))
+			}
+		}
+	}()
+	return func() { watcher.Close() }, nil
+}
+
+// Reload re-reads the configuration. An invalid configuration is rejected
+// and the active one is kept.
+func (m *Manager) Reload() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	loaded, err := Load()
+	if err != nil {
+		slog.Error("rejected config reload, keeping the active configuration", slog.Any("error", err))
+		return err
+	}
+
+	old := m.Current()
+	if !reflect.DeepEqual(structural(old), structural(loaded)) {
+		slog.Warn("config changes outside the reloadable settings require a restart")
+	}
+	if reflect.DeepEqual(reloadable(old), reloadable(loaded)) {
+		return nil
+	}
+
+	next := *old
+	next.Logging.Level = loaded.Logging.Level
+	next.RateLimit = loaded.RateLimit
+	if loaded.RateLimit.UsesRedis() != old.RateLimit.UsesRedis() {
+		// The store is picked at startup; switching it needs a restart.
+		slog.Warn("rate limit store changes require a restart, keeping the active rate limits")
+		next.RateLimit = old.RateLimit
+	}
+	next.Features = loaded.Features
+	next.CORS = loaded.CORS
+	next.Version = old.Version + 1
+	next.LoadedAt = time.Now()
+	m.current.Store(&next)
+
+	slog.Info("config reloaded", slog.Int64("version", next.Version))
+	for _, fn := range m.subscribers {
+		fn(&next)
+	}
+	return nil
+}
+
+// reloadableSettings is the part of the configuration that can change at runtime.
+type reloadableSettings struct {
+	LogLevel  string
+	RateLimit RateLimitConfig
+	Features  map[string]bool
+	CORS      CORSConfig
+}
+
+func reloadable(c *Config) reloadableSettings {
+	return reloadableSettings{
+		LogLevel:  c.Logging.Level,
+		RateLimit: c.RateLimit,
+		Features:  c.Features,
+		CORS:      c.CORS,
+	}
+}
+
+// structural strips the reloadable and bookkeeping fields from c.
+func structural(c *Config) Config {
+	s := *c
+	s.Logging.Level = ""
+	s.RateLimit = RateLimitConfig{}
+	s.Features = nil
+	s.CORS = CORSConfig{}
+	s.Version = 0
+	s.LoadedAt = time.Time{}
+	s.settings = nil
+	s.dir = ""
+	return s
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteDialector adds the error translations the upstream SQLite dialector
+// lacks, so CHECK violations surface as gorm.ErrCheckConstraintViolated just
+// like they do on Postgres.
+type sqliteDialector struct {
+	*sqlite.Dialector
+}
+
+// newSQLiteDialector opens the database file at path with foreign keys
+// enforced, WAL journaling and a busy timeout so concurrent writers wait
+// instead of failing.
+func newSQLiteDialector(path string) gorm.Dialector {
+	params := url.Values{}
+	params.Set("_foreign_keys", "on")
+	params.Set("_journal_mode", "WAL")
+	params.Set("_busy_timeout", "5000")
+	params.Set("_txlock", "immediate")
+	return sqliteDialector{sqlite.Open("file:" + path + "?" + params.Encode()).(*sqlite.Dialector)}
+}
+
+func (d sqliteDialector) Translate(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintCheck {
+		return gorm.ErrCheckConstraintViolated
+	}
+	return d.Dialector.Translate(err)
+}
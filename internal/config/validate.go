@@ -0,0 +1,197 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	dbDrivers    = []string{DriverPostgres, DriverSQLite}
+	sslModes     = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels    = []string{"debug", "info", "warn", "warning", "error"}
+	logFormats   = []string{"json", "text"}
+	exporters    = []string{"otlp", "stdout"}
+	storeDrivers = []string{"memory", "redis"}
+	profiles     = []string{"dev", "test", "prod"}
+)
+
+// minSecretLength is the shortest auth.secret accepted: 256 bits for HS256.
+const minSecretLength = 32
+
+// Validate checks every section and reports all problems at once so a
+// misconfigured deployment fails fast with a complete list.
+func Validate(cfg *Config) error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains(profiles, cfg.Profile), "profile must be one of %v, got %q", profiles, cfg.Profile)
+
+	check(validPort(cfg.Server.Port), "server.port must be a port number, got %q", cfg.Server.Port)
+	check(cfg.Server.Timeout > 0, "server.timeout must be positive")
+	check(cfg.Server.DrainDelay >= 0, "server.drain_delay must not be negative")
+	for _, proxy := range cfg.Server.TrustedProxies {
+		_, _, err := net.ParseCIDR(proxy)
+		check(err == nil || net.ParseIP(proxy) != nil, "server.trusted_proxies must hold addresses or CIDR ranges, got %q", proxy)
+	}
+
+	check(len(cfg.Auth.Secret) >= minSecretLength, "auth.secret must be at least %d characters", minSecretLength)
+	check(cfg.Auth.AccessTTL > 0, "auth.access_ttl must be positive")
+	check(cfg.Auth.RefreshTTL >= cfg.Auth.AccessTTL, "auth.refresh_ttl must not be shorter than auth.access_ttl")
+
+	db := cfg.Database
+	check(slices.Contains(dbDrivers, db.Driver), "database.driver must be one of %v, got %q", dbDrivers, db.Driver)
+	if db.Driver == DriverSQLite {
+		check(db.Path != "", "database.path is required for the sqlite driver")
+	} else {
+		check(db.Host != "", "database.host is required")
+		check(validPort(db.Port), "database.port must be a port number, got %q", db.Port)
+		check(db.Name != "", "database.name is required")
+		check(db.User != "", "database.user is required")
+		check(db.Password != "" || db.SSLCert != "", "database.password is required unless a client certificate is configured")
+		check(slices.Contains(sslModes, db.SSLMode), "database.sslmode must be one of %v, got %q", sslModes, db.SSLMode)
+		check(db.SSLMode != "verify-ca" && db.SSLMode != "verify-full" || db.SSLRootCert != "",
+			"database.sslrootcert is required with sslmode %s", db.SSLMode)
+		check((db.SSLCert == "") == (db.SSLKey == ""), "database.sslcert and database.sslkey must be set together")
+	}
+	check(db.MaxConnections >= 0, "database.max_connections must not be negative")
+	check(db.IdleConnections >= 0, "database.idle_connections must not be negative")
+	check(db.MaxConnections == 0 || db.IdleConnections <= db.MaxConnections,
+		"database.idle_connections must not exceed database.max_connections")
+	if len(db.Replicas) > 0 {
+		check(db.Driver == DriverPostgres, "database.replicas are only supported with the postgres driver")
+		for i, replica := range db.Replicas {
+			check(replica.Host != "", "database.replicas[%d].host is required", i)
+			check(validPort(replica.Port), "database.replicas[%d].port must be a port number, got %q", i, replica.Port)
+		}
+		check(db.ReadYourWrites >= 0, "database.read_your_writes must not be negative")
+		check(db.ReplicaCheckInterval > 0, "database.replica_check_interval must be positive")
+	}
+
+	check(slices.Contains(logLevels, cfg.Logging.Level), "logging.level must be one of %v, got %q", logLevels, cfg.Logging.Level)
+	check(slices.Contains(logFormats, cfg.Logging.Format), "logging.format must be one of %v, got %q", logFormats, cfg.Logging.Format)
+
+	if cfg.Tracing.Enabled {
+		check(slices.Contains(exporters, cfg.Tracing.Exporter), "tracing.exporter must be one of %v, got %q", exporters, cfg.Tracing.Exporter)
+		check(cfg.Tracing.Exporter != "otlp" || cfg.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+		check(cfg.Tracing.SampleRatio > 0 && cfg.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be in (0, 1]")
+	}
+
+	check(cfg.Storage.Driver == "local", "storage.driver must be local, got %q", cfg.Storage.Driver)
+	check(cfg.Storage.Path != "", "storage.path is required")
+	check(cfg.Storage.MaxFileSize > 0, "storage.max_file_size must be positive")
+
+	errs = append(errs, validateRateLimit(cfg.RateLimit)...)
+
+	if cfg.Cache.Enabled {
+		check(slices.Contains(storeDrivers, cfg.Cache.Store), "cache.store must be one of %v, got %q", storeDrivers, cfg.Cache.Store)
+		check(cfg.Cache.TTL > 0, "cache.ttl must be positive")
+		check(cfg.Cache.MaxEntries >= 0, "cache.max_entries must not be negative")
+	}
+
+	check(cfg.GraphQL.MaxDepth > 0, "graphql.max_depth must be positive")
+	check(cfg.GraphQL.MaxComplexity > 0, "graphql.max_complexity must be positive")
+
+	if cfg.GRPC.Enabled {
+		check(validPort(cfg.GRPC.Port), "grpc.port must be a port number, got %q", cfg.GRPC.Port)
+		check(cfg.GRPC.Port != cfg.Server.Port, "grpc.port must differ from server.port")
+	}
+
+	check(cfg.Notifications.DueSoon > 0, "notifications.due_soon must be positive")
+	check(cfg.Notifications.ScanInterval > 0, "notifications.scan_interval must be positive")
+
+	if cfg.InboundEmail.Enabled {
+		errs = append(errs, validateInboundEmail(cfg)...)
+	}
+
+	if cfg.UsesRedis() {
+		check(cfg.Redis.Addr != "", "redis.addr is required when a redis store is configured")
+		check(cfg.Redis.DB >= 0, "redis.db must not be negative")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func validateInboundEmail(cfg *Config) []error {
+	var errs []error
+	in := cfg.InboundEmail
+	addr, err := mail.ParseAddress(in.Address)
+	switch {
+	case err != nil || addr.Name != "" || addr.Address != in.Address:
+		errs = append(errs, fmt.Errorf("inbound_email.address must be a plain email address, got %q", in.Address))
+	case strings.Contains(in.Address[:strings.LastIndexByte(in.Address, '@')], "+"):
+		// Reply addresses add "+<task ID>" to the address.
+		errs = append(errs, fmt.Errorf("inbound_email.address must not contain a +, got %q", in.Address))
+	}
+	if len(in.Secret) < minSecretLength {
+		errs = append(errs, fmt.Errorf("inbound_email.secret must be at least %d characters", minSecretLength))
+	}
+	if in.SMTPPort != "" {
+		if !validPort(in.SMTPPort) {
+			errs = append(errs, fmt.Errorf("inbound_email.smtp_port must be a port number, got %q", in.SMTPPort))
+		}
+		if in.SMTPPort == cfg.Server.Port || cfg.GRPC.Enabled && in.SMTPPort == cfg.GRPC.Port {
+			errs = append(errs, errors.New("inbound_email.smtp_port must differ from server.port and grpc.port"))
+		}
+		if len(in.SMTPRelays) == 0 {
+			errs = append(errs, errors.New("inbound_email.smtp_relays must name the mail servers relaying to smtp_port"))
+		}
+		for _, relay := range in.SMTPRelays {
+			if _, _, err := net.ParseCIDR(relay); err != nil && net.ParseIP(relay) == nil {
+				errs = append(errs, fmt.Errorf("inbound_email.smtp_relays must hold addresses or CIDR ranges, got %q", relay))
+			}
+		}
+	}
+	if in.MaxSize <= 0 {
+		errs = append(errs, errors.New("inbound_email.max_size must be positive"))
+	}
+	return errs
+}
+
+func validateRateLimit(cfg RateLimitConfig) []error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var errs []error
+	if !slices.Contains(storeDrivers, cfg.Store) {
+		errs = append(errs, fmt.Errorf("rate_limit.store must be one of %v, got %q", storeDrivers, cfg.Store))
+	}
+	if err := validateRule("rate_limit.default", cfg.Default); err != nil {
+		errs = append(errs, err)
+	}
+	for name, rule := range cfg.Groups {
+		if err := validateRule("rate_limit.groups."+name, rule); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func validateRule(key string, rule RateLimitRule) error {
+	if rule.Requests <= 0 || rule.Period <= 0 || rule.Burst <= 0 {
+		return fmt.Errorf("%s requires positive requests, period and burst", key)
+	}
+	return nil
+}
+
+// UsesRedis reports whether any enabled component needs a Redis connection.
+func (c *Config) UsesRedis() bool {
+	return c.RateLimit.UsesRedis() || c.Cache.UsesRedis()
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
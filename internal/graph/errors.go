@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+)
+
+// fieldError marks an error returned by one of our resolvers, as opposed to
+// the errors graphql-go reports for invalid queries. Its message is safe to
+// show to clients.
+type fieldError struct {
+	err *apperrors.Error
+}
+
+func (e fieldError) Error() string {
+	return e.err.Message
+}
+
+func (e fieldError) Unwrap() error {
+	return e.err
+}
+
+// resolve wraps fn so the errors it returns, directly or from its thunk,
+// are reported with their apperrors code.
+func resolve(fn graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, err := fn(p)
+		if err != nil {
+			return nil, fieldError{apperrors.As(err)}
+		}
+		if next, ok := v.(thunk); ok {
+			return thunk(func() (interface{}, error) {
+				v, err := next()
+				if err != nil {
+					return nil, fieldError{apperrors.As(err)}
+				}
+				return v, nil
+			}), nil
+		}
+		return v, nil
+	}
+}
+
+// formatErrors adds the code and rejected fields of resolver errors as
+// extensions and logs internal ones, whose cause isn't sent to clients.
+func formatErrors(ctx context.Context, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	for i, e := range errs {
+		var fe fieldError
+		if !errors.As(original(e), &fe) {
+			continue
+		}
+		if fe.err.Kind == apperrors.KindInternal {
+			logger.FromContext(ctx).ErrorContext(ctx, "graphql resolver failed",
+				slog.Any("path", e.Path), slog.Any("error", fe.err.Err))
+		}
+		errs[i].Message = fe.err.Message
+		errs[i].Extensions = map[string]interface{}{"code": fe.err.Code}
+		if len(fe.err.Fields) > 0 {
+			errs[i].Extensions["fields"] = fe.err.Fields
+		}
+	}
+	return errs
+}
+
+// original digs the error a resolver returned out of the layers graphql-go
+// wraps it in.
+func original(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			if e.OriginalError() == nil {
+				return e
+			}
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			if e.OriginalError == nil {
+				return e
+			}
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
+
+// requestError reports a request rejected before execution.
+func requestError(code, message string) gqlerrors.FormattedError {
+	err := gqlerrors.NewFormattedError(message)
+	err.Extensions = map[string]interface{}{"code": code}
+	return err
+}
+
+func parseID(args map[string]interface{}, name string) (uuid.UUID, error) {
+	s, _ := args[name].(string)
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, apperrors.Validation(name+" must be a valid UUID",
+			apperrors.FieldError{Field: name, Message: "must be a valid UUID"}).Wrap(err)
+	}
+	return id, nil
+}
+
+// validate checks the binding rules of an input, like the REST handlers do
+// when binding request bodies.
+func validate(input interface{}) error {
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		return validation.BindError(err)
+	}
+	return nil
+}
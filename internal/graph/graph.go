@@ -0,0 +1,59 @@
+// Package graph serves the GraphQL API. Queries and mutations go through the
+// same services as the REST handlers. Related records are loaded in batches
+// per request, so listing tasks with their owners, comments and attachments
+// costs one query per relation rather than one per task.
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+// Services are the collaborators the resolvers call.
+type Services struct {
+	Tasks       *services.TaskService
+	Comments    *services.CommentService
+	Attachments *services.AttachmentService
+	Users       repositories.UserRepository
+}
+
+// request is the per-request state resolvers find in their context.
+type request struct {
+	services Services
+	user     *uuid.UUID // nil for anonymous callers
+
+	mu      sync.Mutex
+	loaders *loaders
+}
+
+type requestKey struct{}
+
+func withRequest(ctx context.Context, req *request) context.Context {
+	return context.WithValue(ctx, requestKey{}, req)
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// load returns the loaders for the current execution.
+func (r *request) load() *loaders {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.loaders == nil {
+		r.loaders = newLoaders(r.services)
+	}
+	return r.loaders
+}
+
+// reset drops everything loaded so far. Mutations call it after writing and
+// subscriptions before each event, so later fields don't see stale records.
+func (r *request) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loaders = nil
+}
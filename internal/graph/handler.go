@@ -0,0 +1,201 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/replica"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+)
+
+// keepAlive is how often an idle event stream gets a comment line, so
+// proxies don't time the connection out.
+const keepAlive = 15 * time.Second
+
+// Handler serves GraphQL over HTTP. Responses are JSON unless the client
+// accepts text/event-stream, which subscriptions require. Events are then
+// sent in the distinct connections mode of the graphql-sse protocol: a
+// "next" event per result and a "complete" event at the end.
+type Handler struct {
+	schema   *Schema
+	services Services
+	limits   config.GraphQLConfig
+	// stopping is closed on shutdown to end event streams.
+	stopping <-chan struct{}
+}
+
+func NewHandler(schema *Schema, services Services, limits config.GraphQLConfig, stopping <-chan struct{}) *Handler {
+	return &Handler{schema: schema, services: services, limits: limits, stopping: stopping}
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// Serve handles POST /api/v1/graphql.
+func (h *Handler) Serve(c *gin.Context) {
+	var body graphQLRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.Error(validation.BindError(err))
+		return
+	}
+	stream := strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+
+	doc, op, errs := h.prepare(body)
+	if len(errs) > 0 {
+		h.respond(c, stream, &graphql.Result{Errors: errs})
+		return
+	}
+
+	if op != nil && op.Operation != ast.OperationTypeMutation {
+		replica.ReadOnly(c)
+	}
+
+	req := &request{services: h.services}
+	ctx := c.Request.Context()
+	if userID, err := uuid.Parse(c.GetString(middleware.UserIDKey)); err == nil {
+		req.user = &userID
+		ctx = services.WithActor(ctx, userID)
+	}
+	ctx, cancel := context.WithCancel(withRequest(ctx, req))
+	defer cancel()
+
+	params := graphql.ExecuteParams{
+		Schema:        h.schema.schema,
+		AST:           doc,
+		OperationName: body.OperationName,
+		Args:          body.Variables,
+		Context:       ctx,
+	}
+	if op != nil && op.Operation == ast.OperationTypeSubscription {
+		if !stream {
+			h.respond(c, false, &graphql.Result{Errors: []gqlerrors.FormattedError{
+				requestError("event_stream_required", "subscriptions must accept text/event-stream"),
+			}})
+			return
+		}
+		h.stream(c, cancel, graphql.ExecuteSubscription(params))
+		return
+	}
+
+	result := graphql.Execute(params)
+	result.Errors = formatErrors(ctx, result.Errors)
+	h.respond(c, stream, result)
+}
+
+// prepare parses and validates the query and checks it against the limits.
+// The operation is nil if the document has none matching the request.
+func (h *Handler) prepare(body graphQLRequest) (*ast.Document, *ast.OperationDefinition, []gqlerrors.FormattedError) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(body.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return nil, nil, gqlerrors.FormatErrors(err)
+	}
+	if result := graphql.ValidateDocument(&h.schema.schema, doc, nil); !result.IsValid {
+		return nil, nil, result.Errors
+	}
+
+	op := operation(doc, body.OperationName)
+	if op == nil {
+		return doc, nil, nil
+	}
+	return doc, op, checkLimits(&h.schema.schema, doc, op, body.Variables, h.limits)
+}
+
+// operation returns the operation named name, or the first one if name is
+// empty. Executing the document reports a missing or ambiguous operation.
+func operation(doc *ast.Document, name string) *ast.OperationDefinition {
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if ok && (name == "" || op.Name != nil && op.Name.Value == name) {
+			return op
+		}
+	}
+	return nil
+}
+
+// respond sends a single result, as JSON or as a one event stream.
+func (h *Handler) respond(c *gin.Context, stream bool, result *graphql.Result) {
+	if !stream {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	results := make(chan *graphql.Result, 1)
+	results <- result
+	close(results)
+	h.stream(c, func() {}, results)
+}
+
+// stream sends results as server-sent events until the channel is closed.
+// If the client goes away or the server shuts down, cancel stops the
+// execution and the remaining results are discarded.
+func (h *Handler) stream(c *gin.Context, cancel context.CancelFunc, results <-chan *graphql.Result) {
+	// Subscriptions outlive the server's write timeout.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		var err error
+		select {
+		case result, ok := <-results:
+			if !ok {
+				writeEvent(c, "complete", nil)
+				return
+			}
+			result.Errors = formatErrors(ctx, result.Errors)
+			err = writeEvent(c, "next", result)
+		case <-ticker.C:
+			_, err = fmt.Fprint(c.Writer, ":\n\n")
+			c.Writer.Flush()
+		case <-h.stopping:
+			err = http.ErrServerClosed
+		}
+		if err != nil {
+			cancel()
+			for range results {
+			}
+			return
+		}
+	}
+}
+
+func writeEvent(c *gin.Context, event string, data interface{}) error {
+	payload := []byte{}
+	if data != nil {
+		var err error
+		if payload, err = json.Marshal(data); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+)
+
+// defaultListSize is the number of items assumed for list fields without a
+// limit argument when estimating the complexity of a query.
+const defaultListSize = 10
+
+// checkLimits measures an operation that has passed validation and reports
+// whether it is nested too deeply or would resolve too many fields.
+// Introspection fields aren't counted.
+func checkLimits(schema *graphql.Schema, doc *ast.Document, op *ast.OperationDefinition,
+	variables map[string]interface{}, limits config.GraphQLConfig) []gqlerrors.FormattedError {
+	var root *graphql.Object
+	switch op.Operation {
+	case ast.OperationTypeMutation:
+		root = schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = schema.SubscriptionType()
+	default:
+		root = schema.QueryType()
+	}
+
+	m := &measurer{
+		schema:    schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+		max:       limits.MaxComplexity,
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			m.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	depth, complexity := m.measure(root, op.SelectionSet)
+	var errs []gqlerrors.FormattedError
+	if depth > limits.MaxDepth {
+		errs = append(errs, requestError("query_too_deep",
+			fmt.Sprintf("query is nested %d levels deep, the limit is %d", depth, limits.MaxDepth)))
+	}
+	if complexity > limits.MaxComplexity {
+		errs = append(errs, requestError("query_too_complex",
+			fmt.Sprintf("query complexity exceeds the limit of %d", limits.MaxComplexity)))
+	}
+	return errs
+}
+
+type measurer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	max       int // complexity beyond which counting stops, so products can't overflow
+}
+
+// measure returns the depth of a selection set on an object, interface or
+// union type and the number of fields it is estimated to resolve. Each list
+// field counts its selections once per item it may return.
+func (m *measurer) measure(parent graphql.Type, set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil || parent == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			def, ok := fields(parent)[s.Name.Value]
+			if !ok {
+				continue
+			}
+			child, _ := graphql.GetNamed(def.Type).(graphql.Type)
+			d, c = m.measure(child, s.SelectionSet)
+			d, c = d+1, 1+m.items(def, s)*c
+		case *ast.InlineFragment:
+			d, c = m.measure(m.condition(parent, s.TypeCondition), s.SelectionSet)
+		case *ast.FragmentSpread:
+			// Validation has rejected fragment cycles and unknown fragments.
+			if fragment, ok := m.fragments[s.Name.Value]; ok {
+				d, c = m.measure(m.condition(parent, fragment.TypeCondition), fragment.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity = min(complexity+c, m.max+1)
+	}
+	return depth, complexity
+}
+
+// condition returns the type a fragment's selections are made on: its type
+// condition, or parent for inline fragments without one.
+func (m *measurer) condition(parent graphql.Type, on *ast.Named) graphql.Type {
+	if on == nil {
+		return parent
+	}
+	return m.schema.Type(on.Name.Value)
+}
+
+// fields returns the fields of an object or interface type. Unions have
+// none; their selections are all fragments.
+func fields(t graphql.Type) graphql.FieldDefinitionMap {
+	switch t := t.(type) {
+	case *graphql.Object:
+		return t.Fields()
+	case *graphql.Interface:
+		return t.Fields()
+	}
+	return nil
+}
+
+// items returns how many items a field is assumed to return: one for
+// non-list fields and the limit argument, its default or defaultListSize
+// for lists.
+func (m *measurer) items(def *graphql.FieldDefinition, field *ast.Field) int {
+	t := def.Type
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	if _, ok := t.(*graphql.List); !ok {
+		return 1
+	}
+
+	n := defaultListSize
+	for _, arg := range def.Args {
+		if arg.Name() == "limit" {
+			if v, ok := arg.DefaultValue.(int); ok {
+				n = v
+			}
+		}
+	}
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if i, err := strconv.Atoi(v.Value); err == nil {
+				n = i
+			}
+		case *ast.Variable:
+			if i, ok := intValue(m.variables[v.Name.Value]); ok {
+				n = i
+			}
+		}
+	}
+	return min(max(n, 0), m.max+1)
+}
+
+// intValue converts a JSON decoded variable to an int.
+func intValue(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case json.Number:
+		i, err := strconv.Atoi(v.String())
+		return i, err == nil
+	}
+	return 0, false
+}
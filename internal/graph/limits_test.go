@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+)
+
+// limitCodes returns the codes of the limits query exceeds.
+func limitCodes(t *testing.T, schema *graphql.Schema, query string, variables map[string]interface{},
+	limits config.GraphQLConfig) []string {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := graphql.ValidateDocument(schema, doc, nil); !result.IsValid {
+		t.Fatalf("invalid query: %v", result.Errors)
+	}
+	var codes []string
+	for _, err := range checkLimits(schema, doc, operation(doc, ""), variables, limits) {
+		codes = append(codes, err.Extensions["code"].(string))
+	}
+	return codes
+}
+
+func TestCheckLimits(t *testing.T) {
+	schema, err := NewSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	limits := config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 100}
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      []string
+	}{
+		{"within the limits", `{ tasks { id owner { email } } }`, nil, nil},
+		{"too deep", `{ tasks(limit: 1) { owner { tasks(limit: 1) { owner { email } } } } }`, nil,
+			[]string{"query_too_deep"}},
+		{"too deep in a fragment", `{ ...Deep }
+			fragment Deep on Query { tasks(limit: 1) { owner { tasks(limit: 1) { owner { email } } } } }`, nil,
+			[]string{"query_too_deep"}},
+		{"too deep in an inline fragment", `{ tasks(limit: 1) { ... on Task { owner { tasks(limit: 1) { owner { email } } } } } }`, nil,
+			[]string{"query_too_deep"}},
+		// 1 + 50 tasks * (1 id + 10 comments * 1 id)
+		{"too complex", `{ tasks(limit: 50) { id comments { id } } }`, nil, []string{"query_too_complex"}},
+		{"too complex with a limit variable", `query Tasks($n: Int) { tasks(limit: $n) { id } }`,
+			map[string]interface{}{"n": float64(200)}, []string{"query_too_complex"}},
+		{"too deep and complex", `{ tasks { owner { tasks { owner { email } } } } }`, nil,
+			[]string{"query_too_deep", "query_too_complex"}},
+		{"introspection", `{ __schema { types { fields { type { ofType { name } } } } } }`, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limitCodes(t, &schema.schema, tt.query, tt.variables, limits); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckLimitsCountsInterfaces(t *testing.T) {
+	var node *graphql.Interface
+	var item *graphql.Object
+	fields := graphql.FieldsThunk(func() graphql.Fields {
+		return graphql.Fields{
+			"id":       {Type: graphql.ID},
+			"children": {Type: graphql.NewList(node)},
+		}
+	})
+	node = graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        "Node",
+		Fields:      fields,
+		ResolveType: func(graphql.ResolveTypeParams) *graphql.Object { return item },
+	})
+	item = graphql.NewObject(graphql.ObjectConfig{Name: "Item", Interfaces: []*graphql.Interface{node}, Fields: fields})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: graphql.Fields{
+			"node": {Type: node},
+		}}),
+		Types: []graphql.Type{item},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limits := config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 100}
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"fields of the interface", `{ node { children { children { children { id } } } } }`,
+			[]string{"query_too_deep", "query_too_complex"}},
+		// 1 node + 10 children * (1 + 10 children * 1 id)
+		{"fields of an implementation", `{ node { ... on Item { children { children { id } } } } }`,
+			[]string{"query_too_complex"}},
+		{"a fragment on the interface", `{ node { ...Tree } } fragment Tree on Node { children { children { id } } }`,
+			[]string{"query_too_complex"}},
+		{"within the limits", `{ node { id ... on Item { children { id } } } }`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limitCodes(t, &schema, tt.query, nil, limits); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+// loaders batch the lookups of related records made while resolving one
+// level of a query.
+type loaders struct {
+	users       *loader[models.User]
+	tasks       *loader[models.Task]
+	comments    *loader[[]models.Comment]    // keyed by task ID
+	attachments *loader[[]models.Attachment] // keyed by task ID
+}
+
+func newLoaders(svc Services) *loaders {
+	return &loaders{
+		users: newLoader(func(ctx context.Context, ids []string) (map[string]models.User, error) {
+			users, err := svc.Users.GetByIDs(ctx, ids)
+			return byKey(users, func(u models.User) string { return u.ID.String() }), err
+		}),
+		tasks: newLoader(func(ctx context.Context, ids []string) (map[string]models.Task, error) {
+			tasks, err := svc.Tasks.GetTasksByIDs(ctx, ids)
+			return byKey(tasks, func(t models.Task) string { return t.ID.String() }), err
+		}),
+		comments: newLoader(func(ctx context.Context, taskIDs []string) (map[string][]models.Comment, error) {
+			comments, err := svc.Comments.GetTaskComments(ctx, taskIDs)
+			return groupBy(taskIDs, comments, func(c models.Comment) string { return c.TaskID.String() }), err
+		}),
+		attachments: newLoader(func(ctx context.Context, taskIDs []string) (map[string][]models.Attachment, error) {
+			attachments, err := svc.Tasks.GetAttachments(ctx, taskIDs)
+			return groupBy(taskIDs, attachments, func(a models.Attachment) string { return a.TaskID.String() }), err
+		}),
+	}
+}
+
+// loader collects keys until the first of its thunks is called, then
+// fetches them all at once. graphql-go resolves a level of the query before
+// calling the thunks returned for it, so siblings share one fetch.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	results map[string]result[V]
+}
+
+type result[V any] struct {
+	value V
+	found bool
+	err   error
+}
+
+func newLoader[V any](fetch func(context.Context, []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{fetch: fetch, queued: map[string]bool{}, results: map[string]result[V]{}}
+}
+
+// load queues key and returns a thunk that reports its value and whether
+// it exists.
+func (l *loader[V]) load(ctx context.Context, key string) func() (V, bool, error) {
+	l.mu.Lock()
+	if _, done := l.results[key]; !done && !l.queued[key] {
+		l.pending = append(l.pending, key)
+		l.queued[key] = true
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, done := l.results[key]; !done {
+			l.flush(ctx)
+		}
+		r := l.results[key]
+		return r.value, r.found, r.err
+	}
+}
+
+func (l *loader[V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	clear(l.queued)
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		v, found := values[key]
+		l.results[key] = result[V]{value: v, found: found, err: err}
+	}
+}
+
+func byKey[V any](values []V, key func(V) string) map[string]V {
+	m := make(map[string]V, len(values))
+	for _, v := range values {
+		m[key(v)] = v
+	}
+	return m
+}
+
+// groupBy groups values under the given keys, with an empty group for keys
+// that have no values.
+func groupBy[V any](keys []string, values []V, key func(V) string) map[string][]V {
+	m := make(map[string][]V, len(keys))
+	for _, k := range keys {
+		m[k] = []V{}
+	}
+	for _, v := range values {
+		k := key(v)
+		m[k] = append(m[k], v)
+	}
+	return m
+}
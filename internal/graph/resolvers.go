@@ -0,0 +1,305 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+func queryTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	return one(requestFrom(p.Context).load().tasks.load(p.Context, id.String())), nil
+}
+
+func queryTasks(p graphql.ResolveParams) (interface{}, error) {
+	query := models.TaskListQuery{Page: p.Args["page"].(int), Limit: p.Args["limit"].(int)}
+	query.Status, _ = p.Args["status"].(string)
+	query.Priority, _ = p.Args["priority"].(int)
+	query.UserID, _ = p.Args["userId"].(string)
+	query.Query, _ = p.Args["q"].(string)
+	if len(query.Filters()) > 1 {
+		return nil, apperrors.Validation("only one of status, priority, userId and q may be given")
+	}
+	return listTasks(p, query)
+}
+
+func queryUser(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	return one(requestFrom(p.Context).load().users.load(p.Context, id.String())), nil
+}
+
+func queryAttachment(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := requestFrom(p.Context).services.Attachments.GetAttachment(id)
+	if err != nil {
+		if apperrors.Is(err, apperrors.KindNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func userTasks(p graphql.ResolveParams) (interface{}, error) {
+	user := p.Source.(*models.User)
+	return listTasks(p, models.TaskListQuery{
+		UserID: user.ID.String(),
+		Page:   p.Args["page"].(int),
+		Limit:  p.Args["limit"].(int),
+	})
+}
+
+func listTasks(p graphql.ResolveParams, query models.TaskListQuery) (interface{}, error) {
+	if err := validate(query); err != nil {
+		return nil, err
+	}
+	tasks, err := requestFrom(p.Context).services.Tasks.ListTasks(p.Context, query)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(tasks), nil
+}
+
+func taskOwner(p graphql.ResolveParams) (interface{}, error) {
+	task := p.Source.(*models.Task)
+	if task.UserID == nil {
+		return nil, nil
+	}
+	return one(requestFrom(p.Context).load().users.load(p.Context, task.UserID.String())), nil
+}
+
+func taskComments(p graphql.ResolveParams) (interface{}, error) {
+	task := p.Source.(*models.Task)
+	return many(requestFrom(p.Context).load().comments.load(p.Context, task.ID.String())), nil
+}
+
+func taskAttachments(p graphql.ResolveParams) (interface{}, error) {
+	task := p.Source.(*models.Task)
+	return many(requestFrom(p.Context).load().attachments.load(p.Context, task.ID.String())), nil
+}
+
+func commentAuthor(p graphql.ResolveParams) (interface{}, error) {
+	comment := p.Source.(*models.Comment)
+	if comment.UserID == nil {
+		return nil, nil
+	}
+	return one(requestFrom(p.Context).load().users.load(p.Context, comment.UserID.String())), nil
+}
+
+func commentTask(p graphql.ResolveParams) (interface{}, error) {
+	comment := p.Source.(*models.Comment)
+	return one(requestFrom(p.Context).load().tasks.load(p.Context, comment.TaskID.String())), nil
+}
+
+func attachmentTask(p graphql.ResolveParams) (interface{}, error) {
+	attachment := p.Source.(*models.Attachment)
+	return one(requestFrom(p.Context).load().tasks.load(p.Context, attachment.TaskID.String())), nil
+}
+
+func createTask(p graphql.ResolveParams) (interface{}, error) {
+	args := p.Args["input"].(map[string]interface{})
+	var input models.CreateTaskInput
+	input.Title, _ = args["title"].(string)
+	input.Description, _ = args["description"].(string)
+	input.Status, _ = args["status"].(string)
+	input.Priority, _ = args["priority"].(int)
+	if due, ok := args["dueDate"].(time.Time); ok {
+		input.DueDate = &due
+	}
+	if estimate, ok := args["estimateMinutes"].(int); ok {
+		input.EstimateMinutes = &estimate
+	}
+	projectID, err := optionalID(args, "projectId")
+	if err != nil {
+		return nil, err
+	}
+	input.ProjectID = projectID
+	if labels := optionalStrings(args, "labels"); labels != nil {
+		input.Labels = *labels
+	}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	req := requestFrom(p.Context)
+	task := input.NewTask(req.user)
+	if err := req.services.Tasks.CreateTask(p.Context, &task); err != nil {
+		return nil, err
+	}
+	req.reset()
+	return &task, nil
+}
+
+func updateTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	args := p.Args["input"].(map[string]interface{})
+	var input models.UpdateTaskInput
+	if title, ok := args["title"].(string); ok {
+		input.Title = &title
+	}
+	if description, ok := args["description"].(string); ok {
+		input.Description = &description
+	}
+	if status, ok := args["status"].(string); ok {
+		input.Status = &status
+	}
+	if priority, ok := args["priority"].(int); ok {
+		input.Priority = &priority
+	}
+	if due, ok := args["dueDate"].(time.Time); ok {
+		input.DueDate = &due
+	}
+	if estimate, ok := args["estimateMinutes"].(int); ok {
+		input.EstimateMinutes = &estimate
+	}
+	if input.UserID, err = optionalID(args, "userId"); err != nil {
+		return nil, err
+	}
+	if input.ProjectID, err = optionalID(args, "projectId"); err != nil {
+		return nil, err
+	}
+	input.Labels = optionalStrings(args, "labels")
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	req := requestFrom(p.Context)
+	task, err := req.services.Tasks.UpdateTask(p.Context, id.String(), input)
+	if err != nil {
+		return nil, err
+	}
+	req.reset()
+	return task, nil
+}
+
+func deleteTask(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	req := requestFrom(p.Context)
+	if err := req.services.Tasks.DeleteTask(p.Context, id.String()); err != nil {
+		return nil, err
+	}
+	req.reset()
+	return id.String(), nil
+}
+
+func addComment(p graphql.ResolveParams) (interface{}, error) {
+	taskID, err := parseID(p.Args, "taskId")
+	if err != nil {
+		return nil, err
+	}
+	input := models.CommentInput{Content: p.Args["content"].(string)}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	req := requestFrom(p.Context)
+	if _, err := req.services.Tasks.GetTaskByID(p.Context, taskID.String()); err != nil {
+		return nil, err
+	}
+	comment := input.NewComment(taskID, req.user)
+	if err := req.services.Comments.CreateComment(p.Context, &comment); err != nil {
+		return nil, err
+	}
+	req.reset()
+	return &comment, nil
+}
+
+func deleteComment(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	req := requestFrom(p.Context)
+	if err := req.services.Comments.DeleteComment(p.Context, id.String()); err != nil {
+		return nil, err
+	}
+	req.reset()
+	return id.String(), nil
+}
+
+func createAttachment(p graphql.ResolveParams) (interface{}, error) {
+	args := p.Args["input"].(map[string]interface{})
+	taskID, err := parseID(args, "taskId")
+	if err != nil {
+		return nil, err
+	}
+	input := models.AttachmentInput{TaskID: taskID}
+	input.FileName, _ = args["fileName"].(string)
+	input.FilePath, _ = args["filePath"].(string)
+	input.ContentType, _ = args["contentType"].(string)
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	req := requestFrom(p.Context)
+	attachment, err := req.services.Attachments.CreateAttachment(input)
+	if err != nil {
+		return nil, err
+	}
+	req.reset()
+	return attachment, nil
+}
+
+func deleteAttachment(p graphql.ResolveParams) (interface{}, error) {
+	id, err := parseID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	req := requestFrom(p.Context)
+	if err := req.services.Attachments.DeleteAttachment(id); err != nil {
+		return nil, err
+	}
+	req.reset()
+	return id.String(), nil
+}
+
+// subscribeTaskChanged returns the channel graphql-go reads the events of a
+// taskChanged subscription from. It is closed when the request ends.
+func subscribeTaskChanged(p graphql.ResolveParams) (interface{}, error) {
+	id, err := optionalID(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	events := requestFrom(p.Context).services.Tasks.Subscribe(p.Context)
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		for event := range events {
+			if id != nil && event.Task.ID != *id {
+				continue
+			}
+			select {
+			case out <- event:
+			case <-p.Context.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// taskChanged resolves each event sent by subscribeTaskChanged.
+func taskChanged(p graphql.ResolveParams) (interface{}, error) {
+	requestFrom(p.Context).reset()
+	event := p.Source.(services.TaskEvent)
+	return &event, nil
+}
@@ -0,0 +1,328 @@
+package graph
+
+import (
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+// Schema is the executable GraphQL schema.
+type Schema struct {
+	schema graphql.Schema
+}
+
+var taskStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TaskStatus",
+	Values: graphql.EnumValueConfigMap{
+		"TODO":        {Value: models.TaskStatusTodo},
+		"IN_PROGRESS": {Value: models.TaskStatusInProgress},
+		"DONE":        {Value: models.TaskStatusDone},
+	},
+})
+
+var taskEventTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TaskEventType",
+	Values: graphql.EnumValueConfigMap{
+		"CREATED": {Value: services.TaskCreated},
+		"UPDATED": {Value: services.TaskUpdated},
+		"DELETED": {Value: services.TaskDeleted},
+	},
+})
+
+var createTaskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateTaskInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":           {Type: graphql.NewNonNull(graphql.String)},
+		"description":     {Type: graphql.String},
+		"status":          {Type: taskStatusEnum},
+		"priority":        {Type: graphql.Int},
+		"dueDate":         {Type: graphql.DateTime},
+		"estimateMinutes": {Type: graphql.Int},
+		"projectId":       {Type: graphql.ID},
+		"labels":          {Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+	},
+})
+
+var updateTaskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "UpdateTaskInput",
+	Description: "Omitted fields are left unchanged.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":           {Type: graphql.String},
+		"description":     {Type: graphql.String},
+		"status":          {Type: taskStatusEnum},
+		"priority":        {Type: graphql.Int},
+		"dueDate":         {Type: graphql.DateTime},
+		"estimateMinutes": {Type: graphql.Int},
+		"userId":          {Type: graphql.ID, Description: "Assigns the task to another user."},
+		"projectId":       {Type: graphql.ID},
+		"labels":          {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Replaces the task's labels."},
+	},
+})
+
+var attachmentInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "AttachmentInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"fileName":    {Type: graphql.NewNonNull(graphql.String)},
+		"filePath":    {Type: graphql.NewNonNull(graphql.String)},
+		"contentType": {Type: graphql.NewNonNull(graphql.String)},
+		"taskId":      {Type: graphql.NewNonNull(graphql.ID)},
+	},
+})
+
+var pageArgs = graphql.FieldConfigArgument{
+	"page":  {Type: graphql.Int, DefaultValue: 1},
+	"limit": {Type: graphql.Int, DefaultValue: 20},
+}
+
+var idArg = graphql.FieldConfigArgument{
+	"id": {Type: graphql.NewNonNull(graphql.ID)},
+}
+
+// NewSchema builds the schema. It only fails if the type definitions are
+// inconsistent.
+func NewSchema() (*Schema, error) {
+	var taskType, userType, commentType, attachmentType *graphql.Object
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        {Type: graphql.NewNonNull(graphql.ID), Resolve: get(func(u *models.User) interface{} { return u.ID.String() })},
+				"email":     {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(u *models.User) interface{} { return u.Email })},
+				"role":      {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(u *models.User) interface{} { return u.Role })},
+				"createdAt": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: get(func(u *models.User) interface{} { return u.CreatedAt })},
+				"tasks":     {Type: listOf(taskType), Args: pageArgs, Resolve: resolve(userTasks)},
+			}
+		}),
+	})
+
+	taskType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":              {Type: graphql.NewNonNull(graphql.ID), Resolve: get(func(t *models.Task) interface{} { return t.ID.String() })},
+				"title":           {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(t *models.Task) interface{} { return t.Title })},
+				"description":     {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(t *models.Task) interface{} { return t.Description })},
+				"status":          {Type: graphql.NewNonNull(taskStatusEnum), Resolve: get(func(t *models.Task) interface{} { return t.Status })},
+				"priority":        {Type: graphql.NewNonNull(graphql.Int), Resolve: get(func(t *models.Task) interface{} { return t.Priority })},
+				"dueDate":         {Type: graphql.DateTime, Resolve: get(func(t *models.Task) interface{} { return t.DueDate })},
+				"estimateMinutes": {Type: graphql.Int, Resolve: get(func(t *models.Task) interface{} { return t.EstimateMinutes })},
+				"projectId":       {Type: graphql.ID, Resolve: get(func(t *models.Task) interface{} { return idString(t.ProjectID) })},
+				"labels":          {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), Resolve: get(func(t *models.Task) interface{} { return []string(t.Labels) })},
+				"createdAt":       {Type: graphql.NewNonNull(graphql.DateTime), Resolve: get(func(t *models.Task) interface{} { return t.CreatedAt })},
+				"updatedAt":       {Type: graphql.NewNonNull(graphql.DateTime), Resolve: get(func(t *models.Task) interface{} { return t.UpdatedAt })},
+				"owner":           {Type: userType, Resolve: resolve(taskOwner)},
+				"comments":        {Type: listOf(commentType), Resolve: resolve(taskComments)},
+				"attachments":     {Type: listOf(attachmentType), Resolve: resolve(taskAttachments)},
+			}
+		}),
+	})
+
+	commentType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Comment",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        {Type: graphql.NewNonNull(graphql.ID), Resolve: get(func(c *models.Comment) interface{} { return c.ID.String() })},
+				"content":   {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(c *models.Comment) interface{} { return c.Content })},
+				"createdAt": {Type: graphql.NewNonNull(graphql.DateTime), Resolve: get(func(c *models.Comment) interface{} { return c.CreatedAt })},
+				"author":    {Type: userType, Resolve: resolve(commentAuthor)},
+				"task":      {Type: taskType, Resolve: resolve(commentTask)},
+			}
+		}),
+	})
+
+	attachmentType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Attachment",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          {Type: graphql.NewNonNull(graphql.ID), Resolve: get(func(a *models.Attachment) interface{} { return a.ID.String() })},
+				"fileName":    {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(a *models.Attachment) interface{} { return a.FileName })},
+				"filePath":    {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(a *models.Attachment) interface{} { return a.FilePath })},
+				"contentType": {Type: graphql.NewNonNull(graphql.String), Resolve: get(func(a *models.Attachment) interface{} { return a.ContentType })},
+				"uploadedAt":  {Type: graphql.NewNonNull(graphql.DateTime), Resolve: get(func(a *models.Attachment) interface{} { return a.UploadedAt })},
+				"task":        {Type: taskType, Resolve: resolve(attachmentTask)},
+			}
+		}),
+	})
+
+	taskEventType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TaskEvent",
+		Fields: graphql.Fields{
+			"type":   {Type: graphql.NewNonNull(taskEventTypeEnum), Resolve: get(func(e *services.TaskEvent) interface{} { return e.Type })},
+			"taskId": {Type: graphql.NewNonNull(graphql.ID), Resolve: get(func(e *services.TaskEvent) interface{} { return e.Task.ID.String() })},
+			"task": {
+				Type:        taskType,
+				Description: "The task after the change, null when it was deleted.",
+				Resolve: get(func(e *services.TaskEvent) interface{} {
+					if e.Type == services.TaskDeleted {
+						return nil
+					}
+					return &e.Task
+				}),
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"task": {Type: taskType, Args: idArg, Resolve: resolve(queryTask)},
+			"tasks": {
+				Type: listOf(taskType),
+				Args: graphql.FieldConfigArgument{
+					"status":   {Type: taskStatusEnum},
+					"priority": {Type: graphql.Int},
+					"userId":   {Type: graphql.ID},
+					"q":        {Type: graphql.String},
+					"page":     pageArgs["page"],
+					"limit":    pageArgs["limit"],
+				},
+				Description: "At most one of status, priority, userId and q may be given.",
+				Resolve:     resolve(queryTasks),
+			},
+			"user":       {Type: userType, Args: idArg, Resolve: resolve(queryUser)},
+			"attachment": {Type: attachmentType, Args: idArg, Resolve: resolve(queryAttachment)},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTask": {
+				Type:    graphql.NewNonNull(taskType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(createTaskInput)}},
+				Resolve: resolve(createTask),
+			},
+			"updateTask": {
+				Type: graphql.NewNonNull(taskType),
+				Args: graphql.FieldConfigArgument{
+					"id":    idArg["id"],
+					"input": {Type: graphql.NewNonNull(updateTaskInput)},
+				},
+				Resolve: resolve(updateTask),
+			},
+			"deleteTask": {Type: graphql.NewNonNull(graphql.ID), Args: idArg, Resolve: resolve(deleteTask)},
+			"addComment": {
+				Type: graphql.NewNonNull(commentType),
+				Args: graphql.FieldConfigArgument{
+					"taskId":  {Type: graphql.NewNonNull(graphql.ID)},
+					"content": {Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolve(addComment),
+			},
+			"deleteComment": {Type: graphql.NewNonNull(graphql.ID), Args: idArg, Resolve: resolve(deleteComment)},
+			"createAttachment": {
+				Type:    graphql.NewNonNull(attachmentType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(attachmentInput)}},
+				Resolve: resolve(createAttachment),
+			},
+			"deleteAttachment": {Type: graphql.NewNonNull(graphql.ID), Args: idArg, Resolve: resolve(deleteAttachment)},
+		},
+	})
+
+	subscription := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"taskChanged": {
+				Type:        graphql.NewNonNull(taskEventType),
+				Description: "Changes to every task, or to the one with the given ID.",
+				Args:        graphql.FieldConfigArgument{"id": {Type: graphql.ID}},
+				Subscribe:   resolve(subscribeTaskChanged),
+				Resolve:     taskChanged,
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:        query,
+		Mutation:     mutation,
+		Subscription: subscription,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema}, nil
+}
+
+func listOf(t graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+// get resolves a field from the source object, which resolvers always pass
+// on as a pointer.
+func get[T any](field func(*T) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return field(p.Source.(*T)), nil
+	}
+}
+
+// thunk is the deferred result graphql-go accepts from resolvers. It is
+// called once every field of the current level has been resolved.
+type thunk = func() (interface{}, error)
+
+// one turns a load into a thunk resolving to the record, or null when it
+// doesn't exist.
+func one[V any](load func() (V, bool, error)) thunk {
+	return func() (interface{}, error) {
+		v, found, err := load()
+		if err != nil || !found {
+			return nil, err
+		}
+		return &v, nil
+	}
+}
+
+// many turns a load of a group of records into a thunk.
+func many[V any](load func() ([]V, bool, error)) thunk {
+	return func() (interface{}, error) {
+		v, _, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return pointers(v), nil
+	}
+}
+
+func pointers[V any](values []V) []*V {
+	ptrs := make([]*V, len(values))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	return ptrs
+}
+
+// idString returns an optional ID as a string, or nil.
+func idString(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+// optionalStrings returns the list of strings argument name, or nil if it
+// wasn't given.
+func optionalStrings(args map[string]interface{}, name string) *[]string {
+	list, ok := args[name].([]interface{})
+	if !ok {
+		return nil
+	}
+	strs := make([]string, 0, len(list))
+	for _, v := range list {
+		s, _ := v.(string)
+		strs = append(strs, s)
+	}
+	return &strs
+}
+
+// optionalID returns the ID argument name, or nil if it wasn't given.
+func optionalID(args map[string]interface{}, name string) (*uuid.UUID, error) {
+	if args[name] == nil {
+		return nil, nil
+	}
+	id, err := parseID(args, name)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
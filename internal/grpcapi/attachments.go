@@ -0,0 +1,92 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type attachmentServer struct {
+	taskapiv1.UnimplementedAttachmentServiceServer
+	svc Services
+}
+
+// attachmentInput validates the fields shared by the create and update
+// requests.
+func attachmentInput(taskID, fileName, filePath, contentType string) (models.AttachmentInput, error) {
+	id, err := parseID("task_id", taskID)
+	if err != nil {
+		return models.AttachmentInput{}, err
+	}
+	input := models.AttachmentInput{TaskID: id, FileName: fileName, FilePath: filePath, ContentType: contentType}
+	return input, validate(input)
+}
+
+func (s *attachmentServer) CreateAttachment(ctx context.Context, req *taskapiv1.CreateAttachmentRequest) (*taskapiv1.Attachment, error) {
+	input, err := attachmentInput(req.TaskId, req.FileName, req.FilePath, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := s.svc.Attachments.CreateAttachment(input)
+	if err != nil {
+		return nil, err
+	}
+	return attachmentToProto(attachment), nil
+}
+
+func (s *attachmentServer) GetAttachment(ctx context.Context, req *taskapiv1.GetAttachmentRequest) (*taskapiv1.Attachment, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := s.svc.Attachments.GetAttachment(id)
+	if err != nil {
+		return nil, err
+	}
+	return attachmentToProto(attachment), nil
+}
+
+func (s *attachmentServer) ListTaskAttachments(ctx context.Context, req *taskapiv1.ListTaskAttachmentsRequest) (*taskapiv1.ListTaskAttachmentsResponse, error) {
+	taskID, err := parseID("task_id", req.TaskId)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := s.svc.Attachments.GetTaskAttachments(taskID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taskapiv1.ListTaskAttachmentsResponse{Attachments: make([]*taskapiv1.Attachment, len(attachments))}
+	for i := range attachments {
+		resp.Attachments[i] = attachmentToProto(&attachments[i])
+	}
+	return resp, nil
+}
+
+func (s *attachmentServer) UpdateAttachment(ctx context.Context, req *taskapiv1.UpdateAttachmentRequest) (*taskapiv1.Attachment, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	input, err := attachmentInput(req.TaskId, req.FileName, req.FilePath, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := s.svc.Attachments.UpdateAttachment(id, input)
+	if err != nil {
+		return nil, err
+	}
+	return attachmentToProto(attachment), nil
+}
+
+func (s *attachmentServer) DeleteAttachment(ctx context.Context, req *taskapiv1.DeleteAttachmentRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Attachments.DeleteAttachment(id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,65 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type commentServer struct {
+	taskapiv1.UnimplementedCommentServiceServer
+	svc Services
+}
+
+func (s *commentServer) AddComment(ctx context.Context, req *taskapiv1.AddCommentRequest) (*taskapiv1.Comment, error) {
+	taskID, err := parseID("task_id", req.TaskId)
+	if err != nil {
+		return nil, err
+	}
+	input := models.CommentInput{Content: req.Content}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.svc.Tasks.GetTaskByID(ctx, taskID.String()); err != nil {
+		return nil, err
+	}
+	comment := input.NewComment(taskID, userIDFrom(ctx))
+	if err := s.svc.Comments.CreateComment(ctx, &comment); err != nil {
+		return nil, err
+	}
+	return commentToProto(&comment), nil
+}
+
+func (s *commentServer) ListComments(ctx context.Context, req *taskapiv1.ListCommentsRequest) (*taskapiv1.ListCommentsResponse, error) {
+	taskID, err := parseID("task_id", req.TaskId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.svc.Tasks.GetTaskByID(ctx, taskID.String()); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.svc.Comments.GetTaskComments(ctx, []string{taskID.String()})
+	if err != nil {
+		return nil, err
+	}
+	resp := &taskapiv1.ListCommentsResponse{Comments: make([]*taskapiv1.Comment, len(comments))}
+	for i := range comments {
+		resp.Comments[i] = commentToProto(&comments[i])
+	}
+	return resp, nil
+}
+
+func (s *commentServer) DeleteComment(ctx context.Context, req *taskapiv1.DeleteCommentRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Comments.DeleteComment(ctx, id.String()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,127 @@
+package grpcapi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var taskStatuses = map[taskapiv1.TaskStatus]string{
+	taskapiv1.TaskStatus_TASK_STATUS_TODO:        models.TaskStatusTodo,
+	taskapiv1.TaskStatus_TASK_STATUS_IN_PROGRESS: models.TaskStatusInProgress,
+	taskapiv1.TaskStatus_TASK_STATUS_DONE:        models.TaskStatusDone,
+}
+
+var taskEventTypes = map[string]taskapiv1.TaskEventType{
+	services.TaskCreated: taskapiv1.TaskEventType_TASK_EVENT_TYPE_CREATED,
+	services.TaskUpdated: taskapiv1.TaskEventType_TASK_EVENT_TYPE_UPDATED,
+	services.TaskDeleted: taskapiv1.TaskEventType_TASK_EVENT_TYPE_DELETED,
+}
+
+// statusFromProto returns the model status of s, or "" if it is
+// unspecified. Unknown values are passed on as their number, which fails
+// validation.
+func statusFromProto(s taskapiv1.TaskStatus) string {
+	if s == taskapiv1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return ""
+	}
+	if status, ok := taskStatuses[s]; ok {
+		return status
+	}
+	return strconv.Itoa(int(s))
+}
+
+func statusToProto(status string) taskapiv1.TaskStatus {
+	for s, name := range taskStatuses {
+		if name == status {
+			return s
+		}
+	}
+	return taskapiv1.TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+// timeFromProto converts an optional timestamp. field names it in the
+// error reported for an invalid one.
+func timeFromProto(field string, ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, apperrors.Validation(field+" must be a valid timestamp",
+			apperrors.FieldError{Field: field, Message: "must be a valid timestamp"}).Wrap(err)
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func idToProto(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func taskToProto(t *models.Task) *taskapiv1.Task {
+	return &taskapiv1.Task{
+		Id:          t.ID.String(),
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      statusToProto(t.Status),
+		Priority:    int32(t.Priority),
+		DueDate:     timeToProto(t.DueDate),
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+		UserId:      idToProto(t.UserID),
+	}
+}
+
+func taskEventToProto(e services.TaskEvent) *taskapiv1.TaskEvent {
+	event := &taskapiv1.TaskEvent{Type: taskEventTypes[e.Type], TaskId: e.Task.ID.String()}
+	if e.Type != services.TaskDeleted {
+		event.Task = taskToProto(&e.Task)
+	}
+	return event
+}
+
+func userToProto(u *models.User) *taskapiv1.User {
+	return &taskapiv1.User{
+		Id:        u.ID.String(),
+		Email:     u.Email,
+		Role:      u.Role,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+}
+
+func commentToProto(c *models.Comment) *taskapiv1.Comment {
+	return &taskapiv1.Comment{
+		Id:        c.ID.String(),
+		TaskId:    c.TaskID.String(),
+		UserId:    idToProto(c.UserID),
+		Content:   c.Content,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
+
+func attachmentToProto(a *models.Attachment) *taskapiv1.Attachment {
+	return &taskapiv1.Attachment{
+		Id:         a.ID.String(),
+		TaskId:     a.TaskID.String(),
+		FileName:   a.FileName,
+		FilePath:   a.FilePath,
+		UploadedAt: timestamppb.New(a.UploadedAt),
+	}
+}
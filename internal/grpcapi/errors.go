@@ -0,0 +1,139 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/ratelimit"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to errors,
+// whose reason is the code a REST problem document would carry.
+const ErrorDomain = "taskapi"
+
+// codeOf returns the gRPC code matching an apperrors kind.
+func codeOf(kind apperrors.Kind) codes.Code {
+	switch kind {
+	case apperrors.KindNotFound:
+		return codes.NotFound
+	case apperrors.KindConflict:
+		return codes.AlreadyExists
+	case apperrors.KindValidation:
+		return codes.InvalidArgument
+	case apperrors.KindUnauthorized:
+		return codes.Unauthenticated
+	case apperrors.KindForbidden:
+		return codes.PermissionDenied
+	case apperrors.KindPreconditionFailed:
+		return codes.FailedPrecondition
+	case apperrors.KindTooManyRequests:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
+
+// statusError converts an error returned by an RPC into a status. Domain
+// errors carry their code in an ErrorInfo and their rejected fields as
+// BadRequest violations. The cause of internal errors is logged but not
+// sent to clients.
+func statusError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	appErr := apperrors.As(err)
+	if appErr.Kind == apperrors.KindInternal {
+		logger.FromContext(ctx).ErrorContext(ctx, "call failed", slog.Any("error", appErr.Err))
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: appErr.Code, Domain: ErrorDomain}}
+	if len(appErr.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(appErr.Fields))
+		for i, f := range appErr.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	return withDetails(status.New(codeOf(appErr.Kind), appErr.Message), details...).Err()
+}
+
+// withDetails returns st with details attached, or st alone if they can't
+// be encoded.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	if detailed, err := st.WithDetails(details...); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// rateLimitHeaders sends the state of the caller's bucket as response
+// metadata, like the RateLimit headers of the REST API.
+func rateLimitHeaders(ctx context.Context, res ratelimit.Result) {
+	md := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(res.Limit),
+		"ratelimit-remaining", strconv.Itoa(res.Remaining),
+		"ratelimit-reset", strconv.Itoa(ceilSeconds(res.Reset)),
+	)
+	if !res.Allowed {
+		md.Set("retry-after", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+	}
+	_ = grpc.SetHeader(ctx, md)
+}
+
+// rateLimited is the error of a call over its quota. The RetryInfo detail
+// tells clients when to try again.
+func rateLimited(res ratelimit.Result) error {
+	appErr := apperrors.TooManyRequests("rate limit exceeded")
+	return withDetails(status.New(codes.ResourceExhausted, appErr.Message),
+		&errdetails.ErrorInfo{Reason: appErr.Code, Domain: ErrorDomain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(res.RetryAfter)},
+	).Err()
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+func parseID(field, s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, apperrors.Validation(field+" must be a valid UUID",
+			apperrors.FieldError{Field: field, Message: "must be a valid UUID"}).Wrap(err)
+	}
+	return id, nil
+}
+
+// validate checks the binding rules of an input, like the REST handlers do
+// when binding request bodies.
+func validate(input interface{}) error {
+	if err := binding.Validator.ValidateStruct(input); err != nil {
+		return validation.BindError(err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/ratelimit"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey is the metadata key of the request ID, the counterpart of
+// the X-Request-ID header.
+const requestIDKey = "x-request-id"
+
+// rateLimitGroups maps services to the rate limit group of the matching
+// REST routes, so a client has one quota whichever API it calls. Comments
+// have no REST routes of their own.
+var rateLimitGroups = map[string]string{
+	"taskapi.v1.TaskService":       "tasks",
+	"taskapi.v1.UserService":       "users",
+	"taskapi.v1.CommentService":    "comments",
+	"taskapi.v1.AttachmentService": "attachments",
+}
+
+// interceptors do for calls what the REST middleware does for requests:
+// request IDs, access logs, panic recovery, authentication, rate limits
+// and error rendering.
+type interceptors struct {
+	log          *slog.Logger
+	limiter      *ratelimit.Limiter
+	authenticate Authenticator
+}
+
+func (i *interceptors) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+	ctx, finish := i.begin(ctx, info.FullMethod)
+	defer func() { err = finish(ctx, recover(), err) }()
+
+	if ctx, err = i.admit(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (i *interceptors) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) (err error) {
+	ctx, finish := i.begin(ss.Context(), info.FullMethod)
+	defer func() { err = finish(ctx, recover(), err) }()
+
+	if ctx, err = i.admit(ctx, info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// begin tags the call with a request ID and a logger. The returned function
+// ends the call, given its final context: it turns a recovered panic into
+// an internal error, converts the error into a status and writes the
+// access log line.
+func (i *interceptors) begin(ctx context.Context, method string) (context.Context, func(ctx context.Context, rec interface{}, err error) error) {
+	start := time.Now()
+
+	requestID := ""
+	if values := metadata.ValueFromIncomingContext(ctx, requestIDKey); len(values) > 0 {
+		requestID = values[0]
+	}
+	if !middleware.ValidRequestID(requestID) {
+		requestID = uuid.New().String()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+	ctx = logger.WithContext(ctx, i.log.With(slog.String("request_id", requestID)))
+
+	return ctx, func(ctx context.Context, rec interface{}, err error) error {
+		if rec != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "panic recovered",
+				slog.Any("panic", rec), slog.String("stack", string(debug.Stack())))
+			err = apperrors.Internal(fmt.Errorf("panic: %v", rec))
+		}
+		err = statusError(ctx, err)
+		i.logCall(ctx, method, start, err)
+		return err
+	}
+}
+
+func (i *interceptors) logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	attrs := []any{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("client_ip", clientIP(ctx)),
+	}
+	if userID := userIDFrom(ctx); userID != nil {
+		attrs = append(attrs, slog.String("user_id", userID.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("errors", status.Convert(err).Message()))
+	}
+
+	log := logger.FromContext(ctx)
+	switch code {
+	case codes.OK:
+		log.InfoContext(ctx, "call completed", attrs...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		log.ErrorContext(ctx, "call completed", attrs...)
+	default:
+		log.WarnContext(ctx, "call completed", attrs...)
+	}
+}
+
+// admit authenticates the caller and counts the call against its quota.
+func (i *interceptors) admit(ctx context.Context, method string) (context.Context, error) {
+	ctx, err := i.authorize(ctx)
+	if err != nil {
+		return ctx, err
+	}
+	if i.limiter == nil {
+		return ctx, nil
+	}
+
+	service := strings.Split(strings.TrimPrefix(method, "/"), "/")[0]
+	client := "ip:" + clientIP(ctx)
+	if userID := userIDFrom(ctx); userID != nil {
+		client = "user:" + userID.String()
+	}
+	res, limited := i.limiter.Allow(ctx, rateLimitGroups[service], client)
+	if !limited {
+		return ctx, nil
+	}
+	rateLimitHeaders(ctx, res)
+	if !res.Allowed {
+		return ctx, rateLimited(res)
+	}
+	return ctx, nil
+}
+
+// authorize reads the "authorization: Bearer <token>" metadata and stores
+// the caller's ID in the context. Calls without a valid token are rejected,
+// as are all calls when no authenticator is configured.
+func (i *interceptors) authorize(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 {
+		return ctx, apperrors.Unauthorized("a bearer access token is required")
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return ctx, apperrors.Unauthorized("authorization must be a bearer token")
+	}
+	if i.authenticate == nil {
+		return ctx, apperrors.Unauthorized("authentication is not configured")
+	}
+
+	userID, err := i.authenticate(ctx, token)
+	if err != nil {
+		logger.FromContext(ctx).DebugContext(ctx, "authentication failed", slog.Any("error", err))
+		return ctx, apperrors.Unauthorized("access token is invalid or expired")
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return ctx, apperrors.Internal(fmt.Errorf("authenticator returned user ID %q: %w", userID, err))
+	}
+	return services.WithActor(context.WithValue(ctx, userIDKey{}, id), id), nil
+}
+
+type userIDKey struct{}
+
+// userIDFrom returns the authenticated caller, or nil outside of a call.
+func userIDFrom(ctx context.Context) *uuid.UUID {
+	if id, ok := ctx.Value(userIDKey{}).(uuid.UUID); ok {
+		return &id
+	}
+	return nil
+}
+
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
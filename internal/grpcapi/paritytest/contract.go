@@ -0,0 +1,311 @@
+package paritytest
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/grpcapi"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Tasks runs the task parity checks against the harnesses returned by
+// newHarness, one per subtest.
+func Tasks(t *testing.T, newHarness func(t *testing.T) Harness) {
+	ctx := context.Background()
+
+	t.Run("CreatedOverRESTReadOverGRPC", func(t *testing.T) {
+		h := newHarness(t)
+		resp := h.do(t, http.MethodPost, "/api/v1/tasks/", map[string]interface{}{
+			"title": "from REST", "description": "read over gRPC", "priority": 2,
+		})
+		if resp.status != http.StatusCreated {
+			t.Fatalf("POST /tasks/ = %d %s", resp.status, resp.body)
+		}
+		var created models.Task
+		resp.decode(t, &created)
+
+		got, err := tasksClient(h).GetTask(ctx, &taskapiv1.GetTaskRequest{Id: created.ID.String()})
+		if err != nil {
+			t.Fatalf("GetTask: %v", err)
+		}
+		sameTask(t, created, got)
+	})
+
+	t.Run("CreatedOverGRPCReadOverREST", func(t *testing.T) {
+		h := newHarness(t)
+		created, err := tasksClient(h).CreateTask(ctx, &taskapiv1.CreateTaskRequest{
+			Title:  "from gRPC",
+			Status: taskapiv1.TaskStatus_TASK_STATUS_IN_PROGRESS,
+		})
+		if err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		if created.Priority != models.DefaultTaskPriority {
+			t.Fatalf("CreateTask priority = %d, want the REST default %d", created.Priority, models.DefaultTaskPriority)
+		}
+
+		resp := h.do(t, http.MethodGet, "/api/v1/tasks/"+created.Id, nil)
+		if resp.status != http.StatusOK {
+			t.Fatalf("GET /tasks/%s = %d %s", created.Id, resp.status, resp.body)
+		}
+		var got models.Task
+		resp.decode(t, &got)
+		sameTask(t, got, created)
+	})
+
+	t.Run("UpdateLeavesUnsetFields", func(t *testing.T) {
+		h := newHarness(t)
+		created, err := tasksClient(h).CreateTask(ctx, &taskapiv1.CreateTaskRequest{Title: "before", Description: "kept"})
+		if err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+
+		title := "after"
+		updated, err := tasksClient(h).UpdateTask(ctx, &taskapiv1.UpdateTaskRequest{
+			Id:     created.Id,
+			Title:  &title,
+			Status: taskapiv1.TaskStatus_TASK_STATUS_DONE,
+		})
+		if err != nil {
+			t.Fatalf("UpdateTask: %v", err)
+		}
+
+		resp := h.do(t, http.MethodGet, "/api/v1/tasks/"+created.Id, nil)
+		var got models.Task
+		resp.decode(t, &got)
+		if got.Title != "after" || got.Description != "kept" || got.Status != models.TaskStatusDone {
+			t.Fatalf("GET after UpdateTask = %+v", got)
+		}
+		sameTask(t, got, updated)
+	})
+
+	t.Run("ListFiltersAlike", func(t *testing.T) {
+		h := newHarness(t)
+		for _, status := range []string{models.TaskStatusTodo, models.TaskStatusDone, models.TaskStatusDone} {
+			resp := h.do(t, http.MethodPost, "/api/v1/tasks/", map[string]interface{}{"title": status, "status": status})
+			if resp.status != http.StatusCreated {
+				t.Fatalf("POST /tasks/ = %d %s", resp.status, resp.body)
+			}
+		}
+
+		resp := h.do(t, http.MethodGet, "/api/v1/tasks/?status=done", nil)
+		var rest []models.Task
+		resp.decode(t, &rest)
+		list, err := tasksClient(h).ListTasks(ctx, &taskapiv1.ListTasksRequest{Status: taskapiv1.TaskStatus_TASK_STATUS_DONE})
+		if err != nil {
+			t.Fatalf("ListTasks: %v", err)
+		}
+
+		var restIDs, grpcIDs []string
+		for _, task := range rest {
+			restIDs = append(restIDs, task.ID.String())
+		}
+		for _, task := range list.Tasks {
+			grpcIDs = append(grpcIDs, task.Id)
+		}
+		slices.Sort(restIDs)
+		slices.Sort(grpcIDs)
+		if len(restIDs) != 2 || !slices.Equal(restIDs, grpcIDs) {
+			t.Fatalf("done tasks: REST %v, gRPC %v", restIDs, grpcIDs)
+		}
+	})
+
+	t.Run("DeletedOverGRPCGoneOverREST", func(t *testing.T) {
+		h := newHarness(t)
+		created, err := tasksClient(h).CreateTask(ctx, &taskapiv1.CreateTaskRequest{Title: "doomed"})
+		if err != nil {
+			t.Fatalf("CreateTask: %v", err)
+		}
+		if _, err := tasksClient(h).DeleteTask(ctx, &taskapiv1.DeleteTaskRequest{Id: created.Id}); err != nil {
+			t.Fatalf("DeleteTask: %v", err)
+		}
+		if resp := h.do(t, http.MethodGet, "/api/v1/tasks/"+created.Id, nil); resp.status != http.StatusNotFound {
+			t.Fatalf("GET deleted task = %d, want 404", resp.status)
+		}
+	})
+
+	t.Run("ErrorsAgree", func(t *testing.T) {
+		h := newHarness(t)
+		missing := uuid.NewString()
+		cases := []struct {
+			name string
+			rest func() restResponse
+			rpc  func() error
+		}{
+			{
+				name: "missing task",
+				rest: func() restResponse { return h.do(t, http.MethodGet, "/api/v1/tasks/"+missing, nil) },
+				rpc: func() error {
+					_, err := tasksClient(h).GetTask(ctx, &taskapiv1.GetTaskRequest{Id: missing})
+					return err
+				},
+			},
+			{
+				name: "malformed id",
+				rest: func() restResponse { return h.do(t, http.MethodDelete, "/api/v1/tasks/nope", nil) },
+				rpc: func() error {
+					_, err := tasksClient(h).DeleteTask(ctx, &taskapiv1.DeleteTaskRequest{Id: "nope"})
+					return err
+				},
+			},
+			{
+				name: "invalid input",
+				rest: func() restResponse {
+					return h.do(t, http.MethodPost, "/api/v1/tasks/", map[string]interface{}{"title": "", "priority": 9})
+				},
+				rpc: func() error {
+					_, err := tasksClient(h).CreateTask(ctx, &taskapiv1.CreateTaskRequest{Priority: 9})
+					return err
+				},
+			},
+			{
+				name: "due date in the past",
+				rest: func() restResponse {
+					return h.do(t, http.MethodPost, "/api/v1/tasks/", map[string]interface{}{
+						"title": "late", "due_date": time.Now().Add(-time.Hour),
+					})
+				},
+				rpc: func() error {
+					_, err := tasksClient(h).CreateTask(ctx, &taskapiv1.CreateTaskRequest{
+						Title: "late", DueDate: timestamppb.New(time.Now().Add(-time.Hour)),
+					})
+					return err
+				},
+			},
+			{
+				name: "combined filters",
+				rest: func() restResponse { return h.do(t, http.MethodGet, "/api/v1/tasks/?status=done&priority=1", nil) },
+				rpc: func() error {
+					_, err := tasksClient(h).ListTasks(ctx, &taskapiv1.ListTasksRequest{
+						Status: taskapiv1.TaskStatus_TASK_STATUS_DONE, Priority: 1,
+					})
+					return err
+				},
+			},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				sameError(t, tc.rest(), tc.rpc())
+			})
+		}
+	})
+
+	t.Run("AnonymousRejected", func(t *testing.T) {
+		anonymous := newHarness(t)
+		anonymous.Token = ""
+		_, err := tasksClient(anonymous).ListTasks(ctx, &taskapiv1.ListTasksRequest{})
+		sameError(t, anonymous.do(t, http.MethodGet, "/api/v1/tasks/", nil), err)
+	})
+
+	t.Run("WatchSeesRESTChanges", func(t *testing.T) {
+		h := newHarness(t)
+		watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		stream, err := tasksClient(h).WatchTasks(watchCtx, &taskapiv1.WatchTasksRequest{})
+		if err != nil {
+			t.Fatalf("WatchTasks: %v", err)
+		}
+		// The headers arrive once the server has subscribed.
+		if _, err := stream.Header(); err != nil {
+			t.Fatalf("WatchTasks header: %v", err)
+		}
+
+		resp := h.do(t, http.MethodPost, "/api/v1/tasks/", map[string]interface{}{"title": "watched"})
+		var created models.Task
+		resp.decode(t, &created)
+		h.do(t, http.MethodDelete, "/api/v1/tasks/"+created.ID.String(), nil)
+
+		for _, want := range []taskapiv1.TaskEventType{
+			taskapiv1.TaskEventType_TASK_EVENT_TYPE_CREATED,
+			taskapiv1.TaskEventType_TASK_EVENT_TYPE_DELETED,
+		} {
+			event, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("WatchTasks Recv: %v", err)
+			}
+			if event.Type != want || event.TaskId != created.ID.String() {
+				t.Fatalf("event = %v %s, want %v %s", event.Type, event.TaskId, want, created.ID)
+			}
+		}
+	})
+}
+
+// sameTask fails unless a task returned by REST matches one returned by gRPC.
+func sameTask(t *testing.T, rest models.Task, rpc *taskapiv1.Task) {
+	t.Helper()
+	dueDate := rest.DueDate != nil && rpc.DueDate != nil && rest.DueDate.Equal(rpc.DueDate.AsTime()) ||
+		rest.DueDate == nil && rpc.DueDate == nil
+	owner := ""
+	if rest.UserID != nil {
+		owner = rest.UserID.String()
+	}
+	if rest.ID.String() != rpc.Id || rest.Title != rpc.Title || rest.Description != rpc.Description ||
+		rest.Status != statusName(rpc.Status) || int32(rest.Priority) != rpc.Priority || !dueDate ||
+		!rest.CreatedAt.Equal(rpc.CreatedAt.AsTime()) || owner != rpc.UserId {
+		t.Fatalf("REST task %+v doesn't match gRPC task %v", rest, rpc)
+	}
+}
+
+func statusName(s taskapiv1.TaskStatus) string {
+	switch s {
+	case taskapiv1.TaskStatus_TASK_STATUS_TODO:
+		return models.TaskStatusTodo
+	case taskapiv1.TaskStatus_TASK_STATUS_IN_PROGRESS:
+		return models.TaskStatusInProgress
+	case taskapiv1.TaskStatus_TASK_STATUS_DONE:
+		return models.TaskStatusDone
+	}
+	return ""
+}
+
+// sameError fails unless a REST problem and a gRPC error report the same
+// failure: matching status and code, the problem code as ErrorInfo reason
+// and the same rejected fields.
+func sameError(t *testing.T, rest restResponse, err error) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		t.Fatalf("gRPC error = %v, want a status", err)
+	}
+	if want := gatewayCodes[st.Code()]; rest.status != want {
+		t.Fatalf("REST status %d, gRPC code %v maps to %d", rest.status, st.Code(), want)
+	}
+
+	problem := rest.problem(t)
+	var reason string
+	var fields []string
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.Domain == grpcapi.ErrorDomain {
+				reason = d.Reason
+			}
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	if reason != problem.Code {
+		t.Fatalf("ErrorInfo reason %q, REST code %q", reason, problem.Code)
+	}
+
+	var restFields []string
+	for _, f := range problem.Errors {
+		restFields = append(restFields, f.Field)
+	}
+	// The order follows each API's validation, which may differ.
+	slices.Sort(fields)
+	slices.Sort(restFields)
+	if !slices.Equal(fields, restFields) {
+		t.Fatalf("gRPC rejected fields %v, REST %v", fields, restFields)
+	}
+}
@@ -0,0 +1,130 @@
+// Package paritytest checks that the REST and gRPC APIs agree. Tests wire
+// both to the same services and call Tasks, which performs each operation
+// through one API and reads it back through the other, and compares errors
+// using the HTTP to gRPC code mapping of grpc-gateway.
+package paritytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/grpcapi"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Harness is a REST API and a gRPC API backed by the same services, and an
+// access token of a user both accept. The token is sent with every request
+// and call.
+type Harness struct {
+	REST  http.Handler
+	GRPC  grpc.ClientConnInterface
+	Token string
+}
+
+// Dial serves srv over an in-memory listener and returns a connection to
+// it. Both are closed when the test ends.
+func Dial(t *testing.T, srv *grpcapi.Server) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial gRPC server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// gatewayCodes is how grpc-gateway maps gRPC codes to HTTP statuses, for
+// the codes the API returns. Validation errors are the exception: REST
+// answers 422 where the gateway would answer 400.
+var gatewayCodes = map[codes.Code]int{
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.InvalidArgument:    http.StatusUnprocessableEntity,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Internal:           http.StatusInternalServerError,
+}
+
+// restResponse is a recorded REST response.
+type restResponse struct {
+	status int
+	body   []byte
+}
+
+func (h Harness) do(t *testing.T, method, path string, body interface{}) restResponse {
+	t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			t.Fatalf("encode %s %s body: %v", method, path, err)
+		}
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if h.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.Token)
+	}
+	rec := httptest.NewRecorder()
+	h.REST.ServeHTTP(rec, req)
+	return restResponse{status: rec.Code, body: rec.Body.Bytes()}
+}
+
+func (r restResponse) decode(t *testing.T, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(r.body, v); err != nil {
+		t.Fatalf("decode REST response %s: %v", r.body, err)
+	}
+}
+
+func (r restResponse) problem(t *testing.T) middleware.Problem {
+	t.Helper()
+	var p middleware.Problem
+	r.decode(t, &p)
+	return p
+}
+
+func tasksClient(h Harness) taskapiv1.TaskServiceClient {
+	return taskapiv1.NewTaskServiceClient(authConn{conn: h.GRPC, token: h.Token})
+}
+
+// authConn sends the token, if any, as the authorization metadata of every
+// call.
+type authConn struct {
+	conn  grpc.ClientConnInterface
+	token string
+}
+
+func (c authConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.conn.Invoke(c.outgoing(ctx), method, args, reply, opts...)
+}
+
+func (c authConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.conn.NewStream(c.outgoing(ctx), desc, method, opts...)
+}
+
+func (c authConn) outgoing(ctx context.Context) context.Context {
+	if c.token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+}
@@ -0,0 +1,87 @@
+// Package grpcapi serves the gRPC API defined in proto/taskapi/v1. The RPCs
+// go through the same services as the REST handlers and report errors with
+// the gRPC code matching their apperrors kind.
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sync"
+
+	"github.com/sampathreddy22/task-management-api/internal/ratelimit"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+	"google.golang.org/grpc"
+)
+
+// Services are the collaborators the RPCs call. Tasks must be the instance
+// the REST API uses, so WatchTasks sees changes made through either.
+type Services struct {
+	Tasks       *services.TaskService
+	Comments    *services.CommentService
+	Attachments *services.AttachmentService
+	Users       repositories.UserRepository
+}
+
+// Authenticator resolves the bearer token of a call to the caller's user ID.
+type Authenticator func(ctx context.Context, token string) (userID string, err error)
+
+type Options struct {
+	Logger  *slog.Logger
+	Limiter *ratelimit.Limiter
+	// Authenticate checks the bearer token every call must send, as for the
+	// REST API. Without it every call is rejected.
+	Authenticate Authenticator
+}
+
+// Server is a gRPC server with the API services registered.
+type Server struct {
+	grpc *grpc.Server
+	// stopping is closed on shutdown to end WatchTasks streams, which would
+	// otherwise hold up a graceful stop.
+	stopping chan struct{}
+	stop     sync.Once
+}
+
+func New(svc Services, opts Options) *Server {
+	i := &interceptors{log: opts.Logger, limiter: opts.Limiter, authenticate: opts.Authenticate}
+	s := &Server{
+		grpc: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(i.unary),
+			grpc.ChainStreamInterceptor(i.stream),
+		),
+		stopping: make(chan struct{}),
+	}
+
+	taskapiv1.RegisterTaskServiceServer(s.grpc, &taskServer{svc: svc, stopping: s.stopping})
+	taskapiv1.RegisterUserServiceServer(s.grpc, &userServer{svc: svc})
+	taskapiv1.RegisterCommentServiceServer(s.grpc, &commentServer{svc: svc})
+	taskapiv1.RegisterAttachmentServiceServer(s.grpc, &attachmentServer{svc: svc})
+	return s
+}
+
+// Serve accepts calls on lis until Shutdown is called.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown stops accepting calls and waits for the running ones to finish.
+// They are cancelled if ctx expires first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.stop.Do(func() { close(s.stopping) })
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,168 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type taskServer struct {
+	taskapiv1.UnimplementedTaskServiceServer
+	svc      Services
+	stopping <-chan struct{}
+}
+
+func (s *taskServer) CreateTask(ctx context.Context, req *taskapiv1.CreateTaskRequest) (*taskapiv1.Task, error) {
+	dueDate, err := timeFromProto("due_date", req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	input := models.CreateTaskInput{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      statusFromProto(req.Status),
+		Priority:    int(req.Priority),
+		DueDate:     dueDate,
+	}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	task := input.NewTask(userIDFrom(ctx))
+	if err := s.svc.Tasks.CreateTask(ctx, &task); err != nil {
+		return nil, err
+	}
+	return taskToProto(&task), nil
+}
+
+func (s *taskServer) GetTask(ctx context.Context, req *taskapiv1.GetTaskRequest) (*taskapiv1.Task, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.svc.Tasks.GetTaskByID(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+	return taskToProto(task), nil
+}
+
+func (s *taskServer) UpdateTask(ctx context.Context, req *taskapiv1.UpdateTaskRequest) (*taskapiv1.Task, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	input := models.UpdateTaskInput{Title: req.Title, Description: req.Description}
+	if req.Status != taskapiv1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		status := statusFromProto(req.Status)
+		input.Status = &status
+	}
+	if req.Priority != nil {
+		priority := int(*req.Priority)
+		input.Priority = &priority
+	}
+	if input.DueDate, err = timeFromProto("due_date", req.DueDate); err != nil {
+		return nil, err
+	}
+	if err := validate(input); err != nil {
+		return nil, err
+	}
+
+	task, err := s.svc.Tasks.UpdateTask(ctx, id.String(), input)
+	if err != nil {
+		return nil, err
+	}
+	return taskToProto(task), nil
+}
+
+func (s *taskServer) DeleteTask(ctx context.Context, req *taskapiv1.DeleteTaskRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Tasks.DeleteTask(ctx, id.String()); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// listFields maps the filter names of models.TaskListQuery to the fields
+// of ListTasksRequest.
+var listFields = map[string]string{"status": "status", "priority": "priority", "user_id": "user_id", "q": "query"}
+
+func (s *taskServer) ListTasks(ctx context.Context, req *taskapiv1.ListTasksRequest) (*taskapiv1.ListTasksResponse, error) {
+	query := models.TaskListQuery{
+		Status:   statusFromProto(req.Status),
+		Priority: int(req.Priority),
+		UserID:   req.UserId,
+		Query:    req.Query,
+		Page:     int(req.Page),
+		Limit:    int(req.Limit),
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 20
+	}
+	if err := validate(query); err != nil {
+		return nil, err
+	}
+	if filters := query.Filters(); len(filters) > 1 {
+		return nil, apperrors.Validation("only one of status, priority, user_id and query may be given",
+			apperrors.FieldError{Field: listFields[filters[1]], Message: "can't be combined with " + listFields[filters[0]]})
+	}
+
+	tasks, err := s.svc.Tasks.ListTasks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	resp := &taskapiv1.ListTasksResponse{Tasks: make([]*taskapiv1.Task, len(tasks))}
+	for i := range tasks {
+		resp.Tasks[i] = taskToProto(&tasks[i])
+	}
+	return resp, nil
+}
+
+// WatchTasks sends task events until the client cancels the call or the
+// server shuts down. The response headers are sent once the subscription
+// is in place, so changes made after they arrive are seen.
+func (s *taskServer) WatchTasks(req *taskapiv1.WatchTasksRequest, stream taskapiv1.TaskService_WatchTasksServer) error {
+	var only *uuid.UUID
+	if req.TaskId != "" {
+		id, err := parseID("task_id", req.TaskId)
+		if err != nil {
+			return err
+		}
+		only = &id
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	events := s.svc.Tasks.Subscribe(ctx)
+	// Sending the headers right away tells the client it is subscribed.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			if only != nil && event.Task.ID != *only {
+				continue
+			}
+			if err := stream.Send(taskEventToProto(event)); err != nil {
+				return err
+			}
+		case <-s.stopping:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package grpcapi
+
+import (
+	"context"
+
+	taskapiv1 "github.com/sampathreddy22/task-management-api/pkg/api/taskapi/v1"
+)
+
+type userServer struct {
+	taskapiv1.UnimplementedUserServiceServer
+	svc Services
+}
+
+func (s *userServer) GetUser(ctx context.Context, req *taskapiv1.GetUserRequest) (*taskapiv1.User, error) {
+	id, err := parseID("id", req.Id)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.svc.Users.GetByID(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+	return userToProto(user), nil
+}
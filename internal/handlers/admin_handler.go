@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+)
+
+type AdminHandler struct {
+	configManager *config.Manager
+}
+
+func NewAdminHandler(configManager *config.Manager) *AdminHandler {
+	return &AdminHandler{configManager: configManager}
+}
+
+// ConfigVersion describes the active configuration.
+type ConfigVersion struct {
+	Version        int64                    `json:"version"`
+	LoadedAt       time.Time                `json:"loaded_at"`
+	Profile        string                   `json:"profile"`
+	LogLevel       string                   `json:"log_level"`
+	Features       map[string]bool          `json:"features"`
+	AllowedOrigins []string                 `json:"allowed_origins"`
+	RateLimits     map[string]RateLimitRule `json:"rate_limits"`
+}
+
+type RateLimitRule struct {
+	Requests int    `json:"requests"`
+	Period   string `json:"period"`
+	Burst    int    `json:"burst"`
+}
+
+// GetConfigVersion handles GET /api/v1/admin/config.
+func (h *AdminHandler) GetConfigVersion(c *gin.Context) {
+	cfg := h.configManager.Current()
+
+	rateLimits := map[string]RateLimitRule{}
+	if cfg.RateLimit.Enabled {
+		rateLimits["default"] = newRateLimitRule(cfg.RateLimit.Default)
+		for group, rule := range cfg.RateLimit.Groups {
+			rateLimits[group] = newRateLimitRule(rule)
+		}
+	}
+
+	c.JSON(http.StatusOK, ConfigVersion{
+		Version:        cfg.Version,
+		LoadedAt:       cfg.LoadedAt,
+		Profile:        cfg.Profile,
+		LogLevel:       cfg.Logging.Level,
+		Features:       cfg.Features,
+		AllowedOrigins: cfg.CORS.AllowedOrigins,
+		RateLimits:     rateLimits,
+	})
+}
+
+func newRateLimitRule(rule config.RateLimitRule) RateLimitRule {
+	return RateLimitRule{Requests: rule.Requests, Period: rule.Period.String(), Burst: rule.Burst}
+}
@@ -1,144 +1,189 @@
 package handlers
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
 	"github.com/sampathreddy22/task-management-api/internal/models"
 	"github.com/sampathreddy22/task-management-api/internal/services"
 )
 
 type AttachmentHandler struct {
 	service *services.AttachmentService
+	files   *services.AttachmentFileService
+	// maxFileSize is the largest file accepted for upload, in bytes.
+	maxFileSize int64
 }
 
-func NewAttachmentHandler(service *services.AttachmentService) *AttachmentHandler {
-	return &AttachmentHandler{service: service}
+func NewAttachmentHandler(service *services.AttachmentService, files *services.AttachmentFileService, maxFileSize int64) *AttachmentHandler {
+	return &AttachmentHandler{service: service, files: files, maxFileSize: maxFileSize}
 }
 
-// @Summary Create a new attachment
-// @Description Create a new attachment with the given input
-// @Accept json
-// @Produce json
-// @Param attachment body models.AttachmentInput true "The input for the attachment"
-// @Success 201 {object} models.Attachment
-// @Router /attachments [post]
-// @tags attachments
+// CreateAttachment handles POST /api/v1/attachments/.
 func (h *AttachmentHandler) CreateAttachment(c *gin.Context) {
 	var input models.AttachmentInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidBody(err))
 		return
 	}
 
 	attachment, err := h.service.CreateAttachment(input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, attachment)
 }
 
-// @Summary Get an attachment by ID
-// @Description Get an attachment by ID
-// @Accept json
-// @Produce json
-// @Param id path string true "The ID of the attachment to get"
-// @Success 200 {object} models.Attachment
-// @Router /attachments/{id} [get]
-// @tags attachments
+// GetAttachment handles GET /api/v1/attachments/{id}.
 func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		c.Error(invalidID("id", err))
 		return
 	}
 
 	attachment, err := h.service.GetAttachment(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, attachment)
 }
 
-// @Summary Get all attachments for a task
-// @Description Get all attachments for a task
-// @Accept json
-// @Produce json
-// @Param taskId path string true "The ID of the task to get attachments for"
-// @Success 200 {object} []models.Attachment
-// @Router /attachments/task/{taskId} [get]
-// @tags attachments
+// GetTaskAttachments handles GET /api/v1/attachments/task/{taskId}.
 func (h *AttachmentHandler) GetTaskAttachments(c *gin.Context) {
 	taskID, err := uuid.Parse(c.Param("taskId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID format"})
+		c.Error(invalidID("taskId", err))
 		return
 	}
 
 	attachments, err := h.service.GetTaskAttachments(taskID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, attachments)
 }
 
-// @Summary Update an attachment
-// @Description Update an attachment with the given input
-// @Accept json
-// @Produce json
-// @Param id path string true "The ID of the attachment to update"
-// @Param attachment body models.AttachmentInput true "The input for the attachment"
-// @Success 200 {object} models.Attachment
-// @Router /attachments/{id} [put]
-// @tags attachments
+// UpdateAttachment handles PUT /api/v1/attachments/{id}.
 func (h *AttachmentHandler) UpdateAttachment(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		c.Error(invalidID("id", err))
 		return
 	}
 
 	var input models.AttachmentInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(invalidBody(err))
 		return
 	}
 
 	attachment, err := h.service.UpdateAttachment(id, input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, attachment)
 }
 
-// @Summary Delete an attachment
-// @Description Delete an attachment with the given ID
-// @Accept json
-// @Produce json
-// @Param id path string true "The ID of the attachment to delete"
-// @Success 200
-// @Router /attachments/{id} [delete]
-// @tags attachments
+// DeleteAttachment handles DELETE /api/v1/attachments/{id}.
 func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		c.Error(invalidID("id", err))
 		return
 	}
 
 	if err := h.service.DeleteAttachment(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
 }
+
+// UploadAttachment handles POST /api/v1/tasks/{id}/attachments, taking the
+// file as the "file" field of a multipart/form-data body. The file is
+// streamed to storage rather than buffered.
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.Error(apperrors.Validation("the body must be multipart/form-data").Wrap(err))
+		return
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			c.Error(apperrors.Validation("file is required",
+				apperrors.FieldError{Field: "file", Message: "is required"}))
+			return
+		}
+		if err != nil {
+			c.Error(apperrors.Validation("the body could not be read").Wrap(err))
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		name := part.FileName()
+		if name == "" {
+			name = "file"
+		}
+		content := http.MaxBytesReader(c.Writer, part, h.maxFileSize)
+		attachment, err := h.files.Upload(c.Request.Context(), taskID, name, part.Header.Get("Content-Type"), content)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.Error(apperrors.Validation("the file is larger than "+strconv.FormatInt(h.maxFileSize, 10)+" bytes",
+					apperrors.FieldError{Field: "file", Message: "is too large"}))
+				return
+			}
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, attachment)
+		return
+	}
+}
+
+// DownloadAttachment handles GET /api/v1/attachments/{id}/content.
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	attachment, content, err := h.files.Open(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, -1, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
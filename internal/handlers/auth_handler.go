@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type AuthHandler struct {
+	authService  *services.AuthService
+	signupClosed func() bool
+}
+
+// NewAuthHandler returns the handler of the auth routes. signupClosed is
+// asked on each signup, so that closing signups takes effect on reload.
+func NewAuthHandler(authService *services.AuthService, signupClosed func() bool) *AuthHandler {
+	return &AuthHandler{authService: authService, signupClosed: signupClosed}
+}
+
+// Signup handles POST /api/v1/signup.
+func (h *AuthHandler) Signup(c *gin.Context) {
+	if h.signupClosed() {
+		c.Error(apperrors.Forbidden("signup is closed"))
+		return
+	}
+
+	var input models.Credentials
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	user, err := h.authService.Signup(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, user)
+}
+
+// Login handles POST /api/v1/login.
+func (h *AuthHandler) Login(c *gin.Context) {
+	var input models.Credentials
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	tokens, err := h.authService.Login(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh handles POST /api/v1/refresh.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var input models.RefreshInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	tokens, err := h.authService.Refresh(c.Request.Context(), input.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout handles POST /api/v1/logout, ending the caller's session.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.GetString(middleware.SessionIDKey))
+	if err != nil {
+		c.Error(apperrors.Unauthorized("a bearer access token is required"))
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
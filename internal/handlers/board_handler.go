@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type BoardHandler struct {
+	taskService *services.TaskService
+}
+
+func NewBoardHandler(taskService *services.TaskService) *BoardHandler {
+	return &BoardHandler{taskService: taskService}
+}
+
+// GetBoard handles GET /api/v1/board.
+func (h *BoardHandler) GetBoard(c *gin.Context) {
+	var query models.BoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	board, err := h.taskService.GetBoard(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// GetColumn handles GET /api/v1/board/columns/{status}.
+func (h *BoardHandler) GetColumn(c *gin.Context) {
+	var query models.BoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	column, err := h.taskService.GetBoardColumn(c.Request.Context(), c.Param("status"), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, column)
+}
+
+// UpdateColumn handles PUT /api/v1/board/columns/{status}.
+func (h *BoardHandler) UpdateColumn(c *gin.Context) {
+	var input models.BoardColumnInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	column, err := h.taskService.UpdateBoardColumn(c.Request.Context(), c.Param("status"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, column)
+}
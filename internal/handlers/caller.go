@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+// callerID returns the authenticated caller.
+func callerID(c *gin.Context) (uuid.UUID, error) {
+	caller, err := uuid.Parse(c.GetString(middleware.UserIDKey))
+	if err != nil {
+		return uuid.Nil, apperrors.Unauthorized("authentication is required")
+	}
+	return caller, nil
+}
+
+// actorContext returns the request's context, recording the authenticated
+// caller as the actor of the changes made with it.
+func actorContext(c *gin.Context) context.Context {
+	if caller, err := uuid.Parse(c.GetString(middleware.UserIDKey)); err == nil {
+		return services.WithActor(c.Request.Context(), caller)
+	}
+	return c.Request.Context()
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type CommentHandler struct {
+	commentService *services.CommentService
+	taskService    *services.TaskService
+}
+
+func NewCommentHandler(commentService *services.CommentService, taskService *services.TaskService) *CommentHandler {
+	return &CommentHandler{commentService: commentService, taskService: taskService}
+}
+
+// CreateComment handles POST /api/v1/tasks/{id}/comments. The caller is the
+// comment's author.
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	var input models.CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	if _, err := h.taskService.GetTaskByID(c.Request.Context(), taskID.String()); err != nil {
+		c.Error(err)
+		return
+	}
+	var author *uuid.UUID
+	if userID, err := uuid.Parse(c.GetString(middleware.UserIDKey)); err == nil {
+		author = &userID
+	}
+	comment := input.NewComment(taskID, author)
+	if err := h.commentService.CreateComment(actorContext(c), &comment); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetTaskComments handles GET /api/v1/tasks/{id}/comments.
+func (h *CommentHandler) GetTaskComments(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	if _, err := h.taskService.GetTaskByID(c.Request.Context(), taskID.String()); err != nil {
+		c.Error(err)
+		return
+	}
+	comments, err := h.commentService.GetTaskComments(c.Request.Context(), []string{taskID.String()})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// DeleteComment handles DELETE /api/v1/comments/{id}.
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	if err := h.commentService.DeleteComment(c.Request.Context(), id.String()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
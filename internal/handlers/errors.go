@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+)
+
+func invalidBody(err error) error {
+	return validation.BindError(err)
+}
+
+func invalidID(param string, err error) error {
+	return apperrors.Validation(param+" must be a valid UUID",
+		apperrors.FieldError{Field: param, Message: "must be a valid UUID"}).Wrap(err)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/inbound"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type InboundEmailHandler struct {
+	// inboundEmailService is nil when the gateway is disabled.
+	inboundEmailService *services.InboundEmailService
+	maxSize             int64
+	secret              string
+}
+
+// NewInboundEmailHandler returns the handler for messages of up to maxSize
+// bytes signed with secret. inboundEmailService is nil to reject every
+// message.
+func NewInboundEmailHandler(inboundEmailService *services.InboundEmailService, maxSize int64, secret string) *InboundEmailHandler {
+	return &InboundEmailHandler{inboundEmailService: inboundEmailService, maxSize: maxSize, secret: secret}
+}
+
+// ReceiveEmail handles POST /api/v1/inbound/email, taking a raw RFC 5322
+// message. The recipient query parameters are its envelope recipients.
+// The endpoint takes no access token; instead the message and query are
+// signed as inbound.Sign describes.
+func (h *InboundEmailHandler) ReceiveEmail(c *gin.Context) {
+	if h.inboundEmailService == nil {
+		c.Error(apperrors.Forbidden("the email gateway is disabled"))
+		return
+	}
+
+	raw, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.Error(apperrors.Validation("the message is larger than " + strconv.FormatInt(h.maxSize, 10) + " bytes"))
+			return
+		}
+		c.Error(apperrors.Validation("the message could not be read").Wrap(err))
+		return
+	}
+	if !inbound.Verify(h.secret, c.Request.URL.RawQuery, raw, c.GetHeader(inbound.SignatureHeader)) {
+		c.Error(apperrors.Unauthorized("the message signature is missing or invalid"))
+		return
+	}
+
+	result, err := h.inboundEmailService.Receive(c.Request.Context(), raw, c.QueryArray("recipient"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if result.Duplicate {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+// streamKeepAlive is how often an idle notification stream gets a comment
+// line, so proxies don't time the connection out.
+const streamKeepAlive = 15 * time.Second
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+	// stopping is closed on shutdown to end notification streams.
+	stopping <-chan struct{}
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService, stopping <-chan struct{}) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService, stopping: stopping}
+}
+
+// GetNotifications handles GET /api/v1/notifications.
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	var query models.NotificationListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	notifications, err := h.notificationService.ListNotifications(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// GetUnreadCount handles GET /api/v1/notifications/unread-count.
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	count, err := h.notificationService.CountUnread(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NotificationCount{Count: count})
+}
+
+// MarkRead handles POST /api/v1/notifications/{id}/read.
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	notification, err := h.notificationService.GetNotification(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if userID != notification.UserID {
+		c.Error(apperrors.Forbidden("notifications can only be read by their recipient"))
+		return
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), notification); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+// MarkAllRead handles POST /api/v1/notifications/read-all.
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	count, err := h.notificationService.MarkAllRead(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NotificationCount{Count: count})
+}
+
+// GetPreferences handles GET /api/v1/notifications/preferences.
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	preferences, err := h.notificationService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// UpdatePreferences handles PUT /api/v1/notifications/preferences.
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var input models.NotificationPreferencesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	preferences, err := h.notificationService.SetPreferences(c.Request.Context(), userID, input.Preferences)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// StreamNotifications handles GET /api/v1/notifications/stream, sending the
+// user's push notifications as server-sent events until the client goes
+// away or the server shuts down.
+func (h *NotificationHandler) StreamNotifications(c *gin.Context) {
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	notifications := h.notificationService.Subscribe(ctx, userID)
+
+	// Streams outlive the server's write timeout.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(streamKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+			c.SSEvent("notification", notification)
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ":\n\n")
+		case <-h.stopping:
+			return
+		}
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type ProjectHandler struct {
+	projectService *services.ProjectService
+}
+
+func NewProjectHandler(projectService *services.ProjectService) *ProjectHandler {
+	return &ProjectHandler{projectService: projectService}
+}
+
+// CreateProject handles POST /api/v1/projects.
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var input models.ProjectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	caller, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	project := input.NewProject(caller)
+	if err := h.projectService.CreateProject(c.Request.Context(), &project); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// GetProjects handles GET /api/v1/projects, listing the caller's projects.
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	var query models.ProjectListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	caller, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	projects, err := h.projectService.ListProjects(c.Request.Context(), query, caller)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+// GetProject handles GET /api/v1/projects/{id}.
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	project, err := h.projectService.GetProject(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// UpdateProject handles PUT /api/v1/projects/{id}.
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	var input models.ProjectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	caller, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	project, err := h.projectService.RenameProject(c.Request.Context(), id.String(), input, caller)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject handles DELETE /api/v1/projects/{id}.
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+	caller, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.projectService.DeleteProject(c.Request.Context(), id.String(), caller); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMembers handles GET /api/v1/projects/{id}/members.
+func (h *ProjectHandler) GetMembers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	members, err := h.projectService.ListMembers(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// AddMember handles PUT /api/v1/projects/{id}/members/{userId}.
+func (h *ProjectHandler) AddMember(c *gin.Context) {
+	id, userID, caller, err := memberParams(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.projectService.AddMember(c.Request.Context(), id.String(), userID, caller); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveMember handles DELETE /api/v1/projects/{id}/members/{userId}.
+func (h *ProjectHandler) RemoveMember(c *gin.Context) {
+	id, userID, caller, err := memberParams(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.projectService.RemoveMember(c.Request.Context(), id.String(), userID, caller); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// memberParams returns the project and user named by the path, and the
+// caller.
+func memberParams(c *gin.Context) (id, userID, caller uuid.UUID, err error) {
+	if id, err = uuid.Parse(c.Param("id")); err != nil {
+		return id, userID, caller, invalidID("id", err)
+	}
+	if userID, err = uuid.Parse(c.Param("userId")); err != nil {
+		return id, userID, caller, invalidID("userId", err)
+	}
+	caller, err = callerID(c)
+	return id, userID, caller, err
+}
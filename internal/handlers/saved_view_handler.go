@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type SavedViewHandler struct {
+	savedViewService *services.SavedViewService
+}
+
+func NewSavedViewHandler(savedViewService *services.SavedViewService) *SavedViewHandler {
+	return &SavedViewHandler{savedViewService: savedViewService}
+}
+
+// CreateView handles POST /api/v1/views.
+func (h *SavedViewHandler) CreateView(c *gin.Context) {
+	var input models.SavedViewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	owner, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	view := input.NewSavedView(owner)
+	if err := h.savedViewService.CreateView(c.Request.Context(), &view); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+// GetViews handles GET /api/v1/views.
+func (h *SavedViewHandler) GetViews(c *gin.Context) {
+	viewer, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	views, err := h.savedViewService.ListViews(c.Request.Context(), viewer)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+// GetViewCounts handles GET /api/v1/views/counts.
+func (h *SavedViewHandler) GetViewCounts(c *gin.Context) {
+	viewer, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	counts, err := h.savedViewService.CountViews(c.Request.Context(), viewer)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// GetView handles GET /api/v1/views/{id}.
+func (h *SavedViewHandler) GetView(c *gin.Context) {
+	view, _, err := h.visibleView(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// UpdateView handles PUT /api/v1/views/{id}.
+func (h *SavedViewHandler) UpdateView(c *gin.Context) {
+	var input models.UpdateSavedViewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	view, err := h.ownedView(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	input.Apply(view)
+
+	if err := h.savedViewService.UpdateView(c.Request.Context(), view); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteView handles DELETE /api/v1/views/{id}.
+func (h *SavedViewHandler) DeleteView(c *gin.Context) {
+	view, err := h.ownedView(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.savedViewService.DeleteView(c.Request.Context(), view.ID.String()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetViewTasks handles GET /api/v1/views/{id}/tasks.
+func (h *SavedViewHandler) GetViewTasks(c *gin.Context) {
+	var query models.SavedViewRunQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	view, _, err := h.visibleView(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	tasks, err := h.savedViewService.RunView(c.Request.Context(), view, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetDefaultView handles GET /api/v1/views/default.
+func (h *SavedViewHandler) GetDefaultView(c *gin.Context) {
+	viewer, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	view, err := h.savedViewService.GetDefaultView(c.Request.Context(), viewer)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// SetDefaultView handles PUT /api/v1/views/default.
+func (h *SavedViewHandler) SetDefaultView(c *gin.Context) {
+	var input models.SavedViewDefaultInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	view, err := h.savedViewService.SetDefaultView(c.Request.Context(), userID, input.ViewID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// ClearDefaultView handles DELETE /api/v1/views/default.
+func (h *SavedViewHandler) ClearDefaultView(c *gin.Context) {
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.savedViewService.ClearDefaultView(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// visibleView returns the view named by the path if the viewer can see it,
+// along with the viewer.
+func (h *SavedViewHandler) visibleView(c *gin.Context) (*models.SavedView, uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, uuid.Nil, invalidID("id", err)
+	}
+	viewer, err := callerID(c)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	view, err := h.savedViewService.GetView(c.Request.Context(), id.String(), viewer)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	return view, viewer, nil
+}
+
+// ownedView returns the view named by the path if the viewer owns it.
+// Shared views can be seen but not changed by other users.
+func (h *SavedViewHandler) ownedView(c *gin.Context) (*models.SavedView, error) {
+	view, viewer, err := h.visibleView(c)
+	if err != nil {
+		return nil, err
+	}
+	if view.UserID != viewer {
+		return nil, apperrors.Forbidden("views can only be changed by their owner")
+	}
+	return view, nil
+}
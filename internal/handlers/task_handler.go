@@ -1,12 +1,12 @@
 package handlers
 
 import (
-	"context"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
 	"github.com/sampathreddy22/task-management-api/internal/models"
 	"github.com/sampathreddy22/task-management-api/internal/services"
 )
@@ -19,77 +19,126 @@ func NewTaskHandler(taskService *services.TaskService) *TaskHandler {
 	return &TaskHandler{taskService: taskService}
 }
 
-// @Summary Create a new task
-// @Description Create a new task with the given title and description
-// @Accept json
-// @Produce json
-// @Param task body models.Task true "Task to create"
-// @Success 201 {object} models.Task
-// @Router /tasks [post]
-// @tags tasks
+// CreateTask handles POST /api/v1/tasks/.
 func (h *TaskHandler) CreateTask(c *gin.Context) {
 	// get the task from the request body and create a new task using the task service
-	var task models.Task
-	if err := c.ShouldBindJSON(&task); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var input models.CreateTaskInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
-	task.ID = uuid.New()
-	task.CreatedAt = time.Now()
-	task.UpdatedAt = time.Now()
 
-	if err := h.taskService.CreateTask(context.Background(), &task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var owner *uuid.UUID
+	if userID, err := uuid.Parse(c.GetString(middleware.UserIDKey)); err == nil {
+		owner = &userID
+	}
+	task := input.NewTask(owner)
+
+	if err := h.taskService.CreateTask(actorContext(c), &task); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, task)
 }
 
-// @Summary Get a task by ID
-// @Description Get a task by ID
-// @Accept json
-// @Produce json
-// @Param id path string true "Task ID"
-// @Success 200 {object} models.Task
-// @Router /tasks/{id} [get]
-// @tags tasks
+// GetTaskByID handles GET /api/v1/tasks/{id}.
 func (h *TaskHandler) GetTaskByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	task, err := h.taskService.GetTaskByID(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
+	c.JSON(http.StatusOK, task)
 }
 
-// @Summary Update a task
-// @Description Update a task by ID
-// @Accept json
-// @Produce json
-// @Param id path string true "Task ID"
-// @Param task body models.Task true "Task to update"
-// @Success 200 {object} models.Task
-// @Router /tasks/{id} [put]
-// @tags tasks
+// UpdateTask handles PUT /api/v1/tasks/{id}.
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
 
+	var input models.UpdateTaskInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	task, err := h.taskService.UpdateTask(actorContext(c), id.String(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
 }
 
-// @Summary Delete a task
-// @Description Delete a task by ID
-// @Accept json
-// @Produce json
-// @Param id path string true "Task ID"
-// @Success 204
-// @Router /tasks/{id} [delete]
-// @tags tasks
+// DeleteTask handles DELETE /api/v1/tasks/{id}.
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	if err := h.taskService.DeleteTask(c.Request.Context(), id.String()); err != nil {
+		c.Error(err)
+		return
+	}
 
+	c.Status(http.StatusNoContent)
 }
 
-// @Summary Get all tasks
-// @Description Get all tasks
-// @Accept json
-// @Produce json
-// @Success 200 {array} models.Task
-// @Router /tasks [get]
-// @tags tasks
+// GetTasks handles GET /api/v1/tasks/.
 func (h *TaskHandler) GetTasks(c *gin.Context) {
+	var query models.TaskListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	if filters := query.Filters(); len(filters) > 1 {
+		c.Error(apperrors.Validation("only one of status, priority, user_id and q may be given",
+			apperrors.FieldError{Field: filters[1], Message: "can't be combined with " + filters[0]}))
+		return
+	}
+
+	tasks, err := h.taskService.ListTasks(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// MoveTask handles POST /api/v1/tasks/{id}/move.
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	var move models.TaskMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	task, err := h.taskService.MoveTask(actorContext(c), id.String(), move)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
+	c.JSON(http.StatusOK, task)
 }
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type TaskTemplateHandler struct {
+	templateService *services.TaskTemplateService
+}
+
+func NewTaskTemplateHandler(templateService *services.TaskTemplateService) *TaskTemplateHandler {
+	return &TaskTemplateHandler{templateService: templateService}
+}
+
+// CreateTemplate handles POST /api/v1/templates.
+func (h *TaskTemplateHandler) CreateTemplate(c *gin.Context) {
+	var input models.TaskTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	template := input.NewTaskTemplate()
+	if err := h.templateService.CreateTemplate(c.Request.Context(), &template); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetTemplates handles GET /api/v1/templates.
+func (h *TaskTemplateHandler) GetTemplates(c *gin.Context) {
+	var query models.TemplateListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	templates, err := h.templateService.ListTemplates(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetTemplate handles GET /api/v1/templates/{id}.
+func (h *TaskTemplateHandler) GetTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateTemplate handles PUT /api/v1/templates/{id}.
+func (h *TaskTemplateHandler) UpdateTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	var input models.UpdateTaskTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	input.Apply(template)
+
+	if err := h.templateService.UpdateTemplate(c.Request.Context(), template); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /api/v1/templates/{id}.
+func (h *TaskTemplateHandler) DeleteTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), id.String()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// InstantiateTemplate handles POST /api/v1/templates/{id}/instantiate.
+func (h *TaskTemplateHandler) InstantiateTemplate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	// The body is optional for templates without variables.
+	var input models.InstantiateTemplateInput
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.Error(invalidBody(err))
+			return
+		}
+	}
+
+	// The tasks are owned by the caller, as with POST /tasks.
+	var owner *uuid.UUID
+	if userID, err := uuid.Parse(c.GetString(middleware.UserIDKey)); err == nil {
+		owner = &userID
+	}
+
+	tasks, err := h.templateService.Instantiate(actorContext(c), id.String(), input, owner)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tasks)
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/services"
+)
+
+type TimeEntryHandler struct {
+	timeEntryService *services.TimeEntryService
+}
+
+func NewTimeEntryHandler(timeEntryService *services.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{timeEntryService: timeEntryService}
+}
+
+// StartTimer handles POST /api/v1/tasks/{id}/timer/start.
+func (h *TimeEntryHandler) StartTimer(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	// The body is optional.
+	var input models.TimerStart
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.Error(invalidBody(err))
+			return
+		}
+	}
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := h.timeEntryService.StartTimer(c.Request.Context(), taskID.String(), userID, input.Note)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// StopTimer handles POST /api/v1/timer/stop.
+func (h *TimeEntryHandler) StopTimer(c *gin.Context) {
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := h.timeEntryService.StopTimer(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// GetTimer handles GET /api/v1/timer.
+func (h *TimeEntryHandler) GetTimer(c *gin.Context) {
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := h.timeEntryService.GetRunningTimer(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// CreateTimeEntry handles POST /api/v1/tasks/{id}/time-entries.
+func (h *TimeEntryHandler) CreateTimeEntry(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	var input models.TimeEntryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	userID, err := callerID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry := input.NewTimeEntry(taskID, userID)
+	if err := h.timeEntryService.CreateTimeEntry(c.Request.Context(), &entry); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// GetTaskTimeEntries handles GET /api/v1/tasks/{id}/time-entries.
+func (h *TimeEntryHandler) GetTaskTimeEntries(c *gin.Context) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	var query models.TimeEntryListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	entries, err := h.timeEntryService.ListTaskTimeEntries(c.Request.Context(), taskID.String(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetTimeEntry handles GET /api/v1/time-entries/{id}.
+func (h *TimeEntryHandler) GetTimeEntry(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	entry, err := h.timeEntryService.GetTimeEntry(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// UpdateTimeEntry handles PUT /api/v1/time-entries/{id}.
+func (h *TimeEntryHandler) UpdateTimeEntry(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	var input models.UpdateTimeEntryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	entry, err := h.timeEntryService.GetTimeEntry(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := ownEntry(c, entry); err != nil {
+		c.Error(err)
+		return
+	}
+
+	input.Apply(entry)
+
+	if err := h.timeEntryService.UpdateTimeEntry(c.Request.Context(), entry); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// DeleteTimeEntry handles DELETE /api/v1/time-entries/{id}.
+func (h *TimeEntryHandler) DeleteTimeEntry(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("id", err))
+		return
+	}
+
+	entry, err := h.timeEntryService.GetTimeEntry(c.Request.Context(), id.String())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := ownEntry(c, entry); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.timeEntryService.DeleteTimeEntry(c.Request.Context(), id.String()); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTimeReport handles GET /api/v1/reports/time.
+func (h *TimeEntryHandler) GetTimeReport(c *gin.Context) {
+	var query models.TimeReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	report, err := h.timeEntryService.Report(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if query.Format != "csv" {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="time-report.csv"`)
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{report.GroupBy, "entries", "seconds", "hours"})
+	for _, row := range report.Rows {
+		w.Write([]string{row.Key, strconv.Itoa(row.Entries), strconv.FormatInt(row.Seconds, 10), hours(row.Seconds)})
+	}
+	w.Write([]string{"total", "", strconv.FormatInt(report.TotalSeconds, 10), hours(report.TotalSeconds)})
+	w.Flush()
+}
+
+func hours(seconds int64) string {
+	return fmt.Sprintf("%.2f", float64(seconds)/3600)
+}
+
+// ownEntry rejects changes to the time entries of other users than the
+// caller.
+func ownEntry(c *gin.Context, entry *models.TimeEntry) error {
+	caller, err := callerID(c)
+	if err != nil {
+		return err
+	}
+	if entry.UserID != caller {
+		return apperrors.Forbidden("time can only be tracked for yourself")
+	}
+	return nil
+}
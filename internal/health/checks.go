@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+// requiredTables are created by the initial migration.
+var requiredTables = []string{"users", "tasks", "comments", "attachments"}
+
+// DatabaseCheck pings the connection pool.
+func DatabaseCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return fmt.Errorf("failed to get database instance: %w", err)
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// MigrationCheck fails while the last migration is dirty or the schema is
+// missing tables the API depends on.
+func MigrationCheck(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		conn := db.WithContext(ctx)
+		if conn.Migrator().HasTable("schema_migrations") {
+			var state struct {
+				Version int64
+				Dirty   bool
+			}
+			if err := conn.Table("schema_migrations").Select("version, dirty").Limit(1).Scan(&state).Error; err != nil {
+				return fmt.Errorf("failed to read migration state: %w", err)
+			}
+			if state.Dirty {
+				return fmt.Errorf("migration %d is dirty", state.Version)
+			}
+		}
+
+		for _, table := range requiredTables {
+			if !conn.Migrator().HasTable(table) {
+				return fmt.Errorf("table %q is missing", table)
+			}
+		}
+		return nil
+	}
+}
+
+// DirectoryCheck verifies that a local storage directory exists and is writable.
+func DirectoryCheck(path string) CheckFunc {
+	return func(ctx context.Context) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return errors.New("storage path is not a directory")
+		}
+
+		f, err := os.CreateTemp(path, ".readyz-*")
+		if err != nil {
+			return fmt.Errorf("storage path is not writable: %w", err)
+		}
+		name := f.Name()
+		f.Close()
+		return os.Remove(name)
+	}
+}
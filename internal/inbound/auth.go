@@ -0,0 +1,113 @@
+package inbound
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// SignatureHeader carries the signature of a message posted to the email
+// gateway's HTTP endpoint, as returned by Sign.
+const SignatureHeader = "X-Inbound-Signature"
+
+// Sign returns the signature of a message posted with the raw query
+// string: "sha256=" and the hex HMAC-SHA256, keyed with secret, of the
+// query, a newline and the message. The query is signed so that a signed
+// message can't be replayed to other recipients.
+func Sign(secret, rawQuery string, message []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(rawQuery))
+	mac.Write([]byte("\n"))
+	mac.Write(message)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the one Sign returns.
+func Verify(secret, rawQuery string, message []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, rawQuery, message)))
+}
+
+// AuthResult is a result of an Authentication-Results header (RFC 8601),
+// such as the spf=pass in
+//
+//	Authentication-Results: mx.example.com; spf=pass smtp.mailfrom=ann@example.com
+type AuthResult struct {
+	// AuthservID names the mail server that added the header.
+	AuthservID string
+	// Method is the check, such as spf, dkim or dmarc, and Result its
+	// outcome, such as pass or fail; both lowercased.
+	Method, Result string
+	// Properties maps a property, such as header.d, to its value.
+	Properties map[string]string
+}
+
+var comment = regexp.MustCompile(`\([^()]*\)`)
+
+// parseAuthResults returns the results of Authentication-Results header
+// values. Malformed results are skipped.
+func parseAuthResults(values []string) []AuthResult {
+	var results []AuthResult
+	for _, value := range values {
+		parts := strings.Split(comment.ReplaceAllString(value, " "), ";")
+		// The authserv-id may be followed by a version.
+		id := strings.Fields(parts[0])
+		if len(id) == 0 {
+			continue
+		}
+		for _, part := range parts[1:] {
+			fields := strings.Fields(part)
+			if len(fields) == 0 {
+				continue
+			}
+			method, result, ok := strings.Cut(fields[0], "=")
+			if !ok {
+				continue
+			}
+			method, _, _ = strings.Cut(method, "/")
+			r := AuthResult{
+				AuthservID: strings.ToLower(id[0]),
+				Method:     strings.ToLower(method),
+				Result:     strings.ToLower(result),
+				Properties: map[string]string{},
+			}
+			for _, field := range fields[1:] {
+				if key, value, ok := strings.Cut(field, "="); ok {
+					r.Properties[strings.ToLower(key)] = strings.Trim(value, `"`)
+				}
+			}
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// Authenticated reports whether the mail server named authservID found
+// that the message comes from the domain of its From address: a DMARC,
+// DKIM or SPF pass for that domain or a parent of it. Results added by
+// other servers are ignored, since senders can write them; the server
+// must remove those claiming to be its own.
+func (m *Message) Authenticated(authservID string) bool {
+	from := m.From[strings.LastIndexByte(m.From, '@')+1:]
+	for _, r := range m.AuthResults {
+		if r.AuthservID != strings.ToLower(authservID) || r.Result != "pass" {
+			continue
+		}
+		var domain string
+		switch r.Method {
+		case "dmarc":
+			domain = r.Properties["header.from"]
+		case "dkim":
+			domain = r.Properties["header.d"]
+		case "spf":
+			mailFrom := r.Properties["smtp.mailfrom"]
+			domain = mailFrom[strings.LastIndexByte(mailFrom, '@')+1:]
+		}
+		domain = strings.ToLower(domain)
+		if domain != "" && (from == domain || strings.HasSuffix(from, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
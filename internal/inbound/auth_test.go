@@ -0,0 +1,51 @@
+package inbound
+
+import "testing"
+
+func TestVerify(t *testing.T) {
+	const secret = "0123456789abcdef0123456789abcdef"
+	message := []byte("Subject: Fix the build\r\n\r\nIt fails.\r\n")
+	signature := Sign(secret, "recipient=tasks%40example.com", message)
+
+	if !Verify(secret, "recipient=tasks%40example.com", message, signature) {
+		t.Error("the signature doesn't verify")
+	}
+	for name, ok := range map[string]bool{
+		"another secret":    Verify(secret+"x", "recipient=tasks%40example.com", message, signature),
+		"another recipient": Verify(secret, "recipient=ops%40example.com", message, signature),
+		"another message":   Verify(secret, "recipient=tasks%40example.com", append(message, '!'), signature),
+		"no signature":      Verify(secret, "recipient=tasks%40example.com", message, ""),
+	} {
+		if ok {
+			t.Errorf("verified with %s", name)
+		}
+	}
+}
+
+func TestAuthenticated(t *testing.T) {
+	tests := []struct {
+		name    string
+		results string
+		want    bool
+	}{
+		{"spf pass", "mx.example.net; spf=pass smtp.mailfrom=ada@example.com", true},
+		{"dkim pass for a parent domain", "MX.example.net 1; dkim=pass (2048-bit key) header.d=example.com", true},
+		{"dmarc pass", `mx.example.net; spf=fail smtp.mailfrom=x@evil.test; dmarc=pass header.from="mail.example.com"`, true},
+		{"spf fail", "mx.example.net; spf=fail smtp.mailfrom=ada@mail.example.com", false},
+		{"pass for another domain", "mx.example.net; dkim=pass header.d=evil.test; spf=pass smtp.mailfrom=a@le.com", false},
+		{"pass from another server", "mx.evil.test; spf=pass smtp.mailfrom=ada@mail.example.com", false},
+		{"no results", "mx.example.net; none", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := Parse([]byte("From: Ada <ada@mail.example.com>\r\nAuthentication-Results: " + tt.results +
+				"\r\nSubject: Fix the build\r\n\r\nIt fails.\r\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := msg.Authenticated("mx.example.net"); got != tt.want {
+				t.Errorf("Authenticated = %v, want %v for %+v", got, tt.want, msg.AuthResults)
+			}
+		})
+	}
+}
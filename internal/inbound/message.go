@@ -0,0 +1,260 @@
+// Package inbound receives email for the email gateway: it parses RFC 5322
+// messages, checks who sent them and runs the SMTP listener that accepts
+// them.
+package inbound
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits on the MIME structure of a message, which would otherwise let a
+// small message fan out into a lot of work.
+const (
+	maxParts = 100
+	maxDepth = 10
+)
+
+// Message is the part of an email the gateway uses.
+type Message struct {
+	// MessageID is the message's Message-ID without angle brackets, or
+	// empty if it has none.
+	MessageID string
+	// References are the Message-IDs in In-Reply-To and References, the
+	// messages this one replies to.
+	References []string
+	// From is the sender's address, lowercased.
+	From string
+	// To are the addresses in To and Cc, lowercased.
+	To      []string
+	Subject string
+	// Text is the plain text body, or the HTML body converted to text if
+	// there is no plain text one.
+	Text        string
+	Attachments []Attachment
+	// AuthResults are the results of the Authentication-Results headers
+	// added by the mail servers the message went through.
+	AuthResults []AuthResult
+}
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// Parse parses a raw RFC 5322 message. Errors describe what is wrong with
+// the message.
+func Parse(raw []byte) (*Message, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("malformed message: %w", err)
+	}
+
+	from, err := m.Header.AddressList("From")
+	if err != nil || len(from) != 1 {
+		return nil, errors.New("the message must have a single From address")
+	}
+	msg := &Message{
+		MessageID:   firstID(m.Header.Get("Message-Id")),
+		References:  messageIDs(m.Header.Get("In-Reply-To") + " " + m.Header.Get("References")),
+		From:        strings.ToLower(from[0].Address),
+		Subject:     decodeHeader(m.Header.Get("Subject")),
+		AuthResults: parseAuthResults(m.Header["Authentication-Results"]),
+	}
+	for _, key := range []string{"To", "Cc"} {
+		// Unparseable recipients are ignored; the envelope says where a
+		// message goes.
+		addrs, _ := m.Header.AddressList(key)
+		for _, addr := range addrs {
+			msg.To = append(msg.To, strings.ToLower(addr.Address))
+		}
+	}
+
+	p := &parser{msg: msg}
+	if err := p.part(m.Header, m.Body, 0); err != nil {
+		return nil, err
+	}
+	msg.Text = p.text
+	if msg.Text == "" && p.html != "" {
+		msg.Text = htmlToText(p.html)
+	}
+	msg.Text = strings.TrimSpace(strings.ReplaceAll(msg.Text, "\r\n", "\n"))
+	return msg, nil
+}
+
+type parser struct {
+	msg   *Message
+	parts int
+	text  string
+	html  string
+}
+
+// part collects the body text and attachments of a MIME entity.
+func (p *parser) part(header map[string][]string, body io.Reader, depth int) error {
+	if p.parts++; p.parts > maxParts || depth > maxDepth {
+		return errors.New("the message has too many MIME parts")
+	}
+	get := func(key string) string {
+		if v := header[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	mediaType, params, err := mime.ParseMediaType(get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if params["boundary"] == "" {
+			return errors.New("a multipart part has no boundary")
+		}
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("malformed multipart body: %w", err)
+			}
+			if err := p.part(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("malformed %s part: %w", mediaType, err)
+	}
+
+	disposition, dparams, _ := mime.ParseMediaType(get("Content-Disposition"))
+	name := dparams["filename"]
+	if name == "" {
+		name = params["name"]
+	}
+	name = decodeHeader(name)
+	switch {
+	case disposition == "attachment" || name != "":
+		if name == "" {
+			name = "attachment"
+		}
+		p.msg.Attachments = append(p.msg.Attachments, Attachment{FileName: name, ContentType: mediaType, Data: data})
+	case mediaType == "text/plain" && p.text == "":
+		p.text = toUTF8(data, params["charset"])
+	case mediaType == "text/html" && p.html == "":
+		p.html = toUTF8(data, params["charset"])
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// toUTF8 converts text in the charset to UTF-8. Charsets other than
+// UTF-8, ASCII and Latin-1 are assumed to be mostly ASCII.
+func toUTF8(data []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	default:
+		return strings.ToValidUTF8(string(data), string(utf8.RuneError))
+	}
+}
+
+var wordDecoder = &mime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(toUTF8(data, charset)), nil
+	},
+}
+
+// decodeHeader decodes the RFC 2047 encoded words in a header value.
+func decodeHeader(value string) string {
+	decoded, err := wordDecoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+var messageID = regexp.MustCompile(`<([^<>\s]+)>`)
+
+// messageIDs returns the Message-IDs in a header value.
+func messageIDs(value string) []string {
+	var ids []string
+	for _, match := range messageID.FindAllStringSubmatch(value, -1) {
+		ids = append(ids, match[1])
+	}
+	return ids
+}
+
+func firstID(value string) string {
+	if ids := messageIDs(value); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+var (
+	invisible = regexp.MustCompile(`(?is)<(script|style|head)\b.*?</(script|style|head)\s*>`)
+	lineBreak = regexp.MustCompile(`(?i)<(br|/p|/div|/li|/tr|/h[1-6])\b[^>]*>`)
+	tag       = regexp.MustCompile(`<[^>]*>`)
+	blank     = regexp.MustCompile(`\n[ \t]*(\n[ \t]*)+`)
+)
+
+// htmlToText reduces an HTML body to its text, keeping line breaks.
+func htmlToText(body string) string {
+	body = invisible.ReplaceAllString(body, "")
+	body = lineBreak.ReplaceAllString(body, "\n")
+	body = html.UnescapeString(tag.ReplaceAllString(body, ""))
+	return blank.ReplaceAllString(body, "\n\n")
+}
+
+// quoteHeader matches the line mail clients put above the quoted message
+// in a reply, such as "On Mon, 2 Mar 2026, Ann <ann@example.com> wrote:".
+var quoteHeader = regexp.MustCompile(`^(On\s.*\swrote:|-+\s*Original Message\s*-+)$`)
+
+// Reply returns the text of a reply without the message it quotes.
+func Reply(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if quoteHeader.MatchString(trimmed) {
+			break
+		}
+		if !strings.HasPrefix(trimmed, ">") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,417 @@
+package inbound
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+)
+
+// maxRecipients is the number of recipients accepted per message.
+const maxRecipients = 100
+
+// maxLine is the longest command or message line read. RFC 5321 allows
+// 1000 bytes; more is accepted from clients that don't fold long lines.
+const maxLine = 64 << 10
+
+// Deliver handles a message accepted by the SMTP server. from and to are
+// the envelope sender and recipients. It returns the text of the reply to
+// the client. Errors with an apperrors kind other than internal reject the
+// message; other errors ask the client to try again later.
+type Deliver func(ctx context.Context, from string, to []string, data []byte) (string, error)
+
+type Options struct {
+	Logger *slog.Logger
+	// Hostname is announced in the greeting.
+	Hostname string
+	// MaxSize is the largest message accepted, in bytes.
+	MaxSize int64
+	// Timeout is how long the server waits for a command or a message.
+	Timeout time.Duration
+	// Accept reports whether mail for the recipient is accepted.
+	Accept func(rcpt string) bool
+	// Relays are the networks of the mail servers allowed to connect.
+	// Others are turned away: they could add the Authentication-Results
+	// headers the messages are trusted by themselves.
+	Relays []netip.Prefix
+}
+
+// ParseRelays parses addresses and CIDR ranges into Options.Relays.
+func ParseRelays(addrs []string) ([]netip.Prefix, error) {
+	relays := make([]netip.Prefix, 0, len(addrs))
+	for _, addr := range addrs {
+		prefix, err := netip.ParsePrefix(addr)
+		if err != nil {
+			ip, ipErr := netip.ParseAddr(addr)
+			if ipErr != nil {
+				return nil, fmt.Errorf("relay %q is neither an address nor a CIDR range", addr)
+			}
+			prefix = netip.PrefixFrom(ip.Unmap(), ip.Unmap().BitLen())
+		}
+		relays = append(relays, prefix.Masked())
+	}
+	return relays, nil
+}
+
+// Server is a minimal SMTP server (RFC 5321) passing the messages it
+// receives to Deliver. It doesn't support TLS or authentication, and only
+// talks to the mail servers in Options.Relays.
+type Server struct {
+	deliver Deliver
+	opts    Options
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[*session]struct{}
+	closing  atomic.Bool
+	wg       sync.WaitGroup
+}
+
+func NewServer(deliver Deliver, opts Options) *Server {
+	return &Server{deliver: deliver, opts: opts, conns: make(map[*session]struct{})}
+}
+
+// Serve accepts connections on lis until Shutdown is called.
+func (s *Server) Serve(lis net.Listener) error {
+	s.mu.Lock()
+	s.listener = lis
+	s.mu.Unlock()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			if s.closing.Load() {
+				return nil
+			}
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+		sess := &session{server: s, conn: conn, r: bufio.NewReaderSize(conn, maxLine), w: bufio.NewWriter(conn)}
+		s.mu.Lock()
+		if s.closing.Load() {
+			s.mu.Unlock()
+			conn.Close()
+			return nil
+		}
+		s.conns[sess] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			sess.serve()
+			s.mu.Lock()
+			delete(s.conns, sess)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// Shutdown stops accepting connections, closes the idle ones and waits for
+// messages being delivered. Connections still open when ctx expires are
+// closed.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.closing.Store(true)
+	s.mu.Lock()
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		s.closeIdle(false)
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			s.closeIdle(true)
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// relays reports whether addr is one of Options.Relays.
+func (s *Server) relays(addr net.Addr) bool {
+	addrPort, err := netip.ParseAddrPort(addr.String())
+	if err != nil {
+		return false
+	}
+	ip := addrPort.Addr().Unmap()
+	for _, relay := range s.opts.Relays {
+		if relay.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) closeIdle(all bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sess := range s.conns {
+		if all || !sess.busy.Load() {
+			sess.conn.Close()
+		}
+	}
+}
+
+// session is an SMTP conversation with a client.
+type session struct {
+	server *Server
+	conn   net.Conn
+	r      *bufio.Reader
+	w      *bufio.Writer
+	// busy is set while a message is received and delivered, when
+	// shutting down must wait.
+	busy atomic.Bool
+
+	helo string
+	from *string
+	to   []string
+}
+
+func (sess *session) serve() {
+	defer sess.conn.Close()
+	log := sess.server.opts.Logger.With(slog.String("remote_addr", sess.conn.RemoteAddr().String()))
+
+	if !sess.server.relays(sess.conn.RemoteAddr()) {
+		log.Warn("refused SMTP connection from a client that isn't a relay")
+		sess.reply(554, "5.7.1 "+sess.server.opts.Hostname+" only accepts mail from its relays")
+		return
+	}
+	sess.reply(220, sess.server.opts.Hostname+" ESMTP ready")
+	for {
+		if err := sess.conn.SetReadDeadline(time.Now().Add(sess.server.opts.Timeout)); err != nil {
+			return
+		}
+		line, err := sess.readLine()
+		if errors.Is(err, bufio.ErrBufferFull) {
+			sess.reply(500, "5.5.2 line too long")
+			return
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) && !sess.server.closing.Load() {
+				log.Debug("SMTP connection ended", slog.Any("error", err))
+			}
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		if !sess.command(strings.ToUpper(verb), strings.TrimSpace(arg), log) {
+			return
+		}
+	}
+}
+
+// command runs a command and reports whether the session goes on.
+func (sess *session) command(verb, arg string, log *slog.Logger) bool {
+	switch verb {
+	case "HELO", "EHLO":
+		if arg == "" {
+			sess.reply(501, "5.5.4 a domain is required")
+			return true
+		}
+		sess.helo, sess.from, sess.to = arg, nil, nil
+		if verb == "HELO" {
+			sess.reply(250, sess.server.opts.Hostname)
+		} else {
+			sess.reply(250, sess.server.opts.Hostname, "8BITMIME", "SIZE "+strconv.FormatInt(sess.server.opts.MaxSize, 10))
+		}
+	case "MAIL":
+		sess.mail(arg)
+	case "RCPT":
+		sess.rcpt(arg)
+	case "DATA":
+		sess.data(log)
+	case "RSET":
+		sess.from, sess.to = nil, nil
+		sess.reply(250, "2.0.0 OK")
+	case "NOOP":
+		sess.reply(250, "2.0.0 OK")
+	case "VRFY":
+		sess.reply(252, "2.5.0 cannot verify the user")
+	case "QUIT":
+		sess.reply(221, "2.0.0 bye")
+		return false
+	case "STARTTLS", "AUTH":
+		sess.reply(502, "5.5.1 not supported")
+	default:
+		sess.reply(500, "5.5.2 unknown command")
+	}
+	return true
+}
+
+func (sess *session) mail(arg string) {
+	switch {
+	case sess.helo == "":
+		sess.reply(503, "5.5.1 send EHLO first")
+		return
+	case sess.from != nil:
+		sess.reply(503, "5.5.1 sender already given")
+		return
+	}
+	addr, params, ok := pathArg(arg, "FROM:")
+	if !ok {
+		sess.reply(501, "5.5.4 expected MAIL FROM:<address>")
+		return
+	}
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "SIZE") {
+			if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > sess.server.opts.MaxSize {
+				sess.reply(552, "5.3.4 message too big")
+				return
+			}
+		}
+	}
+	sess.from = &addr
+	sess.reply(250, "2.1.0 OK")
+}
+
+func (sess *session) rcpt(arg string) {
+	if sess.from == nil {
+		sess.reply(503, "5.5.1 send MAIL first")
+		return
+	}
+	addr, _, ok := pathArg(arg, "TO:")
+	if !ok || addr == "" {
+		sess.reply(501, "5.5.4 expected RCPT TO:<address>")
+		return
+	}
+	if len(sess.to) == maxRecipients {
+		sess.reply(452, "4.5.3 too many recipients")
+		return
+	}
+	if _, err := mail.ParseAddress(addr); err != nil || !sess.server.opts.Accept(strings.ToLower(addr)) {
+		sess.reply(550, "5.1.1 no such mailbox")
+		return
+	}
+	sess.to = append(sess.to, strings.ToLower(addr))
+	sess.reply(250, "2.1.5 OK")
+}
+
+func (sess *session) data(log *slog.Logger) {
+	if len(sess.to) == 0 {
+		sess.reply(503, "5.5.1 send RCPT first")
+		return
+	}
+	sess.busy.Store(true)
+	defer sess.busy.Store(false)
+	sess.reply(354, "end data with <CR><LF>.<CR><LF>")
+	if err := sess.conn.SetReadDeadline(time.Now().Add(sess.server.opts.Timeout)); err != nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	tooBig := false
+	for {
+		line, err := sess.r.ReadSlice('\n')
+		if err != nil {
+			if errors.Is(err, bufio.ErrBufferFull) {
+				sess.reply(500, "5.5.2 line too long")
+			}
+			sess.conn.Close()
+			return
+		}
+		if bytes.Equal(bytes.TrimRight(line, "\r\n"), []byte(".")) {
+			break
+		}
+		// Lines starting with a dot are sent with another one.
+		line = bytes.TrimPrefix(line, []byte("."))
+		if tooBig = tooBig || int64(buf.Len()+len(line)) > sess.server.opts.MaxSize; !tooBig {
+			buf.Write(line)
+		}
+	}
+	from, to := *sess.from, sess.to
+	sess.from, sess.to = nil, nil
+	if tooBig {
+		sess.reply(552, "5.3.4 message too big")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sess.server.opts.Timeout)
+	defer cancel()
+	text, err := sess.server.deliver(ctx, from, to, buf.Bytes())
+	if err == nil {
+		sess.reply(250, "2.0.0 "+text)
+		return
+	}
+	appErr := apperrors.As(err)
+	switch appErr.Kind {
+	case apperrors.KindInternal:
+		log.Error("failed to deliver inbound email", slog.Any("error", err))
+		sess.reply(451, "4.3.0 temporary failure, try again later")
+	case apperrors.KindForbidden:
+		sess.reply(550, "5.7.1 "+appErr.Message)
+	case apperrors.KindNotFound:
+		sess.reply(550, "5.1.1 "+appErr.Message)
+	case apperrors.KindTooManyRequests:
+		sess.reply(421, "4.7.0 "+appErr.Message)
+	default:
+		sess.reply(550, "5.6.0 "+appErr.Message)
+	}
+}
+
+func (sess *session) readLine() (string, error) {
+	line, err := sess.r.ReadSlice('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+// reply sends a reply with the code, one line per text.
+func (sess *session) reply(code int, lines ...string) {
+	for i, line := range lines {
+		sep := "-"
+		if i == len(lines)-1 {
+			sep = " "
+		}
+		// Replies mustn't span lines.
+		line = strings.NewReplacer("\r", " ", "\n", " ").Replace(line)
+		fmt.Fprintf(sess.w, "%d%s%s\r\n", code, sep, line)
+	}
+	sess.conn.SetWriteDeadline(time.Now().Add(sess.server.opts.Timeout))
+	sess.w.Flush()
+}
+
+// pathArg parses the argument of MAIL and RCPT, a keyword such as "FROM:"
+// followed by an address in angle brackets and parameters.
+func pathArg(arg, keyword string) (addr string, params []string, ok bool) {
+	if len(arg) < len(keyword) || !strings.EqualFold(arg[:len(keyword)], keyword) {
+		return "", nil, false
+	}
+	rest := strings.TrimSpace(arg[len(keyword):])
+	if !strings.HasPrefix(rest, "<") {
+		return "", nil, false
+	}
+	end := strings.IndexByte(rest, '>')
+	if end < 0 {
+		return "", nil, false
+	}
+	return rest[1:end], strings.Fields(rest[end+1:]), true
+}
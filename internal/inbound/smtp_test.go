@@ -0,0 +1,182 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+)
+
+// delivered is a message passed to Deliver.
+type delivered struct {
+	from string
+	to   []string
+	data string
+}
+
+// startServer serves SMTP on a loopback port to clients from relay,
+// accepting mail for tasks@example.com, and returns its address and the
+// messages delivered.
+func startServer(t *testing.T, relay string, deliver Deliver) (addr string, messages func() []delivered) {
+	t.Helper()
+	var (
+		mu  sync.Mutex
+		got []delivered
+	)
+	srv := NewServer(func(ctx context.Context, from string, to []string, data []byte) (string, error) {
+		mu.Lock()
+		got = append(got, delivered{from: from, to: to, data: string(data)})
+		mu.Unlock()
+		return deliver(ctx, from, to, data)
+	}, Options{
+		Logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Hostname: "example.com",
+		MaxSize:  1 << 10,
+		Timeout:  5 * time.Second,
+		Accept:   func(rcpt string) bool { return rcpt == "tasks@example.com" },
+		Relays:   []netip.Prefix{netip.MustParsePrefix(relay)},
+	})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(lis) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown: %v", err)
+		}
+		if err := <-served; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return lis.Addr().String(), func() []delivered {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]delivered(nil), got...)
+	}
+}
+
+// send sends msg from ada@example.com to the recipients and returns the
+// error of the first step that failed.
+func send(t *testing.T, addr, msg string, rcpts ...string) error {
+	t.Helper()
+	c, err := smtp.Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.Hello("client.example.com"); err != nil {
+		return err
+	}
+	if err := c.Mail("ada@example.com"); err != nil {
+		return err
+	}
+	for _, rcpt := range rcpts {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// wantCode fails unless err is an SMTP reply with code and an enhanced
+// status starting with status.
+func wantCode(t *testing.T, err error, code int, status string) {
+	t.Helper()
+	var reply *textproto.Error
+	if !errors.As(err, &reply) || reply.Code != code || !strings.HasPrefix(reply.Msg, status) {
+		t.Fatalf("got %v, want a %d %s reply", err, code, status)
+	}
+}
+
+const testMessage = "From: Ada <ada@example.com>\r\nSubject: Fix the build\r\n\r\n.dotted line\r\n"
+
+func TestServerDelivers(t *testing.T) {
+	addr, messages := startServer(t, "127.0.0.0/8", func(ctx context.Context, from string, to []string, data []byte) (string, error) {
+		return "created task 1", nil
+	})
+	if err := send(t, addr, testMessage, "Tasks@example.com"); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	got := messages()
+	if len(got) != 1 {
+		t.Fatalf("delivered %d messages, want 1", len(got))
+	}
+	if got[0].from != "ada@example.com" || len(got[0].to) != 1 || got[0].to[0] != "tasks@example.com" {
+		t.Errorf("envelope from %q to %q", got[0].from, got[0].to)
+	}
+	if got[0].data != testMessage {
+		t.Errorf("data %q, want %q", got[0].data, testMessage)
+	}
+}
+
+func TestServerRejects(t *testing.T) {
+	addr, messages := startServer(t, "127.0.0.0/8", func(ctx context.Context, from string, to []string, data []byte) (string, error) {
+		if strings.Contains(string(data), "Subject: Retry") {
+			return "", errors.New("database unavailable")
+		}
+		return "", apperrors.Forbidden("the sender failed SPF, DKIM and DMARC checks")
+	})
+
+	wantCode(t, send(t, addr, testMessage, "someone@example.com"), 550, "5.1.1")
+	wantCode(t, send(t, addr, strings.Repeat("x", 2<<10)+"\r\n", "tasks@example.com"), 552, "5.3.4")
+	if got := messages(); len(got) != 0 {
+		t.Fatalf("delivered %d rejected messages", len(got))
+	}
+
+	wantCode(t, send(t, addr, testMessage, "tasks@example.com"), 550, "5.7.1 the sender failed")
+	wantCode(t, send(t, addr, strings.Replace(testMessage, "Fix the build", "Retry", 1), "tasks@example.com"), 451, "4.3.0")
+}
+
+func TestServerOnlyTalksToRelays(t *testing.T) {
+	addr, messages := startServer(t, "192.0.2.0/24", func(ctx context.Context, from string, to []string, data []byte) (string, error) {
+		return "created task 1", nil
+	})
+	_, err := smtp.Dial(addr)
+	wantCode(t, err, 554, "5.7.1")
+	if got := messages(); len(got) != 0 {
+		t.Fatalf("delivered %d messages from a client that isn't a relay", len(got))
+	}
+}
+
+func TestParseRelays(t *testing.T) {
+	relays, err := ParseRelays([]string{"::ffff:192.0.2.1", "2001:db8::/32", "198.51.100.7/24"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+	}
+	if !slices.Equal(relays, want) {
+		t.Errorf("ParseRelays = %v, want %v", relays, want)
+	}
+	if _, err := ParseRelays([]string{"mx.example.com"}); err == nil {
+		t.Error("ParseRelays accepted a host name")
+	}
+}
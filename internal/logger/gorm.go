@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const slowQueryThreshold = 200 * time.Millisecond
+
+// GormLogger routes GORM query logs through slog. Query parameters are
+// never logged so that values such as password hashes stay out of the logs.
+type GormLogger struct {
+	log   *slog.Logger
+	level gormlogger.LogLevel
+}
+
+// NewGormLogger logs every query at debug level, failed queries at error
+// and slow ones at warn. Filtering follows the slog level, which can change
+// at runtime.
+func NewGormLogger(log *slog.Logger) *GormLogger {
+	return &GormLogger{log: log, level: gormlogger.Info}
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.logger(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.logger(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.logger(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	log := l.logger(ctx)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	if !failed && elapsed <= slowQueryThreshold && !log.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []any{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Duration("elapsed", elapsed),
+	}
+
+	switch {
+	case failed && l.level >= gormlogger.Error:
+		log.ErrorContext(ctx, "database query failed", append(attrs, slog.Any("error", err))...)
+	case elapsed > slowQueryThreshold && l.level >= gormlogger.Warn:
+		log.WarnContext(ctx, "slow database query", attrs...)
+	case l.level >= gormlogger.Info:
+		log.DebugContext(ctx, "database query", attrs...)
+	}
+}
+
+// ParamsFilter drops bound parameters so only the parameterized SQL is logged.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// logger prefers the request scoped logger so queries carry the request ID.
+func (l *GormLogger) logger(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if rl, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+			return rl
+		}
+	}
+	return l.log
+}
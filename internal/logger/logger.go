@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/sampathreddy22/task-management-api/internal/config"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys lists attribute keys whose values must never reach the logs.
+// Keys ending in one of them, such as "access_token", are sensitive too.
+var sensitiveKeys = []string{
+	"password", "password_hash", "secret", "token", "authorization", "cookie", "dsn", "api_key", "private_key",
+}
+
+type ctxKey struct{}
+
+// level is shared by every logger built by New so it can be changed at runtime.
+var level = new(slog.LevelVar)
+
+// New builds a slog logger from the logging section of the configuration.
+func New(cfg config.LoggingConfig) *slog.Logger {
+	return NewWithWriter(cfg, os.Stdout)
+}
+
+func NewWithWriter(cfg config.LoggingConfig, w io.Writer) *slog.Logger {
+	level.Set(ParseLevel(cfg.Level))
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(handler)
+}
+
+// SetLevel changes the minimum level of every logger built by New.
+func SetLevel(name string) {
+	level.Set(ParseLevel(name))
+}
+
+// ParseLevel maps a config level name to a slog level, defaulting to info.
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithContext returns a copy of ctx carrying the given logger.
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the request scoped logger stored in ctx, or the default logger.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+// IsSensitive reports whether a key names a secret value. Only the last
+// element of a dotted key is looked at, as a whole word, so that
+// "redis.password" and "refresh_token" are sensitive but "token_count" is
+// not. Header style and camelCase keys are matched as if snake_case.
+func IsSensitive(key string) bool {
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		key = key[i+1:]
+	}
+	key = snakeCase(key)
+	for _, s := range sensitiveKeys {
+		if key == s || strings.HasSuffix(key, "_"+s) {
+			return true
+		}
+	}
+	return false
+}
+
+// snakeCase lowercases key and separates its words with underscores, so
+// "X-Api-Key" and "apiKey" both become "x_api_key" and "api_key".
+func snakeCase(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case r == '-' || r == ' ':
+			r = '_'
+		case r >= 'A' && r <= 'Z':
+			if i > 0 && key[i-1] >= 'a' && key[i-1] <= 'z' {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
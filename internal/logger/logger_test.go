@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/config"
+)
+
+func TestRedaction(t *testing.T) {
+	for _, format := range []string{"json", "text"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			log := NewWithWriter(config.LoggingConfig{Level: "info", Format: format}, &out)
+			log.Info("request",
+				slog.String("password", "hunter2"),
+				slog.String("refresh_token", "refresh-secret"),
+				slog.String("Authorization", "Bearer access-secret"),
+				slog.String("X-Api-Key", "key-secret"),
+				slog.String("apiKey", "camel-secret"),
+				slog.Group("redis", slog.String("password", "redis-secret")),
+				slog.Int("token_count", 3),
+				slog.String("route", "/api/v1/login"),
+			)
+
+			got := out.String()
+			for _, secret := range []string{"hunter2", "refresh-secret", "access-secret", "key-secret", "camel-secret", "redis-secret"} {
+				if strings.Contains(got, secret) {
+					t.Errorf("%q reached the log: %s", secret, got)
+				}
+			}
+			for _, kept := range []string{"token_count", "/api/v1/login", redacted} {
+				if !strings.Contains(got, kept) {
+					t.Errorf("%q is missing from the log: %s", kept, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsSensitive(t *testing.T) {
+	for key, want := range map[string]bool{
+		"password":       true,
+		"access_token":   true,
+		"accessToken":    true,
+		"Authorization":  true,
+		"Set-Cookie":     true,
+		"redis.password": true,
+		"auth.secret":    true,
+		"token_count":    false,
+		"tokens_used":    false,
+		"passwordless":   false,
+		"user_id":        false,
+	} {
+		if got := IsSensitive(key); got != want {
+			t.Errorf("IsSensitive(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const startTimeKey = "metrics:start_time"
+
+// InstrumentDB exports the connection pool statistics of db and times every
+// GORM operation executed through it.
+func (m *Metrics) InstrumentDB(db *gorm.DB, name string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	if err := m.InstrumentPool(sqlDB, name); err != nil {
+		return err
+	}
+
+	cb := db.Callback()
+	hooks := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, h := range hooks {
+		if err := h.before("metrics:before_"+h.operation, startTimer); err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", h.operation, err)
+		}
+		if err := h.after("metrics:after_"+h.operation, m.observeQuery(h.operation)); err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", h.operation, err)
+		}
+	}
+	return nil
+}
+
+// InstrumentPool exports the statistics of a connection pool that isn't
+// behind its own *gorm.DB, such as a read replica. name must be unique.
+func (m *Metrics) InstrumentPool(pool *sql.DB, name string) error {
+	if err := m.registry.Register(collectors.NewDBStatsCollector(pool, name)); err != nil {
+		return fmt.Errorf("failed to register db stats collector for %s: %w", name, err)
+	}
+	return nil
+}
+
+func startTimer(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func (m *Metrics) observeQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		outcome := "success"
+		if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+			outcome = "error"
+		}
+
+		m.dbQueryDuration.WithLabelValues(operation, table, outcome).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+const collectTimeout = 5 * time.Second
+
+// taskCollector computes domain gauges from the tasks table at scrape time.
+type taskCollector struct {
+	db *gorm.DB
+
+	byStatus *prometheus.Desc
+	overdue  *prometheus.Desc
+}
+
+// RegisterTaskCollector publishes task counts by status and the number of
+// overdue tasks.
+func (m *Metrics) RegisterTaskCollector(db *gorm.DB) error {
+	return m.registry.Register(&taskCollector{
+		db: db,
+		byStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "tasks", "by_status"),
+			"Number of tasks per status.",
+			[]string{"status"}, nil,
+		),
+		overdue: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "tasks", "overdue"),
+			"Number of tasks past their due date that are not done.",
+			nil, nil,
+		),
+	})
+}
+
+func (c *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.byStatus
+	ch <- c.overdue
+}
+
+func (c *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	var counts []struct {
+		Status string
+		Count  int64
+	}
+	err := c.db.WithContext(ctx).
+		Table("tasks").
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&counts).Error
+	if err != nil {
+		slog.Warn("failed to collect task status metrics", slog.Any("error", err))
+		ch <- prometheus.NewInvalidMetric(c.byStatus, err)
+	} else {
+		for _, row := range counts {
+			ch <- prometheus.MustNewConstMetric(c.byStatus, prometheus.GaugeValue, float64(row.Count), row.Status)
+		}
+	}
+
+	var overdue int64
+	err = c.db.WithContext(ctx).
+		Table("tasks").
+		Where("due_date < ? AND status <> ?", time.Now(), "done").
+		Count(&overdue).Error
+	if err != nil {
+		slog.Warn("failed to collect overdue task metrics", slog.Any("error", err))
+		ch <- prometheus.NewInvalidMetric(c.overdue, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.overdue, prometheus.GaugeValue, float64(overdue))
+}
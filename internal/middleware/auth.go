@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+)
+
+const (
+	// RoleKey is the gin context key under which authentication stores the
+	// caller's role.
+	RoleKey = "role"
+	// SessionIDKey is the gin context key under which authentication stores
+	// the ID of the caller's session.
+	SessionIDKey = "sessionID"
+)
+
+// Identity is who an access token was issued to.
+type Identity struct {
+	UserID    string
+	Role      string
+	SessionID string
+}
+
+// Verifier returns the identity an access token was issued to, or an
+// error if the token isn't valid.
+type Verifier func(ctx context.Context, token string) (Identity, error)
+
+// Authenticate rejects requests without a valid bearer token, and records
+// the caller of the others under UserIDKey, RoleKey and SessionIDKey.
+func Authenticate(verify Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer`)
+			RenderProblem(c, apperrors.Unauthorized("a bearer access token is required"))
+			return
+		}
+		identity, err := verify(c.Request.Context(), token)
+		if err != nil {
+			appErr := apperrors.As(err)
+			if appErr.Kind == apperrors.KindUnauthorized {
+				c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			}
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(UserIDKey, identity.UserID)
+		c.Set(RoleKey, identity.Role)
+		c.Set(SessionIDKey, identity.SessionID)
+		c.Next()
+	}
+}
+
+// RequireRole rejects callers whose role, as recorded by Authenticate, isn't
+// role. It must run after Authenticate.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(RoleKey) != role {
+			RenderProblem(c, apperrors.Forbidden("this requires the "+role+" role"))
+			return
+		}
+		c.Next()
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
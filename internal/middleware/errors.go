@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document.
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      string                 `json:"code"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Errors    []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+// Errors renders the last error attached with c.Error as problem+json.
+// Handlers only need to call c.Error(err) and return.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := apperrors.As(c.Errors.Last().Err)
+		if err.Kind == apperrors.KindInternal {
+			logger.FromContext(c.Request.Context()).ErrorContext(c.Request.Context(), "request failed",
+				slog.Any("error", err.Err))
+		}
+		RenderProblem(c, err)
+	}
+}
+
+// RenderProblem writes err as a problem details response and aborts the chain.
+func RenderProblem(c *gin.Context, err *apperrors.Error) {
+	status := err.Status()
+	problem := Problem{
+		Type:      "/problems/" + err.Code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    err.Message,
+		Instance:  c.Request.URL.Path,
+		Code:      err.Code,
+		RequestID: c.GetString(RequestIDKey),
+		Errors:    err.Fields,
+	}
+
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(status, problem)
+}
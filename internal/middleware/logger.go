@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+)
+
+// UserIDKey is the gin context key under which authentication stores the caller's ID.
+const UserIDKey = "userID"
+
+// AccessLog writes one structured log line per request once it has completed.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		status := c.Writer.Status()
+		attrs := []any{
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if userID := c.GetString(UserIDKey); userID != "" {
+			attrs = append(attrs, slog.String("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		ctx := c.Request.Context()
+		log := logger.FromContext(ctx)
+		switch {
+		case status >= http.StatusInternalServerError:
+			log.ErrorContext(ctx, "request completed", attrs...)
+		case status >= http.StatusBadRequest:
+			log.WarnContext(ctx, "request completed", attrs...)
+		default:
+			log.InfoContext(ctx, "request completed", attrs...)
+		}
+	}
+}
+
+// Recovery converts panics into 500 responses and logs them with the request logger.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.FromContext(c.Request.Context()).ErrorContext(c.Request.Context(), "panic recovered",
+					slog.Any("panic", rec), slog.String("stack", string(debug.Stack())))
+				RenderProblem(c, apperrors.Internal(fmt.Errorf("panic: %v", rec)))
+			}
+		}()
+		c.Next()
+	}
+}
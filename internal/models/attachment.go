@@ -7,9 +7,27 @@ import (
 )
 
 type Attachment struct {
-	ID        uuid.UUID
-	FileNmae  string
-	FilePath  string //s3 URL or local path
-	UplodedAt time.Time
-	TaskID    uuid.UUID
+	ID          uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	FileName    string    `gorm:"type:varchar(255);not null" json:"file_name"`
+	FilePath    string    `gorm:"type:text;not null" json:"file_path"` //s3 URL or local path
+	ContentType string    `gorm:"type:varchar(255);not null;default:application/octet-stream" json:"content_type"`
+	UploadedAt  time.Time `gorm:"type:timestamptz;autoCreateTime" json:"uploaded_at"`
+	TaskID      uuid.UUID `gorm:"type:uuid;index" json:"task_id"`
+}
+
+// AttachmentInput is the request body for creating or updating an attachment.
+type AttachmentInput struct {
+	FileName    string    `json:"file_name" binding:"required,max=255"`
+	FilePath    string    `json:"file_path" binding:"required"`
+	ContentType string    `json:"content_type" binding:"required,attachment_mime"`
+	TaskID      uuid.UUID `json:"task_id" binding:"required"`
+}
+
+// Apply copies the input onto a, including the content type, so that every
+// field a client sends is persisted.
+func (in AttachmentInput) Apply(a *Attachment) {
+	a.FileName = in.FileName
+	a.FilePath = in.FilePath
+	a.ContentType = in.ContentType
+	a.TaskID = in.TaskID
 }
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BoardColumn holds the settings of the board column showing the tasks
+// with its status. There is one per task status.
+type BoardColumn struct {
+	Status    string    `gorm:"type:varchar(50);primary_key" json:"status"`
+	WIPLimit  *int      `gorm:"column:wip_limit;type:integer;check:wip_limit > 0" json:"wip_limit"` // nil means no limit
+	UpdatedAt time.Time `gorm:"type:timestamptz" json:"updated_at"`
+}
+
+// BoardColumnInput is the request body for changing a column's settings.
+type BoardColumnInput struct {
+	// WIPLimit caps the tasks that can be moved into the column. Null
+	// removes the limit.
+	WIPLimit *int `json:"wip_limit" binding:"omitempty,min=1,max=10000"`
+}
+
+// BoardQuery holds the query parameters accepted by the board endpoints.
+type BoardQuery struct {
+	Page  int `form:"page,default=1" json:"page" binding:"min=1"`
+	Limit int `form:"limit,default=50" json:"limit" binding:"min=1,max=200"`
+}
+
+// Offset returns the number of tasks skipped in each column.
+func (q BoardQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// BoardColumnTasks is a column of the board with a page of its tasks in
+// rank order.
+type BoardColumnTasks struct {
+	BoardColumn
+	Total int    `json:"total"` // tasks in the column, on every page
+	Tasks []Task `json:"tasks"`
+}
+
+// Board lists the columns in the order of TaskStatuses.
+type Board struct {
+	Columns []BoardColumnTasks `json:"columns"`
+}
+
+// TaskMove is the request body for moving a task on the board. The task is
+// placed right after AfterID or right before BeforeID; with both, they must
+// be adjacent, and with neither it goes to the bottom of the column. The
+// neighbours must be in the target column.
+type TaskMove struct {
+	Status   string     `json:"status" binding:"required,task_status"`
+	AfterID  *uuid.UUID `json:"after_id"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
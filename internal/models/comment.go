@@ -7,9 +7,25 @@ import (
 )
 
 type Comment struct {
-	ID        uuid.UUID
-	Content   string
-	CreatedAt time.Time
-	TaskID    uuid.UUID
-	UserID    uuid.UUID
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	Content   string     `gorm:"type:text;not null" json:"content"`
+	CreatedAt time.Time  `gorm:"type:timestamptz" json:"created_at"`
+	TaskID    uuid.UUID  `gorm:"type:uuid;index" json:"task_id"`
+	UserID    *uuid.UUID `gorm:"type:uuid;index" json:"user_id,omitempty"`
+}
+
+// CommentInput is the content of a new comment.
+type CommentInput struct {
+	Content string `json:"content" binding:"required,max=10000"`
+}
+
+// NewComment builds a comment on the task, written by author if it isn't nil.
+func (in CommentInput) NewComment(taskID uuid.UUID, author *uuid.UUID) Comment {
+	return Comment{
+		ID:        uuid.New(),
+		Content:   in.Content,
+		CreatedAt: time.Now(),
+		TaskID:    taskID,
+		UserID:    author,
+	}
 }
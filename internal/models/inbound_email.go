@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboundEmail records a message received by the email gateway, so that a
+// message delivered twice makes one task and replies to it can be threaded.
+// Only messages with a Message-ID are recorded.
+type InboundEmail struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	MessageID string     `gorm:"type:varchar(255);not null;uniqueIndex" json:"message_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	TaskID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"task_id"`
+	CommentID *uuid.UUID `gorm:"type:uuid" json:"comment_id,omitempty"`
+	// ReceivedAt is when the gateway received the message.
+	ReceivedAt time.Time `gorm:"type:timestamptz" json:"received_at"`
+}
+
+// InboundEmailResult is what the email gateway made of a message: a task,
+// or a comment on one for replies.
+type InboundEmailResult struct {
+	Task    *Task    `json:"task"`
+	Comment *Comment `json:"comment,omitempty"`
+	// Attachments are the files stored with the task.
+	Attachments []Attachment `json:"attachments"`
+	// SkippedAttachments are the names of the files left out because their
+	// type isn't accepted or there were too many.
+	SkippedAttachments []string `json:"skipped_attachments"`
+	// ReplyTo is the address replies are sent to so that they are added to
+	// the task as comments.
+	ReplyTo string `json:"reply_to"`
+	// Duplicate is set when the message was received before. Nothing is
+	// created again, and Attachments are left empty.
+	Duplicate bool `json:"duplicate"`
+}
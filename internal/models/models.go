@@ -0,0 +1,23 @@
+package models
+
+// All returns one value of every model persisted by the API, in dependency
+// order. It is used by tooling that inspects the schema.
+func All() []interface{} {
+	return []interface{}{
+		&User{},
+		&Project{},
+		&ProjectMember{},
+		&Task{},
+		&Comment{},
+		&Attachment{},
+		&BoardColumn{},
+		&TimeEntry{},
+		&SavedView{},
+		&SavedViewDefault{},
+		&TaskTemplate{},
+		&Notification{},
+		&NotificationPreference{},
+		&InboundEmail{},
+		&Session{},
+	}
+}
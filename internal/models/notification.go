@@ -0,0 +1,151 @@
+package models
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification types, the events users are notified of.
+const (
+	NotificationAssigned      = "assigned"       // a task was assigned to the user
+	NotificationMentioned     = "mentioned"      // the user was mentioned in a task or comment
+	NotificationCommented     = "commented"      // a task the user is involved in got a comment
+	NotificationStatusChanged = "status_changed" // the user's task changed status
+	NotificationDueSoon       = "due_soon"       // the user's task is due soon
+)
+
+// NotificationTypes lists every notification type.
+var NotificationTypes = []string{
+	NotificationAssigned, NotificationMentioned, NotificationCommented,
+	NotificationStatusChanged, NotificationDueSoon,
+}
+
+// Notification channels. Inbox notifications are stored until read; push
+// notifications are sent to the user's connected clients as they happen.
+const (
+	ChannelInbox = "inbox"
+	ChannelPush  = "push"
+)
+
+// NotificationChannels lists every notification channel.
+var NotificationChannels = []string{ChannelInbox, ChannelPush}
+
+// Notification tells a user about a change to a task made by someone else.
+type Notification struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_notifications_user_created,priority:1" json:"user_id"`
+	Type      string     `gorm:"type:varchar(20);not null" json:"type"`
+	TaskID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"task_id"`
+	CommentID *uuid.UUID `gorm:"type:uuid" json:"comment_id,omitempty"`
+	// ActorID is the user who made the change, if known.
+	ActorID *uuid.UUID `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Message string     `gorm:"type:varchar(500);not null" json:"message"`
+	// DedupKey is set on notifications sent at most once, such as due soon
+	// reminders, and unique.
+	DedupKey  *string    `gorm:"type:varchar(150);uniqueIndex" json:"-"`
+	ReadAt    *time.Time `gorm:"type:timestamptz" json:"read_at"`
+	CreatedAt time.Time  `gorm:"type:timestamptz;index:idx_notifications_user_created,priority:2" json:"created_at"`
+}
+
+// NewNotification builds an unread notification for the user about the task.
+func NewNotification(userID uuid.UUID, kind string, task Task, actor *uuid.UUID) Notification {
+	var message string
+	switch kind {
+	case NotificationAssigned:
+		message = "You were assigned " + quote(task.Title)
+	case NotificationMentioned:
+		message = "You were mentioned in " + quote(task.Title)
+	case NotificationCommented:
+		message = "New comment on " + quote(task.Title)
+	case NotificationStatusChanged:
+		message = quote(task.Title) + " moved to " + task.Status
+	case NotificationDueSoon:
+		message = quote(task.Title) + " is due soon"
+	}
+	return Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      kind,
+		TaskID:    task.ID,
+		ActorID:   actor,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+}
+
+func quote(title string) string {
+	return `"` + title + `"`
+}
+
+// NotificationPreference turns a notification type on or off for a channel.
+// Types and channels without a stored preference are on.
+type NotificationPreference struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
+	Type      string    `gorm:"type:varchar(20);primary_key" json:"type"`
+	Channel   string    `gorm:"type:varchar(20);primary_key" json:"channel"`
+	Enabled   bool      `gorm:"type:boolean;not null" json:"enabled"`
+	UpdatedAt time.Time `gorm:"type:timestamptz" json:"updated_at"`
+}
+
+// NotificationListQuery holds the query parameters accepted when listing
+// notifications.
+type NotificationListQuery struct {
+	Unread bool `form:"unread" json:"unread"`
+	Page   int  `form:"page,default=1" json:"page" binding:"min=1"`
+	Limit  int  `form:"limit,default=20" json:"limit" binding:"min=1,max=100"`
+}
+
+// Offset returns the number of notifications skipped.
+func (q NotificationListQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// NotificationCount is the number of notifications unread, or just marked
+// read.
+type NotificationCount struct {
+	Count int `json:"count"`
+}
+
+// NotificationPreferencesInput is the request body for changing
+// notification preferences. Types and channels left out keep their setting.
+type NotificationPreferencesInput struct {
+	Preferences []NotificationPreferenceInput `json:"preferences" binding:"required,min=1,max=20,dive"`
+}
+
+// NotificationPreferenceInput turns a notification type on or off for a
+// channel.
+type NotificationPreferenceInput struct {
+	Type    string `json:"type" binding:"required,oneof=assigned mentioned commented status_changed due_soon"`
+	Channel string `json:"channel" binding:"required,oneof=inbox push"`
+	Enabled *bool  `json:"enabled" binding:"required"`
+}
+
+// MaxMentions is the number of mentions looked up in one text; later ones
+// are ignored.
+const MaxMentions = 20
+
+// mention matches @email or @username at the start of the text or after a
+// character that can't be part of an address, so that plain email
+// addresses aren't mentions.
+var mention = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+@-])@([A-Za-z0-9._%+-]+(?:@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)?)`)
+
+// Mentions returns the names mentioned in text, lowercased, without
+// duplicates, in order of appearance.
+func Mentions(text string) []string {
+	var names []string
+	for _, match := range mention.FindAllStringSubmatch(text, -1) {
+		// A sentence may end right after the mention.
+		name := strings.ToLower(strings.TrimRight(match[1], "."))
+		if name == "" || slices.Contains(names, name) {
+			continue
+		}
+		if names = append(names, name); len(names) == MaxMentions {
+			break
+		}
+	}
+	return names
+}
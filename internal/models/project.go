@@ -0,0 +1,118 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Project groups tasks, such as those of a client, so that time can be
+// reported per project, and the users views are shared with. Names are
+// unique.
+type Project struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	OwnerID   uuid.UUID `gorm:"type:uuid;not null;index" json:"owner_id"`
+	CreatedAt time.Time `gorm:"type:timestamptz" json:"created_at"`
+	UpdatedAt time.Time `gorm:"type:timestamptz" json:"updated_at"`
+}
+
+// ProjectMember is a user's membership of a project. Views shared with a
+// project are visible to its members. The owner is a member from the
+// start.
+type ProjectMember struct {
+	ProjectID uuid.UUID `gorm:"type:uuid;primary_key" json:"project_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key;index" json:"user_id"`
+	CreatedAt time.Time `gorm:"type:timestamptz" json:"created_at"`
+}
+
+// ProjectInput is the request body for creating or renaming a project.
+type ProjectInput struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+// NewProject builds the project described by the input, owned by owner.
+func (in ProjectInput) NewProject(owner uuid.UUID) Project {
+	now := time.Now()
+	return Project{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(in.Name),
+		OwnerID:   owner,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Apply renames project.
+func (in ProjectInput) Apply(project *Project) {
+	project.Name = strings.TrimSpace(in.Name)
+	project.UpdatedAt = time.Now()
+}
+
+// ProjectListQuery holds the query parameters accepted when listing
+// projects.
+type ProjectListQuery struct {
+	Page  int `form:"page,default=1" json:"page" binding:"min=1"`
+	Limit int `form:"limit,default=20" json:"limit" binding:"min=1,max=100"`
+}
+
+// Offset returns the number of projects skipped.
+func (q ProjectListQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// Labels are the free-form labels of a task, such as "billable". They are
+// stored as a JSON array, sorted and without duplicates.
+type Labels []string
+
+// NewLabels returns the labels trimmed, sorted and without duplicates or
+// empty ones.
+func NewLabels(labels []string) Labels {
+	out := Labels{}
+	for _, label := range labels {
+		if label = strings.TrimSpace(label); label != "" {
+			out = append(out, label)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// Value stores the labels as a JSON array, never null.
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	return string(b), err
+}
+
+func (l *Labels) Scan(src any) error {
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		*l = Labels{}
+		return nil
+	case string:
+		b = []byte(src)
+	case []byte:
+		b = src
+	default:
+		return fmt.Errorf("can't scan %T into Labels", src)
+	}
+	*l = Labels{}
+	return json.Unmarshal(b, (*[]string)(l))
+}
+
+// MarshalJSON encodes missing labels as an empty array.
+func (l Labels) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(l))
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedView is a named task filter and sort a user can run again. Private
+// views are only visible to their owner; shared views are also visible to
+// the members of their project.
+type SavedView struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_saved_views_user_name" json:"user_id"`
+	Name   string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_saved_views_user_name" json:"name"`
+	// Query is the filter and sort in GET /tasks syntax, such as
+	// "status=todo&sort=-priority".
+	Query     string     `gorm:"type:text;not null" json:"query"`
+	Shared    bool       `gorm:"type:boolean;not null;default:false" json:"shared"`
+	ProjectID *uuid.UUID `gorm:"type:uuid;index" json:"project_id,omitempty"`
+	CreatedAt time.Time  `gorm:"type:timestamptz" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"type:timestamptz" json:"updated_at"`
+}
+
+// VisibleTo reports whether the user, a member of projects, can see and
+// run the view.
+func (v *SavedView) VisibleTo(userID uuid.UUID, projects []uuid.UUID) bool {
+	return v.UserID == userID || v.Shared && v.ProjectID != nil && slices.Contains(projects, *v.ProjectID)
+}
+
+// SavedViewDefault records the view a user runs by default. The view may be
+// another user's view shared with one of their projects.
+type SavedViewDefault struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primary_key" json:"user_id"`
+	ViewID    uuid.UUID `gorm:"type:uuid;not null;index" json:"view_id"`
+	UpdatedAt time.Time `gorm:"type:timestamptz" json:"updated_at"`
+}
+
+// SavedViewInput is the request body for creating one of the caller's
+// views. Shared views need a project the caller is a member of.
+type SavedViewInput struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Query     string     `json:"query" binding:"max=2000"`
+	Shared    bool       `json:"shared"`
+	ProjectID *uuid.UUID `json:"project_id"`
+}
+
+// NewSavedView builds the view described by the input for the owner.
+func (in SavedViewInput) NewSavedView(owner uuid.UUID) SavedView {
+	now := time.Now()
+	return SavedView{
+		ID:        uuid.New(),
+		UserID:    owner,
+		Name:      in.Name,
+		Query:     in.Query,
+		Shared:    in.Shared,
+		ProjectID: in.ProjectID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// UpdateSavedViewInput is the request body for editing a view. Omitted
+// fields are left unchanged.
+type UpdateSavedViewInput struct {
+	Name      *string    `json:"name" binding:"omitempty,min=1,max=100"`
+	Query     *string    `json:"query" binding:"omitempty,max=2000"`
+	Shared    *bool      `json:"shared"`
+	ProjectID *uuid.UUID `json:"project_id"`
+}
+
+// Apply copies the fields set in the input onto view.
+func (in UpdateSavedViewInput) Apply(view *SavedView) {
+	if in.Name != nil {
+		view.Name = *in.Name
+	}
+	if in.Query != nil {
+		view.Query = *in.Query
+	}
+	if in.Shared != nil {
+		view.Shared = *in.Shared
+	}
+	if in.ProjectID != nil {
+		view.ProjectID = in.ProjectID
+	}
+	view.UpdatedAt = time.Now()
+}
+
+// SavedViewDefaultInput is the request body for choosing the caller's
+// default view.
+type SavedViewDefaultInput struct {
+	ViewID uuid.UUID `json:"view_id" binding:"required"`
+}
+
+// SavedViewRunQuery holds the query parameters accepted when running a
+// view. The view supplies the filter and sort.
+type SavedViewRunQuery struct {
+	Page  int `form:"page,default=1" json:"page" binding:"min=1"`
+	Limit int `form:"limit,default=20" json:"limit" binding:"min=1,max=100"`
+}
+
+// SavedViewCount is the number of tasks a view currently matches.
+type SavedViewCount struct {
+	ViewID uuid.UUID `json:"view_id"`
+	Name   string    `json:"name"`
+	Count  int       `json:"count"`
+}
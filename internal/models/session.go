@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a signed in client. It holds a hash of the refresh token,
+// which changes each time the token is refreshed, and ends at logout or
+// when it expires. Access tokens name their session, so they stop working
+// when it ends.
+type Session struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time `gorm:"type:timestamptz;not null" json:"expires_at"`
+	CreatedAt time.Time `gorm:"type:timestamptz" json:"created_at"`
+}
+
+// Credentials is the request body for signing up and logging in.
+type Credentials struct {
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// RefreshInput is the request body for refreshing and revoking tokens.
+type RefreshInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenPair is the response to signing up, logging in and refreshing.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	// ExpiresIn is the lifetime of the access token in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+}
@@ -6,16 +6,190 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+)
+
+// TaskStatuses lists every valid task status.
+var TaskStatuses = []string{TaskStatusTodo, TaskStatusInProgress, TaskStatusDone}
+
+const DefaultTaskPriority = 3
+
 type Task struct {
-	ID          uuid.UUID `gorm:"type:uuid;primary_key"`
-	Title       string    `gorm:"not null"`
-	Description string
-	Status      string //"todo", "in progress", "done"
-	Prirority   int
-	DueDate     time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	UserID      uuid.UUID // Foreign key
-	Comments    []Comment
-	Attachments []Attachment
+	ID              uuid.UUID    `gorm:"type:uuid;primary_key;index:idx_tasks_status_rank,priority:3" json:"id"`
+	Title           string       `gorm:"type:varchar(255);not null" json:"title"`
+	Description     string       `gorm:"type:text" json:"description"`
+	Status          string       `gorm:"type:varchar(50);not null;default:todo;index;index:idx_tasks_status_rank,priority:1" json:"status"` //"todo", "in_progress", "done"
+	Rank            string       `gorm:"type:varchar(255);not null;index:idx_tasks_status_rank,priority:2" json:"rank"`                     // order within the status column, see package rank
+	Priority        int          `gorm:"type:integer;check:priority BETWEEN 1 AND 5;index" json:"priority"`
+	DueDate         *time.Time   `gorm:"type:timestamptz" json:"due_date,omitempty"`
+	EstimateMinutes *int         `gorm:"type:integer;check:estimate_minutes >= 0" json:"estimate_minutes,omitempty"` // expected effort, see TimeEntry
+	CreatedAt       time.Time    `gorm:"type:timestamptz" json:"created_at"`
+	UpdatedAt       time.Time    `gorm:"type:timestamptz" json:"updated_at"`
+	UserID          *uuid.UUID   `gorm:"type:uuid;index" json:"user_id,omitempty"`   // Foreign key
+	ParentID        *uuid.UUID   `gorm:"type:uuid;index" json:"parent_id,omitempty"` // set on subtasks created from a template
+	ProjectID       *uuid.UUID   `gorm:"type:uuid;index" json:"project_id,omitempty"`
+	Labels          Labels       `gorm:"type:text;not null;default:'[]'" json:"labels"`
+	Comments        []Comment    `gorm:"constraint:OnDelete:CASCADE" json:"comments,omitempty"`
+	Attachments     []Attachment `gorm:"constraint:OnDelete:CASCADE" json:"attachments,omitempty"`
+}
+
+// CreateTaskInput is the request body for creating a task. Server managed
+// fields such as ID, owner and timestamps can't be set by clients.
+type CreateTaskInput struct {
+	Title           string     `json:"title" binding:"required,min=1,max=255"`
+	Description     string     `json:"description" binding:"max=10000"`
+	Status          string     `json:"status" binding:"omitempty,task_status"`
+	Priority        int        `json:"priority" binding:"omitempty,min=1,max=5"`
+	DueDate         *time.Time `json:"due_date" binding:"omitempty,future"`
+	EstimateMinutes *int       `json:"estimate_minutes" binding:"omitempty,min=0,max=1000000"`
+	ProjectID       *uuid.UUID `json:"project_id"`
+	Labels          []string   `json:"labels" binding:"max=20,dive,min=1,max=50"`
+}
+
+// NewTask builds the task described by the input, owned by owner if it
+// isn't nil. Server managed fields and the status and priority defaults
+// are filled in.
+func (in CreateTaskInput) NewTask(owner *uuid.UUID) Task {
+	now := time.Now()
+	task := Task{
+		ID:              uuid.New(),
+		Title:           in.Title,
+		Description:     in.Description,
+		Status:          in.Status,
+		Priority:        in.Priority,
+		DueDate:         in.DueDate,
+		EstimateMinutes: in.EstimateMinutes,
+		ProjectID:       in.ProjectID,
+		Labels:          NewLabels(in.Labels),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		UserID:          owner,
+	}
+	if task.Status == "" {
+		task.Status = TaskStatusTodo
+	}
+	if task.Priority == 0 {
+		task.Priority = DefaultTaskPriority
+	}
+	return task
+}
+
+// UpdateTaskInput is the request body for updating a task. Omitted fields
+// are left unchanged.
+type UpdateTaskInput struct {
+	Title           *string    `json:"title" binding:"omitempty,min=1,max=255"`
+	Description     *string    `json:"description" binding:"omitempty,max=10000"`
+	Status          *string    `json:"status" binding:"omitempty,task_status"`
+	Priority        *int       `json:"priority" binding:"omitempty,min=1,max=5"`
+	DueDate         *time.Time `json:"due_date" binding:"omitempty,future"`
+	EstimateMinutes *int       `json:"estimate_minutes" binding:"omitempty,min=0,max=1000000"`
+	// UserID assigns the task to another user.
+	UserID    *uuid.UUID `json:"user_id"`
+	ProjectID *uuid.UUID `json:"project_id"`
+	// Labels replace the task's labels.
+	Labels *[]string `json:"labels" binding:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// Apply copies the fields set in the input onto task, like Fields, leaving
+// the status to a move.
+func (in UpdateTaskInput) Apply(task *Task) {
+	if in.Title != nil {
+		task.Title = *in.Title
+	}
+	if in.Description != nil {
+		task.Description = *in.Description
+	}
+	if in.Priority != nil {
+		task.Priority = *in.Priority
+	}
+	if in.DueDate != nil {
+		task.DueDate = in.DueDate
+	}
+	if in.EstimateMinutes != nil {
+		task.EstimateMinutes = in.EstimateMinutes
+	}
+	if in.UserID != nil {
+		task.UserID = in.UserID
+	}
+	if in.ProjectID != nil {
+		task.ProjectID = in.ProjectID
+	}
+	if in.Labels != nil {
+		task.Labels = NewLabels(*in.Labels)
+	}
+	task.UpdatedAt = time.Now()
+}
+
+// Fields returns the columns of the fields set in the input, other than the
+// status, which only a move changes.
+func (in UpdateTaskInput) Fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	if in.Title != nil {
+		fields["title"] = *in.Title
+	}
+	if in.Description != nil {
+		fields["description"] = *in.Description
+	}
+	if in.Priority != nil {
+		fields["priority"] = *in.Priority
+	}
+	if in.DueDate != nil {
+		fields["due_date"] = *in.DueDate
+	}
+	if in.EstimateMinutes != nil {
+		fields["estimate_minutes"] = *in.EstimateMinutes
+	}
+	if in.UserID != nil {
+		fields["user_id"] = *in.UserID
+	}
+	if in.ProjectID != nil {
+		fields["project_id"] = *in.ProjectID
+	}
+	if in.Labels != nil {
+		fields["labels"] = NewLabels(*in.Labels)
+	}
+	return fields
+}
+
+// TaskListQuery holds the query parameters accepted by GET /tasks. At most
+// one of Status, Priority, UserID and Query may be set.
+type TaskListQuery struct {
+	Status   string `form:"status" json:"status" binding:"omitempty,task_status"`
+	Priority int    `form:"priority" json:"priority" binding:"omitempty,min=1,max=5"`
+	UserID   string `form:"user_id" json:"user_id" binding:"omitempty,uuid"`
+	Query    string `form:"q" json:"q" binding:"omitempty,max=255"`
+	// Sort is one of TaskSorts, descending with a "-" prefix. Ties and the
+	// default order are by ID.
+	Sort  string `form:"sort" json:"sort" binding:"omitempty,task_sort"`
+	Page  int    `form:"page,default=1" json:"page" binding:"min=1"`
+	Limit int    `form:"limit,default=20" json:"limit" binding:"min=1,max=100"`
+}
+
+// TaskSorts lists the fields tasks can be sorted by.
+var TaskSorts = []string{"id", "title", "priority", "due_date", "created_at", "updated_at"}
+
+// Filters returns the names of the filters set in the query.
+func (q TaskListQuery) Filters() []string {
+	var filters []string
+	if q.Status != "" {
+		filters = append(filters, "status")
+	}
+	if q.Priority != 0 {
+		filters = append(filters, "priority")
+	}
+	if q.UserID != "" {
+		filters = append(filters, "user_id")
+	}
+	if q.Query != "" {
+		filters = append(filters, "q")
+	}
+	return filters
+}
+
+// Offset returns the number of tasks skipped before the requested page.
+func (q TaskListQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
 }
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits on the task tree of a template.
+const (
+	MaxTemplateDepth = 5   // levels of tasks, counting the root
+	MaxTemplateTasks = 200 // tasks created by one instantiation
+)
+
+// TaskTemplate describes a task and its subtasks to create again and
+// again, such as a release checklist. The tree is stored as one JSON
+// document, as it is always read and written whole.
+type TaskTemplate struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primary_key" json:"id"`
+	Name      string       `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Task      TemplateTask `gorm:"type:text;not null;serializer:json" json:"task"`
+	CreatedAt time.Time    `gorm:"type:timestamptz" json:"created_at"`
+	UpdatedAt time.Time    `gorm:"type:timestamptz" json:"updated_at"`
+}
+
+// TemplateTask is a task of a template. Its title and description may hold
+// {{variable}} placeholders, filled in when the template is instantiated.
+type TemplateTask struct {
+	Title       string `json:"title" binding:"required,min=1,max=255"`
+	Description string `json:"description" binding:"max=10000"`
+	Priority    int    `json:"priority,omitempty" binding:"omitempty,min=1,max=5"` // 0 means DefaultTaskPriority
+	// DueOffsetDays sets the due date relative to the instantiation's
+	// anchor date, before it when negative. Tasks without it have no due
+	// date.
+	DueOffsetDays   *int           `json:"due_offset_days,omitempty" binding:"omitempty,min=-3650,max=3650"`
+	EstimateMinutes *int           `json:"estimate_minutes,omitempty" binding:"omitempty,min=0,max=1000000"`
+	Subtasks        []TemplateTask `json:"subtasks,omitempty" binding:"omitempty,max=50,dive"`
+}
+
+// placeholder matches {{name}}, allowing spaces inside the braces.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Size returns the number of tasks in the tree rooted at t and its depth.
+func (t TemplateTask) Size() (tasks, depth int) {
+	for _, sub := range t.Subtasks {
+		subTasks, subDepth := sub.Size()
+		tasks += subTasks
+		depth = max(depth, subDepth)
+	}
+	return tasks + 1, depth + 1
+}
+
+// Variables returns the names of the placeholders used in the tree, sorted.
+func (t TemplateTask) Variables() []string {
+	var names []string
+	t.walk("task", func(_ string, task TemplateTask) {
+		for _, text := range []string{task.Title, task.Description} {
+			for _, match := range placeholder.FindAllStringSubmatch(text, -1) {
+				names = append(names, match[1])
+			}
+		}
+	})
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// walk calls fn for every task of the tree, parents before their subtasks,
+// with the task's path in the template such as task.subtasks[1].
+func (t TemplateTask) walk(path string, fn func(path string, task TemplateTask)) {
+	fn(path, t)
+	for i, sub := range t.Subtasks {
+		sub.walk(fmt.Sprintf("%s.subtasks[%d]", path, i), fn)
+	}
+}
+
+// TemplateTaskRef identifies the task of a template a created task came
+// from, for reporting problems.
+type TemplateTaskRef struct {
+	Path string // such as task.subtasks[1]
+	Task Task
+}
+
+// Instantiate builds the tasks of the tree, parents before their subtasks,
+// with placeholders replaced by vars. Due offsets count from anchor. The
+// tasks are owned by owner if it isn't nil; they aren't ranked yet.
+func (t TemplateTask) Instantiate(vars map[string]string, anchor time.Time, owner *uuid.UUID) []TemplateTaskRef {
+	fill := func(text string) string {
+		return placeholder.ReplaceAllStringFunc(text, func(match string) string {
+			return vars[placeholder.FindStringSubmatch(match)[1]]
+		})
+	}
+
+	var refs []TemplateTaskRef
+	parents := map[string]uuid.UUID{}
+	now := time.Now()
+	t.walk("task", func(path string, tt TemplateTask) {
+		task := CreateTaskInput{
+			Title:           strings.TrimSpace(fill(tt.Title)),
+			Description:     fill(tt.Description),
+			Priority:        tt.Priority,
+			EstimateMinutes: tt.EstimateMinutes,
+		}.NewTask(owner)
+		task.CreatedAt, task.UpdatedAt = now, now
+		if tt.DueOffsetDays != nil {
+			due := anchor.AddDate(0, 0, *tt.DueOffsetDays)
+			task.DueDate = &due
+		}
+		if i := strings.LastIndex(path, ".subtasks["); i >= 0 {
+			parent := parents[path[:i]]
+			task.ParentID = &parent
+		}
+		parents[path] = task.ID
+		refs = append(refs, TemplateTaskRef{Path: path, Task: task})
+	})
+	return refs
+}
+
+// TaskTemplateInput is the request body for creating a template.
+type TaskTemplateInput struct {
+	Name string       `json:"name" binding:"required,min=1,max=100"`
+	Task TemplateTask `json:"task" binding:"required"`
+}
+
+// NewTaskTemplate builds the template described by the input.
+func (in TaskTemplateInput) NewTaskTemplate() TaskTemplate {
+	now := time.Now()
+	return TaskTemplate{
+		ID:        uuid.New(),
+		Name:      in.Name,
+		Task:      in.Task,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// UpdateTaskTemplateInput is the request body for editing a template.
+// Omitted fields are left unchanged; a new task tree replaces the old one.
+type UpdateTaskTemplateInput struct {
+	Name *string       `json:"name" binding:"omitempty,min=1,max=100"`
+	Task *TemplateTask `json:"task"`
+}
+
+// Apply copies the fields set in the input onto template.
+func (in UpdateTaskTemplateInput) Apply(template *TaskTemplate) {
+	if in.Name != nil {
+		template.Name = *in.Name
+	}
+	if in.Task != nil {
+		template.Task = *in.Task
+	}
+	template.UpdatedAt = time.Now()
+}
+
+// InstantiateTemplateInput is the request body for creating the tasks of a
+// template.
+type InstantiateTemplateInput struct {
+	// Variables fill in the placeholders; every placeholder needs one.
+	Variables map[string]string `json:"variables" binding:"max=100,dive,keys,max=100,endkeys,max=1000"`
+	// Anchor is the date due offsets count from, now by default.
+	Anchor *time.Time `json:"anchor"`
+}
+
+// TemplateListQuery holds the query parameters accepted when listing
+// templates.
+type TemplateListQuery struct {
+	Page  int `form:"page,default=1" json:"page" binding:"min=1"`
+	Limit int `form:"limit,default=20" json:"limit" binding:"min=1,max=100"`
+}
+
+// Offset returns the number of templates skipped.
+func (q TemplateListQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
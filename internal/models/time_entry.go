@@ -0,0 +1,145 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TimeEntry is time a user spent on a task. Entries are recorded with a
+// timer or entered by hand; a user has at most one running timer, which is
+// an entry without EndedAt.
+type TimeEntry struct {
+	ID     uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL" json:"user_id"`
+	TaskID uuid.UUID `gorm:"type:uuid;not null;index" json:"task_id"`
+	// StartedAt is indexed for the date ranges of reports.
+	StartedAt time.Time  `gorm:"type:timestamptz;not null;index" json:"started_at"`
+	EndedAt   *time.Time `gorm:"type:timestamptz" json:"ended_at"`
+	// DurationSeconds is stored when the entry ends so that reports can sum
+	// it without date arithmetic, which differs between databases.
+	DurationSeconds int64     `gorm:"type:bigint;not null;default:0" json:"duration_seconds"`
+	Note            string    `gorm:"type:text" json:"note"`
+	CreatedAt       time.Time `gorm:"type:timestamptz" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"type:timestamptz" json:"updated_at"`
+}
+
+// Running reports whether the entry's timer hasn't been stopped.
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+// End stops the entry at end and records its duration.
+func (e *TimeEntry) End(end time.Time) {
+	e.EndedAt = &end
+	e.DurationSeconds = int64(end.Sub(e.StartedAt) / time.Second)
+	e.UpdatedAt = time.Now()
+}
+
+// TimerStart is the request body for starting a timer on a task. Timers
+// always track the caller's time.
+type TimerStart struct {
+	Note string `json:"note" binding:"max=10000"`
+}
+
+// TimeEntryInput is the request body for entering the caller's time by
+// hand.
+type TimeEntryInput struct {
+	StartedAt time.Time `json:"started_at" binding:"required"`
+	EndedAt   time.Time `json:"ended_at" binding:"required,gtfield=StartedAt"`
+	Note      string    `json:"note" binding:"max=10000"`
+}
+
+// NewTimeEntry builds the entry described by the input for the user.
+func (in TimeEntryInput) NewTimeEntry(taskID, userID uuid.UUID) TimeEntry {
+	now := time.Now()
+	entry := TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TaskID:    taskID,
+		StartedAt: in.StartedAt,
+		Note:      in.Note,
+		CreatedAt: now,
+	}
+	entry.End(in.EndedAt)
+	return entry
+}
+
+// UpdateTimeEntryInput is the request body for editing a time entry.
+// Omitted fields are left unchanged. Setting EndedAt on a running entry
+// stops its timer.
+type UpdateTimeEntryInput struct {
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Note      *string    `json:"note" binding:"omitempty,max=10000"`
+}
+
+// Apply copies the fields set in the input onto entry.
+func (in UpdateTimeEntryInput) Apply(entry *TimeEntry) {
+	if in.StartedAt != nil {
+		entry.StartedAt = *in.StartedAt
+	}
+	if in.Note != nil {
+		entry.Note = *in.Note
+	}
+	switch {
+	case in.EndedAt != nil:
+		entry.End(*in.EndedAt)
+	case !entry.Running():
+		entry.End(*entry.EndedAt)
+	default:
+		entry.UpdatedAt = time.Now()
+	}
+}
+
+// TimeEntryListQuery holds the query parameters accepted when listing the
+// time entries of a task.
+type TimeEntryListQuery struct {
+	Page  int `form:"page,default=1" json:"page" binding:"min=1"`
+	Limit int `form:"limit,default=20" json:"limit" binding:"min=1,max=100"`
+}
+
+// Offset returns the number of entries skipped.
+func (q TimeEntryListQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// Time report groupings.
+const (
+	TimeReportByUser    = "user"
+	TimeReportByTask    = "task"
+	TimeReportByProject = "project"
+	TimeReportByLabel   = "label"
+	TimeReportByDay     = "day"
+)
+
+// TimeReportQuery holds the query parameters of the time report. Entries
+// are included when they started in [From, To) and have ended.
+type TimeReportQuery struct {
+	GroupBy string    `form:"group_by" json:"group_by" binding:"required,oneof=user task project label day"`
+	From    time.Time `form:"from" json:"from" time_format:"2006-01-02T15:04:05Z07:00" binding:"required"`
+	To      time.Time `form:"to" json:"to" time_format:"2006-01-02T15:04:05Z07:00" binding:"required,gtfield=From"`
+	UserID  string    `form:"user_id" json:"user_id" binding:"omitempty,uuid"`
+	TaskID  string    `form:"task_id" json:"task_id" binding:"omitempty,uuid"`
+	Format  string    `form:"format,default=json" json:"format" binding:"oneof=json csv"`
+}
+
+// TimeReportRow is the logged time of one group: a user, task or project
+// ID, a label, or a UTC date formatted as 2006-01-02. The key is empty for
+// the time on tasks without a project or without labels.
+type TimeReportRow struct {
+	Key     string `gorm:"column:group_key" json:"key"`
+	Entries int    `json:"entries"`
+	Seconds int64  `json:"seconds"`
+}
+
+// TimeReport is the logged time in a date range, grouped as requested. The
+// time of a task with several labels counts towards each of them, so the
+// rows of a label report can add up to more than the total.
+type TimeReport struct {
+	GroupBy      string          `json:"group_by"`
+	From         time.Time       `json:"from"`
+	To           time.Time       `json:"to"`
+	TotalSeconds int64           `json:"total_seconds"`
+	Rows         []TimeReportRow `json:"rows"`
+}
@@ -7,11 +7,11 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `gorm:"type:uuid;primary_key"`
-	Email        string    `gorm:"not null;unique"`
-	PasswordHash string    `gorm:"not null"`
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	Role         string
-	Tasks        []Task
+	ID           uuid.UUID `gorm:"type:uuid;primary_key" json:"id"`
+	Email        string    `gorm:"type:varchar(255);not null;unique" json:"email"`
+	PasswordHash string    `gorm:"type:varchar(255);not null" json:"-"`
+	CreatedAt    time.Time `gorm:"type:timestamptz" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"type:timestamptz" json:"updated_at"`
+	Role         string    `gorm:"type:varchar(50);not null;default:user" json:"role"`
+	Tasks        []Task    `gorm:"constraint:OnDelete:CASCADE" json:"tasks,omitempty"`
 }
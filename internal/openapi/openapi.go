@@ -0,0 +1,294 @@
+// Package openapi holds the OpenAPI 3.1 description of the API. It serves
+// the document, validates traffic against it and compares it with the
+// routes registered on the router, so the description can't silently drift
+// from the implementation.
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+//go:embed openapi.json
+var document []byte
+
+// documentURL identifies the document when compiling the schemas in it.
+const documentURL = "file:///openapi.json"
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Spec is the parsed document with every schema compiled.
+type Spec struct {
+	raw        []byte
+	operations map[string]*Operation // keyed by operationKey
+}
+
+// Operation is one method on one path of the document.
+type Operation struct {
+	ID     string
+	Method string // upper case, as in http.Request.Method
+	Path   string // template with {name} parameters
+
+	Parameters []Parameter
+	body       *requestBody
+	responses  map[string]content // keyed by status code, "4XX" style range or "default"
+}
+
+// Parameter is a path, query or header parameter of an operation.
+type Parameter struct {
+	Name     string
+	In       string
+	Required bool
+
+	typ    string // the schema's type, used to convert query and path values
+	items  string // the items' type of array query parameters, given once per item
+	schema *jsonschema.Schema
+}
+
+type requestBody struct {
+	required bool
+	content  content
+}
+
+// content maps media types to their schemas. The schema is nil for media
+// types declared without one.
+type content map[string]*jsonschema.Schema
+
+// Load parses the embedded document and compiles its schemas.
+func Load() (*Spec, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI document: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.AssertFormat()
+	if err := compiler.AddResource(documentURL, doc); err != nil {
+		return nil, err
+	}
+
+	l := &loader{doc: doc, compiler: compiler}
+	spec := &Spec{raw: document, operations: map[string]*Operation{}}
+	paths, _ := lookup(doc, "/paths").(map[string]any)
+	for path := range paths {
+		itemPtr := "/paths/" + escape(path)
+		shared, err := l.parameters(itemPtr + "/parameters")
+		if err != nil {
+			return nil, err
+		}
+		for _, method := range methods {
+			opPtr := itemPtr + "/" + method
+			if lookup(doc, opPtr) == nil {
+				continue
+			}
+			op, err := l.operation(opPtr, shared)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			op.Method, op.Path = strings.ToUpper(method), path
+			spec.operations[operationKey(op.Method, op.Path)] = op
+		}
+	}
+	return spec, nil
+}
+
+// Handler serves the document.
+func (s *Spec) Handler(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", s.raw)
+}
+
+// Operation returns the operation documented for a method and a gin route
+// path such as /api/v1/tasks/:id.
+func (s *Spec) Operation(method, route string) (*Operation, bool) {
+	op, ok := s.operations[operationKey(method, templatePath(route))]
+	return op, ok
+}
+
+// Operations returns every documented operation ordered by path and method.
+func (s *Spec) Operations() []*Operation {
+	ops := make([]*Operation, 0, len(s.operations))
+	for _, op := range s.operations {
+		ops = append(ops, op)
+	}
+	slices.SortFunc(ops, func(a, b *Operation) int {
+		return strings.Compare(operationKey(a.Method, a.Path), operationKey(b.Method, b.Path))
+	})
+	return ops
+}
+
+func operationKey(method, path string) string {
+	return path + " " + method
+}
+
+// templatePath turns gin's :name and *name parameters into {name}.
+func templatePath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// loader turns parts of the document into operations, following $ref and
+// compiling schemas as it goes.
+type loader struct {
+	doc      any
+	compiler *jsonschema.Compiler
+}
+
+func (l *loader) operation(ptr string, shared []Parameter) (*Operation, error) {
+	op := &Operation{}
+	op.ID, _ = lookup(l.doc, ptr+"/operationId").(string)
+
+	params, err := l.parameters(ptr + "/parameters")
+	if err != nil {
+		return nil, err
+	}
+	// Operation parameters override path item ones with the same name and location.
+	for _, p := range shared {
+		if !slices.ContainsFunc(params, func(q Parameter) bool { return q.Name == p.Name && q.In == p.In }) {
+			op.Parameters = append(op.Parameters, p)
+		}
+	}
+	op.Parameters = append(op.Parameters, params...)
+
+	if lookup(l.doc, ptr+"/requestBody") != nil {
+		bodyPtr, body, err := l.resolve(ptr + "/requestBody")
+		if err != nil {
+			return nil, err
+		}
+		op.body = &requestBody{}
+		op.body.required, _ = body["required"].(bool)
+		if op.body.content, err = l.content(bodyPtr + "/content"); err != nil {
+			return nil, err
+		}
+	}
+
+	op.responses = map[string]content{}
+	responses, _ := lookup(l.doc, ptr+"/responses").(map[string]any)
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no responses are documented")
+	}
+	for status := range responses {
+		respPtr, _, err := l.resolve(ptr + "/responses/" + escape(status))
+		if err != nil {
+			return nil, err
+		}
+		if op.responses[strings.ToUpper(status)], err = l.content(respPtr + "/content"); err != nil {
+			return nil, err
+		}
+	}
+	return op, nil
+}
+
+func (l *loader) parameters(ptr string) ([]Parameter, error) {
+	list, _ := lookup(l.doc, ptr).([]any)
+	params := make([]Parameter, 0, len(list))
+	for i := range list {
+		paramPtr, obj, err := l.resolve(fmt.Sprintf("%s/%d", ptr, i))
+		if err != nil {
+			return nil, err
+		}
+		p := Parameter{}
+		p.Name, _ = obj["name"].(string)
+		p.In, _ = obj["in"].(string)
+		p.Required, _ = obj["required"].(bool)
+		if obj["schema"] != nil {
+			if p.schema, err = l.schema(paramPtr + "/schema"); err != nil {
+				return nil, err
+			}
+			p.typ = l.schemaType(paramPtr + "/schema")
+			if schemaPtr, _, err := l.resolve(paramPtr + "/schema"); err == nil && p.typ == "array" {
+				p.items = l.schemaType(schemaPtr + "/items")
+			}
+		}
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+func (l *loader) content(ptr string) (content, error) {
+	media, _ := lookup(l.doc, ptr).(map[string]any)
+	c := content{}
+	for mediaType, v := range media {
+		c[mediaType] = nil
+		if obj, _ := v.(map[string]any); obj["schema"] != nil {
+			schema, err := l.schema(ptr + "/" + escape(mediaType) + "/schema")
+			if err != nil {
+				return nil, err
+			}
+			c[mediaType] = schema
+		}
+	}
+	return c, nil
+}
+
+func (l *loader) schema(ptr string) (*jsonschema.Schema, error) {
+	return l.compiler.Compile(documentURL + "#" + ptr)
+}
+
+// schemaType returns the type of the schema at ptr, following $ref.
+func (l *loader) schemaType(ptr string) string {
+	_, obj, err := l.resolve(ptr)
+	if err != nil {
+		return ""
+	}
+	typ, _ := obj["type"].(string)
+	return typ
+}
+
+// resolve follows local $ref values starting at ptr and returns the pointer
+// and value of the object they lead to.
+func (l *loader) resolve(ptr string) (string, map[string]any, error) {
+	for range 10 {
+		obj, ok := lookup(l.doc, ptr).(map[string]any)
+		if !ok {
+			return "", nil, fmt.Errorf("%s is not an object", ptr)
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return ptr, obj, nil
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return "", nil, fmt.Errorf("%s: only local references are supported, got %q", ptr, ref)
+		}
+		ptr = ref[1:]
+	}
+	return "", nil, fmt.Errorf("%s: too many nested references", ptr)
+}
+
+// lookup returns the value at a JSON pointer, or nil if there is none.
+func lookup(doc any, ptr string) any {
+	v := doc
+	for _, token := range strings.Split(strings.TrimPrefix(ptr, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch node := v.(type) {
+		case map[string]any:
+			v = node[token]
+		case []any:
+			var i int
+			if _, err := fmt.Sscan(token, &i); err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			v = node[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// escape encodes a JSON pointer token.
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Queries lists the query parameters the handler of each route reads,
+// keyed by method and gin route path, e.g. "GET /api/v1/tasks/". Routes
+// without query parameters are left out.
+type Queries map[string][]string
+
+// CheckRoutes compares the routes registered on a gin engine with the
+// documented operations. It reports routes that aren't documented,
+// operations that aren't routed, and path and query parameters that differ
+// between the two. Routes whose paths are listed in ignore are skipped.
+func (s *Spec) CheckRoutes(routes gin.RoutesInfo, queries Queries, ignore ...string) error {
+	var errs []error
+	routed := map[string]bool{}
+	for _, r := range routes {
+		if slices.Contains(ignore, r.Path) {
+			continue
+		}
+		key := operationKey(r.Method, templatePath(r.Path))
+		routed[key] = true
+
+		op, ok := s.operations[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s %s is not documented", r.Method, r.Path))
+			continue
+		}
+		errs = append(errs, op.checkPathParameters(r.Path)...)
+		errs = append(errs, op.checkQueryParameters(r.Path, queries[r.Method+" "+r.Path])...)
+	}
+	for route := range queries {
+		method, path, _ := strings.Cut(route, " ")
+		if !slices.ContainsFunc(routes, func(r gin.RouteInfo) bool { return r.Method == method && r.Path == path }) {
+			errs = append(errs, fmt.Errorf("query parameters are listed for %s, which is not routed", route))
+		}
+	}
+
+	for _, op := range s.Operations() {
+		if !routed[operationKey(op.Method, op.Path)] {
+			errs = append(errs, fmt.Errorf("%s %s is documented but not routed", op.Method, op.Path))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkPathParameters reports path parameters of the route that the
+// operation doesn't declare as required, and declared ones the route lacks.
+func (op *Operation) checkPathParameters(route string) []error {
+	var want []string
+	for _, segment := range strings.Split(route, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			want = append(want, segment[1:])
+		}
+	}
+
+	var errs []error
+	for _, name := range want {
+		i := slices.IndexFunc(op.Parameters, func(p Parameter) bool { return p.In == "path" && p.Name == name })
+		switch {
+		case i < 0:
+			errs = append(errs, fmt.Errorf("%s %s: path parameter %q is not documented", op.Method, route, name))
+		case !op.Parameters[i].Required:
+			errs = append(errs, fmt.Errorf("%s %s: path parameter %q must be required", op.Method, route, name))
+		}
+	}
+	for _, p := range op.Parameters {
+		if p.In == "path" && !slices.Contains(want, p.Name) {
+			errs = append(errs, fmt.Errorf("%s %s: documents path parameter %q the route doesn't have", op.Method, route, p.Name))
+		}
+	}
+	return errs
+}
+
+// checkQueryParameters reports query parameters the handler reads that the
+// operation doesn't declare, and declared ones the handler ignores.
+func (op *Operation) checkQueryParameters(route string, read []string) []error {
+	var errs []error
+	for _, name := range read {
+		if !slices.ContainsFunc(op.Parameters, func(p Parameter) bool { return p.In == "query" && p.Name == name }) {
+			errs = append(errs, fmt.Errorf("%s %s: query parameter %q is not documented", op.Method, route, name))
+		}
+	}
+	for _, p := range op.Parameters {
+		if p.In == "query" && !slices.Contains(read, p.Name) {
+			errs = append(errs, fmt.Errorf("%s %s: documents query parameter %q the handler doesn't read", op.Method, route, p.Name))
+		}
+	}
+	return errs
+}
+
+// FormFields returns the names gin binds query parameters to in the struct
+// v, from its form tags. Fields without a tag or tagged "-" are skipped.
+func FormFields(v any) []string {
+	var names []string
+	t := reflect.TypeOf(v)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			names = append(names, FormFields(reflect.Zero(f.Type).Interface())...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("form"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
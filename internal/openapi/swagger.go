@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: %s,
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    docExpansion: "none",
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// SwaggerUI serves Swagger UI showing the document at specURL. It must be
+// mounted on a catch-all route named "any", e.g. /swagger/*any.
+func SwaggerUI(specURL string) gin.HandlerFunc {
+	files := http.FileServer(http.FS(swaggerFiles.FS))
+	initializer := []byte(fmt.Sprintf(swaggerInitializer, strconv.Quote(specURL)))
+
+	return func(c *gin.Context) {
+		switch file := c.Param("any"); file {
+		case "":
+			c.Redirect(http.StatusMovedPermanently, c.Request.URL.Path+"/")
+		case "/swagger-initializer.js":
+			c.Data(http.StatusOK, "text/javascript; charset=utf-8", initializer)
+		default:
+			req := c.Request.Clone(c.Request.Context())
+			req.URL.Path = file
+			files.ServeHTTP(c.Writer, req)
+		}
+	}
+}
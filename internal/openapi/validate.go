@@ -0,0 +1,273 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// maxRecordedBody caps how much of a response is kept for validation.
+// Larger bodies are passed through without checking their content.
+const maxRecordedBody = 1 << 20
+
+// Middleware checks requests and responses of documented routes against
+// the spec. Requests that don't match are rejected with a validation problem
+// before they reach the handler. Responses that don't match have already
+// been sent, so they are logged as errors.
+//
+// It must run before middleware.Errors so it sees the problems rendered
+// for handler errors.
+func (s *Spec) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, ok := s.Operation(c.Request.Method, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if err := op.validateRequest(c); err != nil {
+			middleware.RenderProblem(c, err)
+			return
+		}
+
+		rec := &recorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+
+		if err := op.validateResponse(rec); err != nil {
+			ctx := c.Request.Context()
+			logger.FromContext(ctx).ErrorContext(ctx, "response does not match the API specification",
+				slog.String("operation", op.ID), slog.Int("status", rec.Status()), slog.Any("error", err))
+		}
+	}
+}
+
+func (op *Operation) validateRequest(c *gin.Context) *apperrors.Error {
+	var fields []apperrors.FieldError
+	for _, p := range op.Parameters {
+		var value string
+		var present bool
+		switch p.In {
+		case "path":
+			value = c.Param(p.Name)
+			present = value != ""
+		case "query":
+			if p.typ == "array" {
+				fields = append(fields, op.validateArray(c, p)...)
+				continue
+			}
+			value, present = c.GetQuery(p.Name)
+		case "header":
+			value = c.GetHeader(p.Name)
+			present = value != ""
+		default:
+			continue
+		}
+
+		if !present {
+			if p.Required {
+				fields = append(fields, apperrors.FieldError{Field: p.Name, Message: "is required"})
+			}
+			continue
+		}
+		if p.schema == nil {
+			continue
+		}
+		if err := p.schema.Validate(convert(value, p.typ)); err != nil {
+			fields = append(fields, schemaErrors(p.Name, err)...)
+		}
+	}
+	if len(fields) > 0 {
+		return apperrors.Validation("request parameters do not match the API specification", fields...)
+	}
+
+	if op.body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return apperrors.Validation("failed to read the request body").Wrap(err)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(body) == 0 {
+		if op.body.required {
+			return apperrors.Validation("request body is required")
+		}
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	schema, ok := op.body.content[mediaType]
+	if !ok {
+		return apperrors.Validation(fmt.Sprintf("content type %q is not accepted", mediaType))
+	}
+	if schema == nil || !isJSON(mediaType) {
+		return nil
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+	if err != nil {
+		return apperrors.Validation("request body is not valid JSON").Wrap(err)
+	}
+	if err := schema.Validate(doc); err != nil {
+		return apperrors.Validation("request body does not match the API specification", schemaErrors("", err)...).Wrap(err)
+	}
+	return nil
+}
+
+// validateArray checks an array query parameter, given once per item.
+func (op *Operation) validateArray(c *gin.Context, p Parameter) []apperrors.FieldError {
+	values, present := c.GetQueryArray(p.Name)
+	if !present {
+		if p.Required {
+			return []apperrors.FieldError{{Field: p.Name, Message: "is required"}}
+		}
+		return nil
+	}
+	items := make([]any, len(values))
+	for i, v := range values {
+		items[i] = convert(v, p.items)
+	}
+	if err := p.schema.Validate(items); err != nil {
+		return schemaErrors(p.Name, err)
+	}
+	return nil
+}
+
+func (op *Operation) validateResponse(rec *recorder) error {
+	status := rec.Status()
+	media, ok := op.responses[strconv.Itoa(status)]
+	if !ok {
+		media, ok = op.responses[strconv.Itoa(status/100)+"XX"]
+	}
+	if !ok {
+		media, ok = op.responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("status %d is not documented", status)
+	}
+	if rec.Size() <= 0 {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	schema, ok := media[mediaType]
+	if !ok {
+		major, _, _ := strings.Cut(mediaType, "/")
+		schema, ok = media[major+"/*"]
+	}
+	if !ok {
+		schema, ok = media["*/*"]
+	}
+	if !ok {
+		return fmt.Errorf("content type %q is not documented for status %d", mediaType, status)
+	}
+	if schema == nil || !isJSON(mediaType) || rec.truncated {
+		return nil
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(rec.body.Bytes()))
+	if err != nil {
+		return fmt.Errorf("body is not valid JSON: %w", err)
+	}
+	return schema.Validate(doc)
+}
+
+// convert turns a parameter value into the JSON type its schema expects,
+// leaving values that don't parse as strings so the schema rejects them.
+func convert(value, typ string) any {
+	switch typ {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return json.Number(value)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+var printer = message.NewPrinter(language.English)
+
+// schemaErrors lists the leaf errors of a schema validation failure. Field
+// names are the instance locations below prefix, joined with dots.
+func schemaErrors(prefix string, err error) []apperrors.FieldError {
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return []apperrors.FieldError{{Field: prefix, Message: err.Error()}}
+	}
+
+	var fields []apperrors.FieldError
+	var walk func(*jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			field := strings.Join(append([]string{prefix}, e.InstanceLocation...), ".")
+			fields = append(fields, apperrors.FieldError{
+				Field:   strings.Trim(field, "."),
+				Message: e.ErrorKind.LocalizedString(printer),
+			})
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(verr)
+	return fields
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// recorder keeps a copy of the response body for validation.
+type recorder struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.record(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) WriteString(s string) (int, error) {
+	r.record([]byte(s))
+	return r.ResponseWriter.WriteString(s)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (r *recorder) record(b []byte) {
+	if r.truncated {
+		return
+	}
+	if r.body.Len()+len(b) > maxRecordedBody {
+		r.truncated = true
+		r.body.Reset()
+		return
+	}
+	r.body.Write(b)
+}
@@ -0,0 +1,167 @@
+// Package rank generates fractional index keys: strings that order tasks
+// within a board column when compared bytewise. A key can always be made
+// between two others, so moving a task only rewrites the moved task's key.
+//
+// Keys use the base 62 digits 0-9, A-Z and a-z, whose ASCII order matches
+// their value, and never end in "0" so there is room below every key.
+// Databases must compare them bytewise (COLLATE "C" on Postgres).
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MaxLength is the longest key stored. Between can produce longer keys when
+// tasks are repeatedly moved into the same gap; the column is re-ranked
+// with Spread then.
+const MaxLength = 255
+
+// jitterLength is the number of random digits After appends.
+const jitterLength = 3
+
+// ErrNoRoom is returned by Between when the bounds are equal or out of
+// order, so no key sorts between them.
+var ErrNoRoom = errors.New("rank: no key between the bounds")
+
+// Between returns a key that sorts after a and before b. An empty a means
+// no lower bound and an empty b no upper bound.
+func Between(a, b string) (string, error) {
+	if err := validate(a); err != nil {
+		return "", err
+	}
+	if err := validate(b); err != nil {
+		return "", err
+	}
+	if b != "" && a >= b {
+		return "", ErrNoRoom
+	}
+	return midpoint(a, b), nil
+}
+
+// After returns a key after a, with random digits appended so that callers
+// appending after the same key at the same time are unlikely to get equal
+// keys. It is meant for writes that don't lock the column.
+func After(a string) (string, error) {
+	key, err := Between(a, "")
+	if err != nil {
+		return "", err
+	}
+	var jitter strings.Builder
+	for i := 0; i < jitterLength; i++ {
+		// Skip "0" so the key doesn't end in it.
+		jitter.WriteByte(digits[1+rand.IntN(len(digits)-1)])
+	}
+	return key + jitter.String(), nil
+}
+
+// AfterN returns n ascending keys after a, for appending several items at
+// once. The first key is After's; the rest are spread over the space above
+// it by bisection, so they stay about as short as the first instead of
+// growing with every key as repeated calls to After would.
+func AfterN(a string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	first, err := After(a)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, n)
+	keys = append(keys, first)
+	var fill func(lo, hi string, n int)
+	fill = func(lo, hi string, n int) {
+		if n == 0 {
+			return
+		}
+		mid := midpoint(lo, hi)
+		fill(lo, mid, (n-1)/2)
+		keys = append(keys, mid)
+		fill(mid, hi, n-1-(n-1)/2)
+	}
+	fill(first, "", n-1)
+	return keys, nil
+}
+
+// Spread returns n ascending keys spaced evenly, for re-ranking a column.
+func Spread(n int) []string {
+	width, space := 1, len(digits)
+	for space <= n {
+		width++
+		space *= len(digits)
+	}
+
+	keys := make([]string, n)
+	buf := make([]byte, width)
+	for i := range keys {
+		value := (i + 1) * space / (n + 1)
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[value%len(digits)]
+			value /= len(digits)
+		}
+		keys[i] = strings.TrimRight(string(buf), "0")
+	}
+	return keys
+}
+
+func validate(key string) error {
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return fmt.Errorf("rank: invalid key %q", key)
+		}
+	}
+	if strings.HasSuffix(key, "0") {
+		return fmt.Errorf("rank: invalid key %q ends in 0", key)
+	}
+	return nil
+}
+
+// midpoint returns a key between a and b, which are valid and ordered.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix, reading missing digits of a as zeros.
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+	high := len(digits)
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+	if high-low > 1 {
+		return string(digits[(low+high+1)/2])
+	}
+	// The first digits are consecutive: a shorter key below b exists if b
+	// has more digits, otherwise extend a.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[low]) + midpoint(suffix(a, 1), "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return digits[0]
+}
+
+func suffix(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
@@ -0,0 +1,198 @@
+package rank
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// wantAscending fails unless keys are valid and strictly ascending, and all
+// sort after lower.
+func wantAscending(t *testing.T, lower string, keys []string) {
+	t.Helper()
+	prev := lower
+	for i, key := range keys {
+		if key == "" || validate(key) != nil {
+			t.Fatalf("key %d is invalid: %q", i, key)
+		}
+		if key <= prev {
+			t.Fatalf("key %d %q doesn't sort after %q", i, key, prev)
+		}
+		prev = key
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name, a, b string
+	}{
+		{"no bounds", "", ""},
+		{"no lower bound", "", "V"},
+		{"below the first digit", "", "1"},
+		{"below a long key", "", "0001"},
+		{"no upper bound", "V", ""},
+		{"above the last digit", "z", ""},
+		{"above a long key", "zzzz", ""},
+		{"wide gap", "1", "y"},
+		{"adjacent digits", "1", "2"},
+		{"adjacent long keys", "Vzz", "W"},
+		{"a prefix of the upper bound", "V", "V1"},
+		{"a prefix of the upper bound with room", "V", "VV"},
+		{"shared prefix", "abc1", "abc2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Between(%q, %q): %v", tt.a, tt.b, err)
+			}
+			if validate(got) != nil || got <= tt.a || tt.b != "" && got >= tt.b {
+				t.Errorf("Between(%q, %q) = %q", tt.a, tt.b, got)
+			}
+		})
+	}
+}
+
+func TestBetweenRejects(t *testing.T) {
+	tests := []struct {
+		name, a, b string
+		noRoom     bool
+	}{
+		{"equal bounds", "V", "V", true},
+		{"bounds out of order", "W", "V", true},
+		{"a key ending in 0", "V0", "", false},
+		{"a digit out of the alphabet", "", "V-", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Between(tt.a, tt.b)
+			if err == nil || errors.Is(err, ErrNoRoom) != tt.noRoom {
+				t.Errorf("Between(%q, %q) = %v, want no room %v", tt.a, tt.b, err, tt.noRoom)
+			}
+		})
+	}
+}
+
+// Moving tasks into the same gap over and over makes keys grow until the
+// column has to be re-ranked with Spread.
+func TestBetweenRepeatedlyThenSpread(t *testing.T) {
+	tests := []struct {
+		name  string
+		below bool // whether each move goes right below the last one
+	}{
+		{"moves below the last moved task", true},
+		{"moves above the last moved task", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := "1", "2"
+			keys := []string{lo, hi}
+			for len(keys[len(keys)-1]) <= MaxLength {
+				if len(keys) > 10000 {
+					t.Fatalf("keys are still %d digits long after %d moves", len(keys[len(keys)-1]), len(keys))
+				}
+				key, err := Between(lo, hi)
+				if err != nil {
+					t.Fatalf("move %d: Between(%q, %q): %v", len(keys), lo, hi, err)
+				}
+				if key <= lo || key >= hi {
+					t.Fatalf("move %d: Between(%q, %q) = %q", len(keys), lo, hi, key)
+				}
+				keys = append(keys, key)
+				if tt.below {
+					hi = key
+				} else {
+					lo = key
+				}
+			}
+
+			spread := Spread(len(keys))
+			wantAscending(t, "", spread)
+			for _, key := range spread {
+				if len(key) > 3 {
+					t.Fatalf("re-ranking %d tasks gave key %q", len(keys), key)
+				}
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	for _, a := range []string{"", "1", "V", "zzz"} {
+		got, err := After(a)
+		if err != nil {
+			t.Fatalf("After(%q): %v", a, err)
+		}
+		wantAscending(t, a, []string{got})
+		if base, _ := Between(a, ""); !strings.HasPrefix(got, base) || len(got) != len(base)+jitterLength {
+			t.Errorf("After(%q) = %q, want %q and %d random digits", a, got, base, jitterLength)
+		}
+	}
+}
+
+func TestAfterN(t *testing.T) {
+	tests := []struct {
+		a string
+		n int
+	}{
+		{"", 0},
+		{"", 1},
+		{"", 2},
+		{"V", 10},
+		{"V", 100},
+		{"zzz", 50},
+		{"y", 1000},
+	}
+	for _, tt := range tests {
+		keys, err := AfterN(tt.a, tt.n)
+		if err != nil {
+			t.Fatalf("AfterN(%q, %d): %v", tt.a, tt.n, err)
+		}
+		if len(keys) != tt.n {
+			t.Fatalf("AfterN(%q, %d) returned %d keys", tt.a, tt.n, len(keys))
+		}
+		wantAscending(t, tt.a, keys)
+		// Bisection keeps the keys about as short as the first.
+		for _, key := range keys {
+			if len(key) > len(keys[0])+2 {
+				t.Errorf("AfterN(%q, %d) gave %q after %q", tt.a, tt.n, key, keys[0])
+			}
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		n, width int
+	}{
+		{0, 0},
+		{1, 1},
+		{61, 1},
+		{62, 2},
+		{3843, 2},
+		{3844, 3},
+	}
+	for _, tt := range tests {
+		keys := Spread(tt.n)
+		if len(keys) != tt.n {
+			t.Fatalf("Spread(%d) returned %d keys", tt.n, len(keys))
+		}
+		wantAscending(t, "", keys)
+		for _, key := range keys {
+			if len(key) > tt.width {
+				t.Fatalf("Spread(%d) gave %q, want at most %d digits", tt.n, key, tt.width)
+			}
+		}
+		// There is room around every key.
+		for i := 0; i+1 < len(keys); i++ {
+			if _, err := Between(keys[i], keys[i+1]); err != nil {
+				t.Fatalf("no room between %q and %q: %v", keys[i], keys[i+1], err)
+			}
+		}
+		if tt.n > 0 {
+			if _, err := Between("", keys[0]); err != nil {
+				t.Fatalf("no room below %q: %v", keys[0], err)
+			}
+		}
+	}
+}
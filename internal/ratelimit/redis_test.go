@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis, *time.Time) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewRedisStore(client)
+	store.now = func() time.Time { return now }
+	return store, mr, &now
+}
+
+func TestRedisStoreBurstThenDeny(t *testing.T) {
+	store, _, _ := newTestRedisStore(t)
+	rule := Rule{Requests: 60, Period: time.Minute, Burst: 3}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		res, err := store.Take(ctx, "client", rule)
+		if err != nil {
+			t.Fatalf("take: %v", err)
+		}
+		if !res.Allowed || res.Remaining != i || res.Limit != 3 {
+			t.Fatalf("take %d: got %+v, want allowed with %d remaining", 3-i, res, i)
+		}
+	}
+
+	res, err := store.Take(ctx, "client", rule)
+	if err != nil {
+		t.Fatalf("take: %v", err)
+	}
+	if res.Allowed {
+		t.Fatalf("request over the burst was allowed: %+v", res)
+	}
+	if res.RetryAfter != time.Second {
+		t.Errorf("RetryAfter = %v, want 1s", res.RetryAfter)
+	}
+	if res.Reset != 3*time.Second {
+		t.Errorf("Reset = %v, want 3s", res.Reset)
+	}
+}
+
+func TestRedisStoreRefills(t *testing.T) {
+	store, _, now := newTestRedisStore(t)
+	rule := Rule{Requests: 1, Period: time.Second, Burst: 2}
+	ctx := context.Background()
+
+	for range 2 {
+		if res, _ := store.Take(ctx, "client", rule); !res.Allowed {
+			t.Fatalf("burst request denied: %+v", res)
+		}
+	}
+	if res, _ := store.Take(ctx, "client", rule); res.Allowed {
+		t.Fatalf("empty bucket allowed a request: %+v", res)
+	}
+
+	*now = now.Add(1500 * time.Millisecond)
+	res, err := store.Take(ctx, "client", rule)
+	if err != nil {
+		t.Fatalf("take: %v", err)
+	}
+	if !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("after refill: got %+v, want allowed with 0 remaining", res)
+	}
+
+	// Refill is capped at the burst size however long the bucket sat idle.
+	*now = now.Add(time.Hour)
+	res, _ = store.Take(ctx, "client", rule)
+	if !res.Allowed || res.Remaining != 1 {
+		t.Fatalf("after idle: got %+v, want allowed with 1 remaining", res)
+	}
+}
+
+func TestRedisStoreKeysAreIndependentAndExpire(t *testing.T) {
+	store, mr, _ := newTestRedisStore(t)
+	rule := Rule{Requests: 1, Period: time.Second, Burst: 1}
+	ctx := context.Background()
+
+	if res, _ := store.Take(ctx, "a", rule); !res.Allowed {
+		t.Fatalf("first request for a denied: %+v", res)
+	}
+	if res, _ := store.Take(ctx, "b", rule); !res.Allowed {
+		t.Fatalf("first request for b denied: %+v", res)
+	}
+	if res, _ := store.Take(ctx, "a", rule); res.Allowed {
+		t.Fatalf("second request for a allowed: %+v", res)
+	}
+
+	ttl := mr.TTL("ratelimit:a")
+	if ttl <= 0 || ttl > 3*time.Second {
+		t.Errorf("bucket TTL = %v, want the refill time plus a margin", ttl)
+	}
+	mr.FastForward(ttl)
+	if mr.Exists("ratelimit:a") {
+		t.Error("bucket was not expired after its TTL")
+	}
+}
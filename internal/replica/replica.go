@@ -0,0 +1,214 @@
+// Package replica routes read queries to read replicas with GORM's
+// dbresolver. Writes always go to the primary; reads fall back to the
+// primary while a client is inside its read-your-writes window or when no
+// replica is healthy.
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Replica is a connection pool to one read replica.
+type Replica struct {
+	Name string // shown in logs, e.g. "replica-1:5432"
+	Conn *sql.DB
+}
+
+// Options configures routing.
+type Options struct {
+	// ReadYourWrites is how long a client's reads stay on the primary after
+	// it wrote, so it sees its own changes despite replication lag.
+	ReadYourWrites time.Duration
+	// CheckInterval is how often replicas are pinged.
+	CheckInterval time.Duration
+}
+
+type member struct {
+	Replica
+	healthy atomic.Bool
+}
+
+// Router decides which reads may go to a replica.
+type Router struct {
+	members []*member
+	byConn  map[gorm.ConnPool]*member
+	next    atomic.Uint64
+	opts    Options
+	log     *slog.Logger
+
+	writes sync.Map // client key -> time.Time until which reads use the primary
+	now    func() time.Time
+
+	running atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// Setup registers dbresolver on db with the given replicas and returns the
+// Router controlling it. Replicas start out healthy; call Run to check them.
+func Setup(db *gorm.DB, replicas []Replica, opts Options, log *slog.Logger) (*Router, error) {
+	if len(replicas) == 0 {
+		return nil, errors.New("no replicas configured")
+	}
+
+	r := &Router{
+		byConn: make(map[gorm.ConnPool]*member, len(replicas)),
+		opts:   opts,
+		log:    log,
+		now:    time.Now,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	dialectors := make([]gorm.Dialector, 0, len(replicas))
+	for _, replica := range replicas {
+		m := &member{Replica: replica}
+		m.healthy.Store(true)
+		r.members = append(r.members, m)
+		r.byConn[replica.Conn] = m
+
+		dialector, err := dialectorFor(db.Dialector.Name(), replica.Conn)
+		if err != nil {
+			return nil, err
+		}
+		dialectors = append(dialectors, dialector)
+	}
+
+	if err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: dialectors, Policy: r})); err != nil {
+		return nil, fmt.Errorf("failed to register dbresolver: %w", err)
+	}
+
+	// dbresolver sends every read to a replica; these callbacks switch a
+	// statement back to the primary when it must see the latest writes.
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("replica:route", r.route); err != nil {
+		return nil, err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("replica:route", r.route); err != nil {
+		return nil, err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("replica:route", r.route); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// dialectorFor wraps an open replica pool in a dialector of the primary's
+// dialect, so dbresolver uses that pool rather than opening its own.
+func dialectorFor(dialect string, conn *sql.DB) (gorm.Dialector, error) {
+	switch dialect {
+	case "postgres":
+		return postgres.New(postgres.Config{Conn: conn}), nil
+	case "sqlite":
+		return sqlite.New(sqlite.Config{Conn: conn}), nil
+	default:
+		return nil, fmt.Errorf("replicas are not supported for %s", dialect)
+	}
+}
+
+func (r *Router) route(db *gorm.DB) {
+	if usePrimary(db.Statement.Context) || !r.anyHealthy() {
+		dbresolver.Write.ModifyStatement(db.Statement)
+	}
+}
+
+// Resolve implements dbresolver.Policy, picking healthy replicas round
+// robin. dbresolver skips the policy when there is a single replica, which
+// is why route also checks health.
+func (r *Router) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	start := r.next.Add(1)
+	for i := range pools {
+		pool := pools[(start+uint64(i))%uint64(len(pools))]
+		if m, ok := r.byConn[pool]; !ok || m.healthy.Load() {
+			return pool
+		}
+	}
+	return pools[start%uint64(len(pools))]
+}
+
+// PoolName returns the name of the replica owning pool, or "primary" for
+// any other pool.
+func (r *Router) PoolName(pool gorm.ConnPool) string {
+	if m, ok := r.byConn[pool]; ok {
+		return m.Name
+	}
+	return "primary"
+}
+
+func (r *Router) anyHealthy() bool {
+	for _, m := range r.members {
+		if m.healthy.Load() {
+			return true
+		}
+	}
+	return false
+}
+
+// Check pings every replica and updates its health, logging changes.
+func (r *Router) Check(ctx context.Context) {
+	for _, m := range r.members {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err := m.Conn.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if m.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			r.log.Info("replica is healthy again", slog.String("replica", m.Name))
+		} else {
+			r.log.Warn("replica is unhealthy, reads fall back to the primary",
+				slog.String("replica", m.Name), slog.Any("error", err))
+		}
+	}
+}
+
+// Run checks the replicas every CheckInterval and forgets expired
+// read-your-writes windows until Close is called.
+func (r *Router) Run() {
+	r.running.Store(true)
+	defer close(r.done)
+	ticker := time.NewTicker(r.opts.CheckInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		r.Check(ctx)
+		r.pruneWrites()
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops Run, if it was started, and closes the replica pools.
+func (r *Router) Close(ctx context.Context) error {
+	close(r.stop)
+	if r.running.Load() {
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+		}
+	}
+
+	var errs []error
+	for _, m := range r.members {
+		errs = append(errs, m.Conn.Close())
+	}
+	return errors.Join(errs...)
+}
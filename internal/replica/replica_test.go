@@ -0,0 +1,163 @@
+package replica
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// origin is a row telling which database served a read.
+type origin struct {
+	ID   int
+	Name string
+}
+
+// openSQLite opens a database file holding one origin row named name.
+func openSQLite(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name+".db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&origin{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&origin{ID: 1, Name: name}).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// newReplicated returns a router over a primary and one replica, and a gin
+// engine whose routes answer with the name of the database they read. The
+// X-User header stands in for authentication.
+func newReplicated(t *testing.T) (*Router, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db := openSQLite(t, "primary")
+	conn, err := openSQLite(t, "replica").DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	router, err := Setup(db, []Replica{{Name: "replica", Conn: conn}},
+		Options{ReadYourWrites: time.Minute, CheckInterval: time.Minute},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		router.Close(context.Background())
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	read := func(c *gin.Context) {
+		var row origin
+		if err := db.WithContext(c.Request.Context()).First(&row).Error; err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.String(http.StatusOK, row.Name)
+	}
+	engine := gin.New()
+	authenticated := engine.Group("", func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			c.Set(middleware.UserIDKey, user)
+		}
+	}, router.Middleware())
+	authenticated.GET("/read", read)
+	authenticated.POST("/write", read)
+	authenticated.POST("/query", func(c *gin.Context) {
+		ReadOnly(c)
+		read(c)
+	})
+	return router, engine
+}
+
+// served returns the database that served a request by user from ip.
+func served(t *testing.T, engine *gin.Engine, method, path, user, ip string) string {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":1234"
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s %s: status %d: %s", method, path, rec.Code, rec.Body)
+	}
+	return rec.Body.String()
+}
+
+func TestReadYourWritesIsPerUser(t *testing.T) {
+	router, engine := newReplicated(t)
+	now := time.Now()
+	router.now = func() time.Time { return now }
+
+	if got := served(t, engine, http.MethodGet, "/read", "ada", "192.0.2.1"); got != "replica" {
+		t.Fatalf("read before any write served by %s", got)
+	}
+	if got := served(t, engine, http.MethodPost, "/write", "ada", "192.0.2.1"); got != "primary" {
+		t.Fatalf("write served by %s", got)
+	}
+
+	tests := []struct {
+		name, user, ip, want string
+	}{
+		{"the writer", "ada", "192.0.2.1", "primary"},
+		{"the writer from another address", "ada", "198.51.100.7", "primary"},
+		{"another user at the same address", "bob", "192.0.2.1", "replica"},
+		{"an anonymous client at the same address", "", "192.0.2.1", "replica"},
+	}
+	for _, tt := range tests {
+		if got := served(t, engine, http.MethodGet, "/read", tt.user, tt.ip); got != tt.want {
+			t.Errorf("%s read from %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	if got := served(t, engine, http.MethodGet, "/read", "ada", "192.0.2.1"); got != "replica" {
+		t.Errorf("read after the window served by %s", got)
+	}
+}
+
+func TestReadOnlyRequestsUseReplicas(t *testing.T) {
+	_, engine := newReplicated(t)
+
+	if got := served(t, engine, http.MethodPost, "/query", "ada", "192.0.2.1"); got != "replica" {
+		t.Fatalf("read-only POST served by %s", got)
+	}
+	if got := served(t, engine, http.MethodGet, "/read", "ada", "192.0.2.1"); got != "replica" {
+		t.Errorf("a read-only POST pinned later reads to the %s", got)
+	}
+
+	served(t, engine, http.MethodPost, "/write", "ada", "192.0.2.1")
+	if got := served(t, engine, http.MethodPost, "/query", "ada", "192.0.2.1"); got != "primary" {
+		t.Errorf("read-only POST after a write served by %s", got)
+	}
+}
+
+func TestReadsFallBackToThePrimary(t *testing.T) {
+	router, engine := newReplicated(t)
+	if got := served(t, engine, http.MethodGet, "/read", "ada", "192.0.2.1"); got != "replica" {
+		t.Fatalf("read served by %s", got)
+	}
+
+	router.members[0].Conn.Close()
+	router.Check(context.Background())
+	if got := served(t, engine, http.MethodGet, "/read", "ada", "192.0.2.1"); got != "primary" {
+		t.Errorf("read with no healthy replica served by %s", got)
+	}
+}
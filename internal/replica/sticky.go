@@ -0,0 +1,94 @@
+package replica
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/middleware"
+)
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose queries are sent to the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// Gin context keys recording what the middleware and ReadOnly decided.
+const (
+	pinnedKey   = "replica.pinned"
+	readOnlyKey = "replica.readOnly"
+)
+
+// Middleware gives clients read-your-writes consistency: requests that
+// modify data, and every request from the same client for ReadYourWrites
+// after a successful one, read from the primary. Clients are told apart by
+// user once authenticated, so on authenticated routes the middleware must
+// run after middleware.Authenticate; other clients are told apart by IP.
+func (r *Router) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := clientKey(c)
+		write := !safeMethod(c.Request.Method)
+		pinned := r.recentlyWrote(key)
+		c.Set(pinnedKey, pinned)
+		if write || pinned {
+			c.Request = c.Request.WithContext(WithPrimary(c.Request.Context()))
+		}
+
+		c.Next()
+
+		if write && !c.GetBool(readOnlyKey) && c.Writer.Status() < http.StatusBadRequest {
+			r.writes.Store(key, r.now().Add(r.opts.ReadYourWrites))
+		}
+	}
+}
+
+// ReadOnly tells the middleware that a request sent with a method that
+// normally writes only reads, such as a GraphQL query sent with POST.
+// Unless the client wrote recently, its reads may go to replicas, and it
+// doesn't start a read-your-writes window. It must be called before the
+// request's first query.
+func ReadOnly(c *gin.Context) {
+	c.Set(readOnlyKey, true)
+	if !c.GetBool(pinnedKey) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), primaryKey{}, false))
+	}
+}
+
+func (r *Router) recentlyWrote(key string) bool {
+	until, ok := r.writes.Load(key)
+	return ok && r.now().Before(until.(time.Time))
+}
+
+func (r *Router) pruneWrites() {
+	now := r.now()
+	r.writes.Range(func(key, until any) bool {
+		if !now.Before(until.(time.Time)) {
+			r.writes.Delete(key)
+		}
+		return true
+	})
+}
+
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// clientKey identifies the client like the rate limiter does: by user when
+// authenticated, otherwise by IP.
+func clientKey(c *gin.Context) string {
+	if userID := c.GetString(middleware.UserIDKey); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -2,51 +2,89 @@ package repositories
 
 import (
 	"context"
+	"reflect"
+	"strings"
 
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
 	"gorm.io/gorm"
 )
 
 type BaseRepository[T any] interface {
 	Create(ctx context.Context, entity *T) error
 	GetByID(ctx context.Context, id string) (*T, error)
+	// GetByIDs returns the entities with the given IDs ordered by ID.
+	// Unknown IDs are skipped rather than reported.
+	GetByIDs(ctx context.Context, ids []string) ([]T, error)
 	Update(ctx context.Context, entity *T) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, offset, limit int) ([]T, error)
 }
 
 type baseRepository[T any] struct {
-	db *gorm.DB
+	db       *gorm.DB
+	resource string // used in error codes, e.g. "task_not_found"
 }
 
 func NewBaseRepository[T any](db *gorm.DB) BaseRepository[T] {
-	return &baseRepository[T]{db: db}
+	return &baseRepository[T]{db: db, resource: resourceName[T]()}
 }
 
 func (r *baseRepository[T]) Create(ctx context.Context, entity *T) error {
-	return r.db.Create(entity).Error
+	return apperrors.FromDB(r.db.WithContext(ctx).Create(entity).Error, r.resource)
 }
 
 func (r *baseRepository[T]) GetByID(ctx context.Context, id string) (*T, error) {
 	var entity T
-	if err := r.db.First(&entity, "id=?", id).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).First(&entity, "id=?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, r.resource)
 	}
 	return &entity, nil
 }
 
+func (r *baseRepository[T]) GetByIDs(ctx context.Context, ids []string) ([]T, error) {
+	entities := []T{}
+	if len(ids) == 0 {
+		return entities, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&entities).Error; err != nil {
+		return nil, apperrors.FromDB(err, r.resource)
+	}
+	return entities, nil
+}
+
 func (r *baseRepository[T]) Update(ctx context.Context, entity *T) error {
-	return r.db.Save(entity).Error
+	return apperrors.FromDB(r.db.WithContext(ctx).Save(entity).Error, r.resource)
 }
 
 func (r *baseRepository[T]) Delete(ctx context.Context, id string) error {
 	var entity T
-	return r.db.Delete(&entity, "id=?", id).Error
+	result := r.db.WithContext(ctx).Delete(&entity, "id=?", id)
+	if result.Error != nil {
+		return apperrors.FromDB(result.Error, r.resource)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound(r.resource)
+	}
+	return nil
 }
 
 func (r *baseRepository[T]) List(ctx context.Context, offset, limit int) ([]T, error) {
 	var entities []T
-	if err := r.db.Offset(offset).Limit(limit).Find(&entities).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).Order("id").Offset(offset).Limit(limit).Find(&entities).Error; err != nil {
+		return nil, apperrors.FromDB(err, r.resource)
 	}
 	return entities, nil
 }
+
+// resourceName derives a snake_case resource name from the model type.
+func resourceName[T any]() string {
+	name := reflect.TypeOf((*T)(nil)).Elem().Name()
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// BoardRepository stores the settings of the board columns. The columns
+// themselves are fixed: one per task status, created by the migrations.
+type BoardRepository interface {
+	// GetColumns returns every column, in no particular order.
+	GetColumns(ctx context.Context) ([]models.BoardColumn, error)
+	GetColumn(ctx context.Context, status string) (*models.BoardColumn, error)
+	// UpdateColumn saves the column's settings and sets its UpdatedAt.
+	UpdateColumn(ctx context.Context, column *models.BoardColumn) error
+}
+
+type boardRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardRepository(db *gorm.DB) BoardRepository {
+	return &boardRepository{db: db}
+}
+
+func (r *boardRepository) GetColumns(ctx context.Context) ([]models.BoardColumn, error) {
+	var columns []models.BoardColumn
+	if err := r.db.WithContext(ctx).Find(&columns).Error; err != nil {
+		return nil, apperrors.FromDB(err, "board_column")
+	}
+	return columns, nil
+}
+
+func (r *boardRepository) GetColumn(ctx context.Context, status string) (*models.BoardColumn, error) {
+	var column models.BoardColumn
+	if err := r.db.WithContext(ctx).Take(&column, "status=?", status).Error; err != nil {
+		return nil, apperrors.FromDB(err, "board_column")
+	}
+	return &column, nil
+}
+
+func (r *boardRepository) UpdateColumn(ctx context.Context, column *models.BoardColumn) error {
+	column.UpdatedAt = time.Now()
+	// A map so that a nil WIPLimit is written as NULL.
+	result := r.db.WithContext(ctx).Model(&models.BoardColumn{}).Where("status=?", column.Status).
+		Updates(map[string]interface{}{"wip_limit": column.WIPLimit, "updated_at": column.UpdatedAt})
+	if result.Error != nil {
+		return apperrors.FromDB(result.Error, "board_column")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("board_column")
+	}
+	return nil
+}
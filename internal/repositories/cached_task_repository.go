@@ -0,0 +1,254 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/cache"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"golang.org/x/sync/singleflight"
+)
+
+// generationKey versions every cached task and list; bumping it on writes
+// invalidates them all at once without having to enumerate their keys.
+// Since a read builds its key before loading, a load that started before a
+// write is stored under the old generation, where no later read finds it.
+const generationKey = "tasks:generation"
+
+// cachedTaskRepository is a read-through cache in front of a TaskRepository.
+// Cache failures are logged and fall back to the wrapped repository.
+type cachedTaskRepository struct {
+	TaskRepository
+	cache   cache.Cache
+	ttl     time.Duration
+	metrics *cache.Metrics
+	group   singleflight.Group
+}
+
+const defaultCacheTTL = 30 * time.Second
+
+func NewCachedTaskRepository(repo TaskRepository, c cache.Cache, ttl time.Duration, metrics *cache.Metrics) TaskRepository {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &cachedTaskRepository{
+		TaskRepository: repo,
+		cache:          c,
+		ttl:            ttl,
+		metrics:        metrics,
+	}
+}
+
+func (r *cachedTaskRepository) Create(ctx context.Context, task *models.Task) error {
+	if err := r.TaskRepository.Create(ctx, task); err != nil {
+		return err
+	}
+	r.invalidate(ctx)
+	return nil
+}
+
+func (r *cachedTaskRepository) CreateBatch(ctx context.Context, tasks []models.Task, wipLimits map[string]int) error {
+	if err := r.TaskRepository.CreateBatch(ctx, tasks, wipLimits); err != nil {
+		return err
+	}
+	r.invalidate(ctx)
+	return nil
+}
+
+func (r *cachedTaskRepository) Update(ctx context.Context, task *models.Task) error {
+	if err := r.TaskRepository.Update(ctx, task); err != nil {
+		return err
+	}
+	r.invalidate(ctx)
+	return nil
+}
+
+func (r *cachedTaskRepository) Delete(ctx context.Context, id string) error {
+	if err := r.TaskRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx)
+	return nil
+}
+
+func (r *cachedTaskRepository) GetByID(ctx context.Context, id string) (*models.Task, error) {
+	return readThrough(ctx, r, "get_by_id", r.key(ctx, "id", id), func(ctx context.Context) (*models.Task, error) {
+		return r.TaskRepository.GetByID(ctx, id)
+	})
+}
+
+func (r *cachedTaskRepository) List(ctx context.Context, offset, limit int) ([]models.Task, error) {
+	return readThrough(ctx, r, "list", r.listKey(ctx, "all", "", offset, limit), func(ctx context.Context) ([]models.Task, error) {
+		return r.TaskRepository.List(ctx, offset, limit)
+	})
+}
+
+func (r *cachedTaskRepository) GetByUserID(ctx context.Context, userID string, offset, limit int) ([]models.Task, error) {
+	return readThrough(ctx, r, "list_by_user", r.listKey(ctx, "user", userID, offset, limit), func(ctx context.Context) ([]models.Task, error) {
+		return r.TaskRepository.GetByUserID(ctx, userID, offset, limit)
+	})
+}
+
+func (r *cachedTaskRepository) GetByStatus(ctx context.Context, status string, offset, limit int) ([]models.Task, error) {
+	return readThrough(ctx, r, "list_by_status", r.listKey(ctx, "status", status, offset, limit), func(ctx context.Context) ([]models.Task, error) {
+		return r.TaskRepository.GetByStatus(ctx, status, offset, limit)
+	})
+}
+
+func (r *cachedTaskRepository) GetByPriority(ctx context.Context, priority string, offset, limit int) ([]models.Task, error) {
+	return readThrough(ctx, r, "list_by_priority", r.listKey(ctx, "priority", priority, offset, limit), func(ctx context.Context) ([]models.Task, error) {
+		return r.TaskRepository.GetByPriority(ctx, priority, offset, limit)
+	})
+}
+
+func (r *cachedTaskRepository) Find(ctx context.Context, query models.TaskListQuery) ([]models.Task, error) {
+	return readThrough(ctx, r, "find", r.listKey(ctx, "find", filterKey(query), query.Offset(), query.Limit), func(ctx context.Context) ([]models.Task, error) {
+		return r.TaskRepository.Find(ctx, query)
+	})
+}
+
+func (r *cachedTaskRepository) Count(ctx context.Context, query models.TaskListQuery) (int, error) {
+	return readThrough(ctx, r, "count", r.listKey(ctx, "count", filterKey(query), 0, 0), func(ctx context.Context) (int, error) {
+		return r.TaskRepository.Count(ctx, query)
+	})
+}
+
+// filterKey identifies the filter and sort of query in cache keys.
+func filterKey(query models.TaskListQuery) string {
+	return url.Values{
+		"status":   {query.Status},
+		"priority": {strconv.Itoa(query.Priority)},
+		"user_id":  {query.UserID},
+		"q":        {query.Query},
+		"sort":     {query.Sort},
+	}.Encode()
+}
+
+func (r *cachedTaskRepository) GetColumn(ctx context.Context, status string, offset, limit int) ([]models.Task, error) {
+	return readThrough(ctx, r, "column", r.listKey(ctx, "column", status, offset, limit), func(ctx context.Context) ([]models.Task, error) {
+		return r.TaskRepository.GetColumn(ctx, status, offset, limit)
+	})
+}
+
+func (r *cachedTaskRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
+	return readThrough(ctx, r, "count_by_status", r.listKey(ctx, "counts", "", 0, 0), func(ctx context.Context) (map[string]int, error) {
+		return r.TaskRepository.CountByStatus(ctx)
+	})
+}
+
+// Move invalidates every task, as re-ranking the column changes the ranks
+// of the other tasks in it.
+func (r *cachedTaskRepository) Move(ctx context.Context, id string, move models.TaskMove, wipLimit *int) (*models.Task, error) {
+	task, err := r.TaskRepository.Move(ctx, id, move, wipLimit)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx)
+	return task, nil
+}
+
+// MoveAndPatch invalidates every task, like Move.
+func (r *cachedTaskRepository) MoveAndPatch(ctx context.Context, id string, move models.TaskMove, wipLimit *int,
+	input models.UpdateTaskInput) (*models.Task, error) {
+	task, err := r.TaskRepository.MoveAndPatch(ctx, id, move, wipLimit, input)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx)
+	return task, nil
+}
+
+func (r *cachedTaskRepository) Patch(ctx context.Context, id string, input models.UpdateTaskInput) (*models.Task, error) {
+	task, err := r.TaskRepository.Patch(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx)
+	return task, nil
+}
+
+// readThrough serves key from the cache, or loads it once for all concurrent
+// callers and stores the result. Each caller decodes its own copy so that
+// returned tasks are never shared between requests.
+func readThrough[T any](ctx context.Context, r *cachedTaskRepository, operation, key string, load func(context.Context) (T, error)) (T, error) {
+	var value T
+
+	data, found, err := r.cache.Get(ctx, key)
+	if err != nil {
+		r.warn(ctx, "cache read failed", err)
+	}
+	if found && json.Unmarshal(data, &value) == nil {
+		r.metrics.Hit(operation)
+		return value, nil
+	}
+	r.metrics.Miss(operation)
+
+	shared, err, _ := r.group.Do(key, func() (interface{}, error) {
+		// Detach from the first caller's cancellation so it can't fail the others.
+		loaded, err := load(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode cache entry: %w", err)
+		}
+		if err := r.cache.Set(ctx, key, encoded, r.ttl); err != nil {
+			r.warn(ctx, "cache write failed", err)
+		}
+		return encoded, nil
+	})
+	if err != nil {
+		return value, err
+	}
+
+	if err := json.Unmarshal(shared.([]byte), &value); err != nil {
+		return value, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+	return value, nil
+}
+
+func (r *cachedTaskRepository) invalidate(ctx context.Context) {
+	if _, err := r.cache.Incr(ctx, generationKey); err != nil {
+		r.warn(ctx, "cache invalidation failed", err)
+	}
+}
+
+func (r *cachedTaskRepository) listKey(ctx context.Context, kind, arg string, offset, limit int) string {
+	return r.key(ctx, "list", kind, arg, strconv.Itoa(offset), strconv.Itoa(limit))
+}
+
+// key returns the key of a cache entry under the current generation.
+func (r *cachedTaskRepository) key(ctx context.Context, parts ...string) string {
+	data, found, err := r.cache.Get(ctx, generationKey)
+	if err != nil {
+		r.warn(ctx, "cache read failed", err)
+	}
+	generation := string(data)
+	if !found {
+		generation = r.resetGeneration(ctx)
+	}
+	return "tasks:" + generation + ":" + strings.Join(parts, ":")
+}
+
+// resetGeneration starts a new generation when the counter is missing, e.g.
+// after Redis evicted it. Seeding from the clock rather than from zero keeps
+// entries cached under earlier generations from being served again.
+func (r *cachedTaskRepository) resetGeneration(ctx context.Context) string {
+	generation := strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := r.cache.Set(ctx, generationKey, []byte(generation), 0); err != nil {
+		r.warn(ctx, "cache write failed", err)
+	}
+	return generation
+}
+
+func (r *cachedTaskRepository) warn(ctx context.Context, msg string, err error) {
+	logger.FromContext(ctx).WarnContext(ctx, msg, slog.Any("error", err))
+}
@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/sampathreddy22/task-management-api/internal/cache"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+func newCachedRepo(t *testing.T, c cache.Cache) (TaskRepository, TaskRepository) {
+	t.Helper()
+	metrics, err := cache.NewMetrics(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("metrics: %v", err)
+	}
+	inner := NewMemoryTaskRepository()
+	return NewCachedTaskRepository(inner, c, time.Minute, metrics), inner
+}
+
+func newRedisCache(t *testing.T) (*cache.Redis, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return cache.NewRedis(client), mr
+}
+
+func newTask(title string) *models.Task {
+	return &models.Task{ID: uuid.New(), Title: title, Status: "todo", Priority: 3}
+}
+
+func TestCachedTaskRepositoryServesAndInvalidates(t *testing.T) {
+	c, _ := newRedisCache(t)
+	repo, inner := newCachedRepo(t, c)
+	ctx := context.Background()
+
+	task := newTask("first")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got, _ := repo.List(ctx, 0, 10); len(got) != 1 {
+		t.Fatalf("List = %d tasks, want 1", len(got))
+	}
+
+	// Writes that bypass the cache are not seen until the entry is invalidated.
+	inner.Create(ctx, newTask("hidden"))
+	if got, _ := repo.List(ctx, 0, 10); len(got) != 1 {
+		t.Fatalf("List after bypassing write = %d tasks, want the cached 1", len(got))
+	}
+
+	task.Title = "renamed"
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got, _ := repo.List(ctx, 0, 10); len(got) != 2 {
+		t.Fatalf("List after update = %d tasks, want 2", len(got))
+	}
+	got, err := repo.GetByID(ctx, task.ID.String())
+	if err != nil || got.Title != "renamed" {
+		t.Fatalf("GetByID = %+v, %v; want the renamed task", got, err)
+	}
+}
+
+func TestCachedTaskRepositorySurvivesGenerationEviction(t *testing.T) {
+	redisCache, mr := newRedisCache(t)
+	caches := map[string]struct {
+		cache cache.Cache
+		evict func()
+	}{
+		"redis": {redisCache, func() { mr.Del("cache:" + generationKey) }},
+		// The LRU only has room for a couple of lists; the generation must
+		// outlive them.
+		"lru": {cache.NewLRU(2), func() {}},
+	}
+
+	for name, tc := range caches {
+		t.Run(name, func(t *testing.T) {
+			repo, _ := newCachedRepo(t, tc.cache)
+			ctx := context.Background()
+
+			// Cache an empty list under the initial generation.
+			if got, _ := repo.List(ctx, 0, 10); len(got) != 0 {
+				t.Fatalf("List = %d tasks, want 0", len(got))
+			}
+			if err := repo.Create(ctx, newTask("first")); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			for offset := range 3 {
+				repo.List(ctx, offset+1, 10)
+			}
+			tc.evict()
+
+			if got, _ := repo.List(ctx, 0, 10); len(got) != 1 {
+				t.Fatalf("List after eviction = %d tasks, want 1", len(got))
+			}
+		})
+	}
+}
+
+// pausedTasks holds up the next GetByID after it has read the task, until
+// release is closed.
+type pausedTasks struct {
+	TaskRepository
+	loaded, release chan struct{}
+}
+
+func (r *pausedTasks) GetByID(ctx context.Context, id string) (*models.Task, error) {
+	task, err := r.TaskRepository.GetByID(ctx, id)
+	if r.loaded != nil {
+		close(r.loaded)
+		r.loaded = nil
+		<-r.release
+	}
+	return task, err
+}
+
+func TestCachedTaskRepositoryDropsLoadsOverlappingWrites(t *testing.T) {
+	for name, c := range map[string]func() cache.Cache{
+		"redis": func() cache.Cache { c, _ := newRedisCache(t); return c },
+		"lru":   func() cache.Cache { return cache.NewLRU(100) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			metrics, err := cache.NewMetrics(prometheus.NewRegistry())
+			if err != nil {
+				t.Fatal(err)
+			}
+			inner := &pausedTasks{TaskRepository: NewMemoryTaskRepository()}
+			repo := NewCachedTaskRepository(inner, c(), time.Minute, metrics)
+			ctx := context.Background()
+			task := newTask("old")
+			if err := repo.Create(ctx, task); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+
+			// A read loads the old row, then the write lands before the
+			// read stores it.
+			inner.loaded, inner.release = make(chan struct{}), make(chan struct{})
+			loaded := inner.loaded
+			read := make(chan *models.Task)
+			go func() {
+				got, _ := repo.GetByID(ctx, task.ID.String())
+				read <- got
+			}()
+			<-loaded
+			task.Title = "new"
+			if err := repo.Update(ctx, task); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			close(inner.release)
+			if got := <-read; got.Title != "old" {
+				t.Fatalf("the overlapping read got %q, want the old title", got.Title)
+			}
+
+			got, err := repo.GetByID(ctx, task.ID.String())
+			if err != nil || got.Title != "new" {
+				t.Fatalf("GetByID after the write = %+v, %v; want the new title", got, err)
+			}
+		})
+	}
+}
+
+func TestCachedTaskRepositoryMoveInvalidatesRerankedTasks(t *testing.T) {
+	repo, inner := newCachedRepo(t, cache.NewLRU(100))
+	ctx := context.Background()
+
+	// Two tasks with the same rank leave no room between them, so moving a
+	// task there re-ranks the column.
+	a, b, moved := newTask("a"), newTask("b"), newTask("moved")
+	if a.ID.String() > b.ID.String() {
+		a, b = b, a
+	}
+	a.Rank, b.Rank, moved.Status = "V", "V", "done"
+	for _, task := range []*models.Task{a, b, moved} {
+		if err := inner.Create(ctx, task); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if _, err := repo.GetByID(ctx, task.ID.String()); err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+	}
+
+	if _, err := repo.Move(ctx, moved.ID.String(), models.TaskMove{Status: "todo", AfterID: &a.ID, BeforeID: &b.ID}, nil); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	for _, task := range []*models.Task{a, b} {
+		want, _ := inner.GetByID(ctx, task.ID.String())
+		got, err := repo.GetByID(ctx, task.ID.String())
+		if err != nil || got.Rank != want.Rank || got.Rank == "V" {
+			t.Errorf("task %s has rank %q in the cache, want the re-ranked %q", task.Title, got.Rank, want.Rank)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	BaseRepository[models.Comment]
+	// GetByTaskIDs returns the comments on the given tasks, oldest first.
+	GetByTaskIDs(ctx context.Context, taskIDs []string) ([]models.Comment, error)
+}
+
+type commentRepository struct {
+	*baseRepository[models.Comment]
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{
+		baseRepository: NewBaseRepository[models.Comment](db).(*baseRepository[models.Comment]),
+		db:             db,
+	}
+}
+
+func (r *commentRepository) GetByTaskIDs(ctx context.Context, taskIDs []string) ([]models.Comment, error) {
+	comments := []models.Comment{}
+	if len(taskIDs) == 0 {
+		return comments, nil
+	}
+	if err := r.db.WithContext(ctx).Where("task_id IN ?", taskIDs).Order("created_at, id").Find(&comments).Error; err != nil {
+		return nil, apperrors.FromDB(err, "comment")
+	}
+	return comments, nil
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type InboundEmailRepository interface {
+	BaseRepository[models.InboundEmail]
+	// GetByMessageIDs returns the recorded emails with the given Message-IDs,
+	// oldest first.
+	GetByMessageIDs(ctx context.Context, messageIDs []string) ([]models.InboundEmail, error)
+	// GetSender returns the user with the email address, ignoring case.
+	GetSender(ctx context.Context, address string) (*models.User, error)
+}
+
+type inboundEmailRepository struct {
+	*baseRepository[models.InboundEmail]
+	db *gorm.DB
+}
+
+func NewInboundEmailRepository(db *gorm.DB) InboundEmailRepository {
+	return &inboundEmailRepository{
+		baseRepository: NewBaseRepository[models.InboundEmail](db).(*baseRepository[models.InboundEmail]),
+		db:             db,
+	}
+}
+
+func (r *inboundEmailRepository) GetByMessageIDs(ctx context.Context, messageIDs []string) ([]models.InboundEmail, error) {
+	emails := []models.InboundEmail{}
+	if len(messageIDs) == 0 {
+		return emails, nil
+	}
+	if err := r.db.WithContext(ctx).Where("message_id IN ?", messageIDs).
+		Order("received_at, id").Find(&emails).Error; err != nil {
+		return nil, apperrors.FromDB(err, "inbound_email")
+	}
+	return emails, nil
+}
+
+func (r *inboundEmailRepository) GetSender(ctx context.Context, address string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, "LOWER(email) = LOWER(?)", address).Error; err != nil {
+		return nil, apperrors.FromDB(err, "user")
+	}
+	return &user, nil
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+type memoryBoardRepository struct {
+	*memoryRepository[models.BoardColumn]
+}
+
+// NewMemoryBoardRepository returns an in-memory BoardRepository for tests,
+// with a column for every task status as the migrations create.
+func NewMemoryBoardRepository() BoardRepository {
+	r := &memoryBoardRepository{
+		memoryRepository: newMemoryRepository(func(c *models.BoardColumn) string { return c.Status }),
+	}
+	for _, status := range models.TaskStatuses {
+		r.items[status] = models.BoardColumn{Status: status, UpdatedAt: time.Now()}
+	}
+	return r
+}
+
+func (r *memoryBoardRepository) GetColumns(ctx context.Context) ([]models.BoardColumn, error) {
+	return r.List(ctx, 0, -1)
+}
+
+func (r *memoryBoardRepository) GetColumn(ctx context.Context, status string) (*models.BoardColumn, error) {
+	return r.GetByID(ctx, status)
+}
+
+func (r *memoryBoardRepository) UpdateColumn(ctx context.Context, column *models.BoardColumn) error {
+	if _, err := r.GetByID(ctx, column.Status); err != nil {
+		return apperrors.NotFound("board_column")
+	}
+	column.UpdatedAt = time.Now()
+	return r.Update(ctx, column)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+type memoryCommentRepository struct {
+	*memoryRepository[models.Comment]
+}
+
+// NewMemoryCommentRepository returns an in-memory CommentRepository for tests.
+func NewMemoryCommentRepository() CommentRepository {
+	return &memoryCommentRepository{
+		memoryRepository: newMemoryRepository(func(c *models.Comment) string { return c.ID.String() }),
+	}
+}
+
+func (r *memoryCommentRepository) GetByTaskIDs(ctx context.Context, taskIDs []string) ([]models.Comment, error) {
+	comments := r.filter(func(c *models.Comment) bool { return slices.Contains(taskIDs, c.TaskID.String()) }, 0, -1)
+	slices.SortStableFunc(comments, func(a, b models.Comment) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return comments, nil
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type memoryInboundEmailRepository struct {
+	*memoryRepository[models.InboundEmail]
+	// users are the users messages can come from.
+	users []models.User
+}
+
+// NewMemoryInboundEmailRepository returns an in-memory
+// InboundEmailRepository for tests. Senders are looked up among users.
+func NewMemoryInboundEmailRepository(users ...models.User) InboundEmailRepository {
+	return &memoryInboundEmailRepository{
+		memoryRepository: newMemoryRepository(func(e *models.InboundEmail) string { return e.ID.String() }),
+		users:            clone(users),
+	}
+}
+
+func (r *memoryInboundEmailRepository) Create(ctx context.Context, email *models.InboundEmail) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.items {
+		if existing.ID == email.ID || existing.MessageID == email.MessageID {
+			return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+		}
+	}
+	r.items[email.ID.String()] = clone(*email)
+	return nil
+}
+
+func (r *memoryInboundEmailRepository) GetByMessageIDs(ctx context.Context, messageIDs []string) ([]models.InboundEmail, error) {
+	emails := r.filter(func(e *models.InboundEmail) bool { return slices.Contains(messageIDs, e.MessageID) }, 0, -1)
+	slices.SortStableFunc(emails, func(a, b models.InboundEmail) int { return a.ReceivedAt.Compare(b.ReceivedAt) })
+	return emails, nil
+}
+
+func (r *memoryInboundEmailRepository) GetSender(ctx context.Context, address string) (*models.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, address) {
+			user = clone(user)
+			return &user, nil
+		}
+	}
+	return nil, apperrors.FromDB(gorm.ErrRecordNotFound, "user")
+}
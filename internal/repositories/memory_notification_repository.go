@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type memoryNotificationRepository struct {
+	*memoryRepository[models.Notification]
+	// preferences are keyed by user, type and channel. They are guarded by
+	// mu.
+	preferences map[[3]string]models.NotificationPreference
+	// users are the users mentions can name.
+	users []models.User
+}
+
+// NewMemoryNotificationRepository returns an in-memory
+// NotificationRepository for tests. Mentions are looked up among users.
+func NewMemoryNotificationRepository(users ...models.User) NotificationRepository {
+	return &memoryNotificationRepository{
+		memoryRepository: newMemoryRepository(func(n *models.Notification) string { return n.ID.String() }),
+		preferences:      make(map[[3]string]models.NotificationPreference),
+		users:            clone(users),
+	}
+}
+
+func (r *memoryNotificationRepository) CreateOnce(ctx context.Context, notification *models.Notification) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[notification.ID.String()]; ok {
+		return false, apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	for _, existing := range r.items {
+		if notification.DedupKey != nil && existing.DedupKey != nil && *existing.DedupKey == *notification.DedupKey {
+			return false, nil
+		}
+	}
+	r.items[notification.ID.String()] = clone(*notification)
+	return true, nil
+}
+
+func (r *memoryNotificationRepository) GetByUserID(ctx context.Context, userID string, unread bool, offset, limit int) ([]models.Notification, error) {
+	notifications := r.filter(func(n *models.Notification) bool {
+		return n.UserID.String() == userID && (!unread || n.ReadAt == nil)
+	}, 0, -1)
+	slices.SortStableFunc(notifications, func(a, b models.Notification) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	if offset >= len(notifications) {
+		return []models.Notification{}, nil
+	}
+	return notifications[offset:min(offset+limit, len(notifications))], nil
+}
+
+func (r *memoryNotificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	unread := r.filter(func(n *models.Notification) bool { return n.UserID.String() == userID && n.ReadAt == nil }, 0, -1)
+	return len(unread), nil
+}
+
+func (r *memoryNotificationRepository) MarkAllRead(ctx context.Context, userID string, at time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	marked := 0
+	for id, n := range r.items {
+		if n.UserID.String() == userID && n.ReadAt == nil {
+			n.ReadAt = &at
+			r.items[id] = n
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (r *memoryNotificationRepository) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	preferences := []models.NotificationPreference{}
+	for _, p := range r.preferences {
+		if p.UserID.String() == userID {
+			preferences = append(preferences, p)
+		}
+	}
+	slices.SortFunc(preferences, func(a, b models.NotificationPreference) int {
+		return strings.Compare(a.Type+" "+a.Channel, b.Type+" "+b.Channel)
+	})
+	return preferences, nil
+}
+
+func (r *memoryNotificationRepository) SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range preferences {
+		r.preferences[[3]string{p.UserID.String(), p.Type, p.Channel}] = p
+	}
+	return nil
+}
+
+func (r *memoryNotificationRepository) MentionedUsers(ctx context.Context, names []string) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	for _, name := range names {
+		var found []uuid.UUID
+		for _, user := range r.users {
+			email := strings.ToLower(user.Email)
+			if email == name || !strings.Contains(name, "@") && strings.HasPrefix(email, name+"@") {
+				found = append(found, user.ID)
+			}
+		}
+		if len(found) == 1 {
+			ids = append(ids, found[0])
+		}
+	}
+	return ids, nil
+}
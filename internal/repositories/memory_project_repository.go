@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type memoryProjectRepository struct {
+	*memoryRepository[models.Project]
+	// members maps project IDs to their members by user ID. It is guarded
+	// by mu.
+	members map[string]map[string]models.ProjectMember
+}
+
+// NewMemoryProjectRepository returns an in-memory ProjectRepository for
+// tests.
+func NewMemoryProjectRepository() ProjectRepository {
+	return &memoryProjectRepository{
+		memoryRepository: newMemoryRepository(func(p *models.Project) string { return p.ID.String() }),
+		members:          make(map[string]map[string]models.ProjectMember),
+	}
+}
+
+// Create and Update keep names unique, like the unique index of the
+// projects table.
+func (r *memoryProjectRepository) Create(ctx context.Context, project *models.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := project.ID.String()
+	if _, ok := r.items[id]; ok || r.nameTaken(project) {
+		return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	r.items[id] = clone(*project)
+	r.members[id] = map[string]models.ProjectMember{
+		project.OwnerID.String(): {ProjectID: project.ID, UserID: project.OwnerID, CreatedAt: project.CreatedAt},
+	}
+	return nil
+}
+
+func (r *memoryProjectRepository) Update(ctx context.Context, project *models.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(project) {
+		return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	r.items[project.ID.String()] = clone(*project)
+	return nil
+}
+
+func (r *memoryProjectRepository) nameTaken(project *models.Project) bool {
+	for _, existing := range r.items {
+		if existing.ID != project.ID && existing.Name == project.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete also drops the project's members, like the cascading foreign key
+// of the project_members table.
+func (r *memoryProjectRepository) Delete(ctx context.Context, id string) error {
+	if err := r.memoryRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.members, id)
+	return nil
+}
+
+func (r *memoryProjectRepository) AddMember(ctx context.Context, projectID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members, ok := r.members[projectID]
+	if !ok {
+		return apperrors.FromDB(gorm.ErrForeignKeyViolated, "project_member")
+	}
+	if _, ok := members[userID]; !ok {
+		members[userID] = models.ProjectMember{
+			ProjectID: uuid.MustParse(projectID), UserID: uuid.MustParse(userID), CreatedAt: time.Now(),
+		}
+	}
+	return nil
+}
+
+func (r *memoryProjectRepository) RemoveMember(ctx context.Context, projectID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.members[projectID][userID]; !ok {
+		return apperrors.NotFound("project_member")
+	}
+	delete(r.members[projectID], userID)
+	return nil
+}
+
+func (r *memoryProjectRepository) GetMembers(ctx context.Context, projectID string) ([]models.ProjectMember, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := []models.ProjectMember{}
+	for _, member := range r.members[projectID] {
+		members = append(members, member)
+	}
+	slices.SortFunc(members, func(a, b models.ProjectMember) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID.String(), b.UserID.String())
+	})
+	return members, nil
+}
+
+func (r *memoryProjectRepository) GetMemberProjects(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := []uuid.UUID{}
+	for _, members := range r.members {
+		if member, ok := members[userID]; ok {
+			ids = append(ids, member.ProjectID)
+		}
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	return ids, nil
+}
+
+func (r *memoryProjectRepository) GetByMember(ctx context.Context, userID string, offset, limit int) ([]models.Project, error) {
+	ids, err := r.GetMemberProjects(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.filter(func(p *models.Project) bool { return slices.Contains(ids, p.ID) }, offset, limit), nil
+}
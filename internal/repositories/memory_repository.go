@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"gorm.io/gorm"
+)
+
+// memoryRepository is an in-memory BaseRepository with the same error and
+// pagination semantics as the GORM implementation. It is meant for tests
+// and local experiments, not production use. Entities are deep copied on
+// the way in and out, so callers never share memory with the stored copy,
+// just as with rows read from a database.
+type memoryRepository[T any] struct {
+	mu       sync.RWMutex
+	items    map[string]T
+	idOf     func(*T) string
+	resource string
+}
+
+// NewMemoryRepository returns an in-memory repository that identifies
+// entities by the key returned from idOf.
+func NewMemoryRepository[T any](idOf func(*T) string) BaseRepository[T] {
+	return newMemoryRepository(idOf)
+}
+
+func newMemoryRepository[T any](idOf func(*T) string) *memoryRepository[T] {
+	return &memoryRepository[T]{
+		items:    make(map[string]T),
+		idOf:     idOf,
+		resource: resourceName[T](),
+	}
+}
+
+func (r *memoryRepository[T]) Create(ctx context.Context, entity *T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.idOf(entity)
+	if _, exists := r.items[id]; exists {
+		return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	r.items[id] = clone(*entity)
+	return nil
+}
+
+func (r *memoryRepository[T]) GetByID(ctx context.Context, id string) (*T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entity, ok := r.items[id]
+	if !ok {
+		return nil, apperrors.FromDB(gorm.ErrRecordNotFound, r.resource)
+	}
+	entity = clone(entity)
+	return &entity, nil
+}
+
+func (r *memoryRepository[T]) GetByIDs(ctx context.Context, ids []string) ([]T, error) {
+	return r.filter(func(entity *T) bool { return slices.Contains(ids, r.idOf(entity)) }, 0, -1), nil
+}
+
+// Update inserts the entity when it doesn't exist yet, like gorm's Save.
+func (r *memoryRepository[T]) Update(ctx context.Context, entity *T) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items[r.idOf(entity)] = clone(*entity)
+	return nil
+}
+
+func (r *memoryRepository[T]) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[id]; !ok {
+		return apperrors.NotFound(r.resource)
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *memoryRepository[T]) List(ctx context.Context, offset, limit int) ([]T, error) {
+	return r.filter(func(*T) bool { return true }, offset, limit), nil
+}
+
+// filter returns a page of the entities matching keep, ordered by ID like
+// the GORM queries. A negative limit means no limit, as with gorm.
+func (r *memoryRepository[T]) filter(keep func(*T) bool, offset, limit int) []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.items))
+	for id := range r.items {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	result := []T{}
+	for _, id := range ids {
+		if limit >= 0 && len(result) >= limit {
+			break
+		}
+		entity := r.items[id]
+		if !keep(&entity) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		result = append(result, clone(entity))
+	}
+	return result
+}
+
+// clone returns a deep copy of v: pointers, slices, maps and interfaces in
+// exported fields are copied rather than shared. Unexported fields, such as
+// the location of a time.Time, are copied shallowly.
+func clone[T any](v T) T {
+	return deepCopy(reflect.ValueOf(&v).Elem()).Interface().(T)
+}
+
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := range v.NumField() {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+func TestMemoryRepositoryDoesNotAliasCallerMemory(t *testing.T) {
+	repo := NewMemoryTaskRepository()
+	ctx := context.Background()
+
+	due := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	task := &models.Task{
+		ID:          uuid.New(),
+		Title:       "write tests",
+		Status:      models.TaskStatusTodo,
+		DueDate:     &due,
+		UserID:      &userID,
+		Attachments: []models.Attachment{{ID: uuid.New(), FileName: "spec.pdf"}},
+	}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Changing the caller's values after the write must not reach the store.
+	due = due.AddDate(1, 0, 0)
+	userID = uuid.New()
+	task.Attachments[0].FileName = "changed.pdf"
+
+	stored, err := repo.GetByID(ctx, task.ID.String())
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if stored.DueDate.Year() != 2030 || *stored.UserID == userID || stored.Attachments[0].FileName != "spec.pdf" {
+		t.Fatalf("stored task changed with the caller's copy: %+v", stored)
+	}
+
+	// Neither must changes to a returned task.
+	*stored.DueDate = stored.DueDate.AddDate(5, 0, 0)
+	stored.Attachments[0].FileName = "returned.pdf"
+	listed, err := repo.List(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if listed[0].DueDate.Year() != 2030 || listed[0].Attachments[0].FileName != "spec.pdf" {
+		t.Fatalf("stored task changed with a returned copy: %+v", listed[0])
+	}
+}
+
+func TestCloneCopiesNestedValues(t *testing.T) {
+	type inner struct{ Values []int }
+	type outer struct {
+		Inner  *inner
+		ByName map[string]*inner
+		Any    interface{}
+	}
+
+	original := outer{
+		Inner:  &inner{Values: []int{1}},
+		ByName: map[string]*inner{"a": {Values: []int{2}}},
+		Any:    &inner{Values: []int{3}},
+	}
+	copied := clone(original)
+	copied.Inner.Values[0] = 10
+	copied.ByName["a"].Values[0] = 20
+	copied.Any.(*inner).Values[0] = 30
+
+	if original.Inner.Values[0] != 1 || original.ByName["a"].Values[0] != 2 || original.Any.(*inner).Values[0] != 3 {
+		t.Fatalf("clone shares memory with the original: %+v", original)
+	}
+}
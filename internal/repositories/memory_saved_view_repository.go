@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type memorySavedViewRepository struct {
+	*memoryRepository[models.SavedView]
+	// defaults maps user IDs to the IDs of their default views. It is
+	// guarded by mu.
+	defaults map[string]string
+}
+
+// NewMemorySavedViewRepository returns an in-memory SavedViewRepository for
+// tests.
+func NewMemorySavedViewRepository() SavedViewRepository {
+	return &memorySavedViewRepository{
+		memoryRepository: newMemoryRepository(func(v *models.SavedView) string { return v.ID.String() }),
+		defaults:         make(map[string]string),
+	}
+}
+
+// Create and Update keep view names unique per owner, like the unique index
+// of the saved_views table.
+func (r *memorySavedViewRepository) Create(ctx context.Context, view *models.SavedView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[view.ID.String()]; ok || r.nameTaken(view) {
+		return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	r.items[view.ID.String()] = clone(*view)
+	return nil
+}
+
+func (r *memorySavedViewRepository) Update(ctx context.Context, view *models.SavedView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(view) {
+		return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	r.items[view.ID.String()] = clone(*view)
+	return nil
+}
+
+func (r *memorySavedViewRepository) nameTaken(view *models.SavedView) bool {
+	for _, existing := range r.items {
+		if existing.ID != view.ID && existing.UserID == view.UserID && existing.Name == view.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete also drops the view as a default, like the cascading foreign key
+// of the saved_view_defaults table.
+func (r *memorySavedViewRepository) Delete(ctx context.Context, id string) error {
+	if err := r.memoryRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for userID, viewID := range r.defaults {
+		if viewID == id {
+			delete(r.defaults, userID)
+		}
+	}
+	return nil
+}
+
+func (r *memorySavedViewRepository) GetVisible(ctx context.Context, userID string, projects []uuid.UUID) ([]models.SavedView, error) {
+	viewer := uuid.MustParse(userID)
+	views := r.filter(func(v *models.SavedView) bool { return v.VisibleTo(viewer, projects) }, 0, -1)
+	slices.SortStableFunc(views, func(a, b models.SavedView) int { return strings.Compare(a.Name, b.Name) })
+	return views, nil
+}
+
+func (r *memorySavedViewRepository) GetDefault(ctx context.Context, userID string) (*models.SavedView, error) {
+	r.mu.RLock()
+	viewID, ok := r.defaults[userID]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, apperrors.NotFound("default_view")
+	}
+	return r.GetByID(ctx, viewID)
+}
+
+func (r *memorySavedViewRepository) SetDefault(ctx context.Context, userID, viewID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[viewID]; !ok {
+		return apperrors.FromDB(gorm.ErrForeignKeyViolated, "default_view")
+	}
+	r.defaults[userID] = viewID
+	return nil
+}
+
+func (r *memorySavedViewRepository) ClearDefault(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.defaults, userID)
+	return nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type memorySessionRepository struct {
+	*memoryRepository[models.Session]
+	// users are keyed by ID. They are guarded by mu.
+	users map[uuid.UUID]models.User
+}
+
+// NewMemorySessionRepository returns an in-memory SessionRepository for
+// tests, starting with the given users.
+func NewMemorySessionRepository(users ...models.User) SessionRepository {
+	r := &memorySessionRepository{
+		memoryRepository: newMemoryRepository(func(s *models.Session) string { return s.ID.String() }),
+		users:            make(map[uuid.UUID]models.User, len(users)),
+	}
+	for _, user := range users {
+		r.users[user.ID] = clone(user)
+	}
+	return r
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *models.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.items {
+		if existing.ID == session.ID || existing.TokenHash == session.TokenHash {
+			return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+		}
+	}
+	if _, ok := r.users[session.UserID]; !ok {
+		return apperrors.FromDB(gorm.ErrForeignKeyViolated, r.resource)
+	}
+	r.items[session.ID.String()] = clone(*session)
+	return nil
+}
+
+func (r *memorySessionRepository) CreateUser(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.ID == user.ID || strings.EqualFold(existing.Email, user.Email) {
+			return apperrors.FromDB(gorm.ErrDuplicatedKey, "user")
+		}
+	}
+	r.users[user.ID] = clone(*user)
+	return nil
+}
+
+func (r *memorySessionRepository) GetUser(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, apperrors.FromDB(gorm.ErrRecordNotFound, "user")
+	}
+	user = clone(user)
+	return &user, nil
+}
+
+func (r *memorySessionRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			user = clone(user)
+			return &user, nil
+		}
+	}
+	return nil, apperrors.FromDB(gorm.ErrRecordNotFound, "user")
+}
+
+func (r *memorySessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error) {
+	sessions := r.filter(func(s *models.Session) bool { return s.TokenHash == tokenHash }, 0, 1)
+	if len(sessions) == 0 {
+		return nil, apperrors.FromDB(gorm.ErrRecordNotFound, r.resource)
+	}
+	return &sessions[0], nil
+}
+
+func (r *memorySessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.items[id.String()]
+	if !ok || session.TokenHash != oldHash {
+		return apperrors.NotFound(r.resource)
+	}
+	session.TokenHash = newHash
+	session.ExpiresAt = expiresAt
+	r.items[id.String()] = session
+	return nil
+}
@@ -0,0 +1,318 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/rank"
+	"gorm.io/gorm"
+)
+
+type memoryTaskRepository struct {
+	*memoryRepository[models.Task]
+	// moveMu serializes moves and creations, standing in for the column
+	// lock.
+	moveMu sync.Mutex
+}
+
+// NewMemoryTaskRepository returns an in-memory TaskRepository for tests.
+func NewMemoryTaskRepository() TaskRepository {
+	return &memoryTaskRepository{
+		memoryRepository: newMemoryRepository(func(t *models.Task) string { return t.ID.String() }),
+	}
+}
+
+func (r *memoryTaskRepository) CreateBatch(ctx context.Context, tasks []models.Task, wipLimits map[string]int) error {
+	r.moveMu.Lock()
+	defer r.moveMu.Unlock()
+
+	err := rankAtBottom(tasks, wipLimits, func(status string) (string, int, error) {
+		column := r.column(status)
+		if len(column) == 0 {
+			return "", 0, nil
+		}
+		return column[len(column)-1].Rank, len(column), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, task := range tasks {
+		id := task.ID.String()
+		if _, exists := r.items[id]; exists || slices.ContainsFunc(tasks[:i], func(t models.Task) bool { return t.ID == task.ID }) {
+			return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+		}
+	}
+	for _, task := range tasks {
+		r.items[task.ID.String()] = clone(task)
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) GetByUserID(ctx context.Context, userID string, offset, limit int) ([]models.Task, error) {
+	return r.filter(func(t *models.Task) bool {
+		return t.UserID != nil && t.UserID.String() == userID
+	}, offset, limit), nil
+}
+
+func (r *memoryTaskRepository) GetByStatus(ctx context.Context, status string, offset, limit int) ([]models.Task, error) {
+	return r.filter(func(t *models.Task) bool { return t.Status == status }, offset, limit), nil
+}
+
+func (r *memoryTaskRepository) GetByPriority(ctx context.Context, priority string, offset, limit int) ([]models.Task, error) {
+	p, err := strconv.Atoi(priority)
+	if err != nil {
+		return []models.Task{}, nil
+	}
+	return r.filter(func(t *models.Task) bool { return t.Priority == p }, offset, limit), nil
+}
+
+func (r *memoryTaskRepository) Search(ctx context.Context, query string, offset, limit int) ([]models.Task, error) {
+	return r.filter(matches(query), offset, limit), nil
+}
+
+func matches(query string) func(*models.Task) bool {
+	query = strings.ToLower(query)
+	return func(t *models.Task) bool {
+		return strings.Contains(strings.ToLower(t.Title), query) ||
+			strings.Contains(strings.ToLower(t.Description), query)
+	}
+}
+
+func (r *memoryTaskRepository) Find(ctx context.Context, query models.TaskListQuery) ([]models.Task, error) {
+	tasks := r.filter(keep(query), 0, -1)
+	slices.SortStableFunc(tasks, compareTasks(query.Sort))
+	offset := query.Offset()
+	if offset >= len(tasks) {
+		return []models.Task{}, nil
+	}
+	return tasks[offset:min(offset+query.Limit, len(tasks))], nil
+}
+
+func (r *memoryTaskRepository) Count(ctx context.Context, query models.TaskListQuery) (int, error) {
+	return len(r.filter(keep(query), 0, -1)), nil
+}
+
+// keep matches the tasks selected by the filter of query.
+func keep(query models.TaskListQuery) func(*models.Task) bool {
+	switch {
+	case query.Status != "":
+		return func(t *models.Task) bool { return t.Status == query.Status }
+	case query.Priority != 0:
+		return func(t *models.Task) bool { return t.Priority == query.Priority }
+	case query.UserID != "":
+		return func(t *models.Task) bool { return t.UserID != nil && t.UserID.String() == query.UserID }
+	case query.Query != "":
+		return matches(query.Query)
+	default:
+		return func(*models.Task) bool { return true }
+	}
+}
+
+// compareTasks orders tasks like the GORM repository's sorts. The input is
+// already ordered by ID, which settles ties in the stable sort.
+func compareTasks(sort string) func(a, b models.Task) int {
+	field, desc := strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+	return func(a, b models.Task) int {
+		var c int
+		switch field {
+		case "title":
+			c = strings.Compare(a.Title, b.Title)
+		case "priority":
+			c = a.Priority - b.Priority
+		case "created_at":
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		case "updated_at":
+			c = a.UpdatedAt.Compare(b.UpdatedAt)
+		case "due_date":
+			switch {
+			case a.DueDate == nil || b.DueDate == nil:
+				// Tasks without a due date come last in both directions.
+				return boolCompare(a.DueDate == nil, b.DueDate == nil)
+			default:
+				c = a.DueDate.Compare(*b.DueDate)
+			}
+		case "id":
+			c = strings.Compare(a.ID.String(), b.ID.String())
+		}
+		if desc {
+			return -c
+		}
+		return c
+	}
+}
+
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// GetAttachments returns the attachments stored with the tasks, since the
+// memory repository keeps no separate attachment table.
+func (r *memoryTaskRepository) GetAttachments(ctx context.Context, taskIDs []string) ([]models.Attachment, error) {
+	attachments := []models.Attachment{}
+	for _, task := range r.filter(func(t *models.Task) bool { return slices.Contains(taskIDs, t.ID.String()) }, 0, -1) {
+		attachments = append(attachments, task.Attachments...)
+	}
+	slices.SortStableFunc(attachments, func(a, b models.Attachment) int { return a.UploadedAt.Compare(b.UploadedAt) })
+	return attachments, nil
+}
+
+func (r *memoryTaskRepository) GetColumn(ctx context.Context, status string, offset, limit int) ([]models.Task, error) {
+	tasks := r.column(status)
+	if offset >= len(tasks) {
+		return []models.Task{}, nil
+	}
+	return tasks[offset:min(offset+limit, len(tasks))], nil
+}
+
+func (r *memoryTaskRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, task := range r.filter(func(*models.Task) bool { return true }, 0, -1) {
+		counts[task.Status]++
+	}
+	return counts, nil
+}
+
+func (r *memoryTaskRepository) GetDueBetween(ctx context.Context, from, to time.Time) ([]models.Task, error) {
+	tasks := r.filter(func(t *models.Task) bool {
+		return t.UserID != nil && t.Status != models.TaskStatusDone && t.DueDate != nil &&
+			!t.DueDate.Before(from) && t.DueDate.Before(to)
+	}, 0, -1)
+	slices.SortStableFunc(tasks, func(a, b models.Task) int { return a.DueDate.Compare(*b.DueDate) })
+	return tasks, nil
+}
+
+func (r *memoryTaskRepository) LastRank(ctx context.Context, status string) (string, error) {
+	tasks := r.column(status)
+	if len(tasks) == 0 {
+		return "", nil
+	}
+	return tasks[len(tasks)-1].Rank, nil
+}
+
+func (r *memoryTaskRepository) Move(ctx context.Context, id string, move models.TaskMove, wipLimit *int) (*models.Task, error) {
+	return r.MoveAndPatch(ctx, id, move, wipLimit, models.UpdateTaskInput{})
+}
+
+func (r *memoryTaskRepository) MoveAndPatch(ctx context.Context, id string, move models.TaskMove, wipLimit *int,
+	input models.UpdateTaskInput) (*models.Task, error) {
+	r.moveMu.Lock()
+	defer r.moveMu.Unlock()
+
+	task, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if wipLimit != nil && task.Status != move.Status && len(r.column(move.Status)) >= *wipLimit {
+		return nil, wipLimitReached(move.Status, *wipLimit)
+	}
+
+	column := &memoryColumn{repo: r, status: move.Status, moved: task.ID}
+	column.load()
+	key, err := placeTask(column, task.ID, move)
+	if err != nil {
+		return nil, err
+	}
+	input.Apply(task)
+	task.Status, task.Rank, task.UpdatedAt = move.Status, key, time.Now()
+	if err := r.Update(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *memoryTaskRepository) Patch(ctx context.Context, id string, input models.UpdateTaskInput) (*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.items[id]
+	if !ok {
+		return nil, apperrors.NotFound(r.resource)
+	}
+	input.Apply(&task)
+	r.items[id] = clone(task)
+	task = clone(task)
+	return &task, nil
+}
+
+// column returns the tasks with the status in board order.
+func (r *memoryTaskRepository) column(status string) []models.Task {
+	tasks := r.filter(func(t *models.Task) bool { return t.Status == status }, 0, -1)
+	slices.SortStableFunc(tasks, func(a, b models.Task) int {
+		if c := strings.Compare(a.Rank, b.Rank); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	return tasks
+}
+
+// memoryColumn is a columnCursor over a snapshot of the column, which is
+// safe to take since moves are serialized.
+type memoryColumn struct {
+	repo   *memoryTaskRepository
+	status string
+	moved  uuid.UUID
+	tasks  []models.Task
+}
+
+func (c *memoryColumn) load() {
+	c.tasks = slices.DeleteFunc(c.repo.column(c.status), func(t models.Task) bool { return t.ID == c.moved })
+}
+
+func (c *memoryColumn) at(i int) *models.Task {
+	if i < 0 || i >= len(c.tasks) {
+		return nil
+	}
+	return &c.tasks[i]
+}
+
+func (c *memoryColumn) index(id uuid.UUID) int {
+	return slices.IndexFunc(c.tasks, func(t models.Task) bool { return t.ID == id })
+}
+
+func (c *memoryColumn) get(id uuid.UUID) (*models.Task, error) {
+	i := c.index(id)
+	if i < 0 {
+		return nil, nil
+	}
+	return c.at(i), nil
+}
+
+func (c *memoryColumn) next(t *models.Task) (*models.Task, error) {
+	return c.at(c.index(t.ID) + 1), nil
+}
+
+func (c *memoryColumn) prev(t *models.Task) (*models.Task, error) {
+	return c.at(c.index(t.ID) - 1), nil
+}
+
+func (c *memoryColumn) last() (*models.Task, error) {
+	return c.at(len(c.tasks) - 1), nil
+}
+
+func (c *memoryColumn) rerank() error {
+	for i, key := range rank.Spread(len(c.tasks)) {
+		c.tasks[i].Rank = key
+		if err := c.repo.Update(context.Background(), &c.tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type memoryTaskTemplateRepository struct {
+	*memoryRepository[models.TaskTemplate]
+}
+
+// NewMemoryTaskTemplateRepository returns an in-memory
+// TaskTemplateRepository for tests.
+func NewMemoryTaskTemplateRepository() TaskTemplateRepository {
+	return &memoryTaskTemplateRepository{
+		memoryRepository: newMemoryRepository(func(t *models.TaskTemplate) string { return t.ID.String() }),
+	}
+}
+
+// Create and Update keep names unique, like the unique index of the
+// task_templates table.
+func (r *memoryTaskTemplateRepository) Create(ctx context.Context, template *models.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[template.ID.String()]; ok || r.nameTaken(template) {
+		return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	r.items[template.ID.String()] = clone(*template)
+	return nil
+}
+
+func (r *memoryTaskTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(template) {
+		return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+	}
+	r.items[template.ID.String()] = clone(*template)
+	return nil
+}
+
+func (r *memoryTaskTemplateRepository) nameTaken(template *models.TaskTemplate) bool {
+	for _, existing := range r.items {
+		if existing.ID != template.ID && existing.Name == template.Name {
+			return true
+		}
+	}
+	return false
+}
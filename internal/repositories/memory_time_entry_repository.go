@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type memoryTimeEntryRepository struct {
+	*memoryRepository[models.TimeEntry]
+}
+
+// NewMemoryTimeEntryRepository returns an in-memory TimeEntryRepository for
+// tests.
+func NewMemoryTimeEntryRepository() TimeEntryRepository {
+	return &memoryTimeEntryRepository{
+		memoryRepository: newMemoryRepository(func(e *models.TimeEntry) string { return e.ID.String() }),
+	}
+}
+
+// Create enforces one running timer per user, like the unique index of the
+// time_entries table.
+func (r *memoryTimeEntryRepository) Create(ctx context.Context, entry *models.TimeEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, existing := range r.items {
+		if id == entry.ID.String() || entry.Running() && existing.Running() && existing.UserID == entry.UserID {
+			return apperrors.FromDB(gorm.ErrDuplicatedKey, r.resource)
+		}
+	}
+	r.items[entry.ID.String()] = clone(*entry)
+	return nil
+}
+
+func (r *memoryTimeEntryRepository) GetRunning(ctx context.Context, userID string) (*models.TimeEntry, error) {
+	running := r.filter(func(e *models.TimeEntry) bool { return e.Running() && e.UserID.String() == userID }, 0, 1)
+	if len(running) == 0 {
+		return nil, apperrors.NotFound("timer")
+	}
+	return &running[0], nil
+}
+
+func (r *memoryTimeEntryRepository) GetByTaskID(ctx context.Context, taskID string, offset, limit int) ([]models.TimeEntry, error) {
+	entries := r.filter(func(e *models.TimeEntry) bool { return e.TaskID.String() == taskID }, 0, -1)
+	slices.SortStableFunc(entries, func(a, b models.TimeEntry) int { return b.StartedAt.Compare(a.StartedAt) })
+	if offset >= len(entries) {
+		return []models.TimeEntry{}, nil
+	}
+	return entries[offset:min(offset+limit, len(entries))], nil
+}
+
+func (r *memoryTimeEntryRepository) Report(ctx context.Context, query models.TimeReportQuery) ([]models.TimeReportRow, error) {
+	groups := make(map[string]*models.TimeReportRow)
+	for _, entry := range r.filter(func(e *models.TimeEntry) bool {
+		return !e.Running() && !e.StartedAt.Before(query.From) && e.StartedAt.Before(query.To) &&
+			(query.UserID == "" || e.UserID.String() == query.UserID) &&
+			(query.TaskID == "" || e.TaskID.String() == query.TaskID)
+	}, 0, -1) {
+		key := entry.UserID.String()
+		switch query.GroupBy {
+		case models.TimeReportByTask:
+			key = entry.TaskID.String()
+		case models.TimeReportByDay:
+			key = entry.StartedAt.UTC().Format("2006-01-02")
+		}
+		row, ok := groups[key]
+		if !ok {
+			row = &models.TimeReportRow{Key: key}
+			groups[key] = row
+		}
+		row.Entries++
+		row.Seconds += entry.DurationSeconds
+	}
+
+	rows := make([]models.TimeReportRow, 0, len(groups))
+	for _, row := range groups {
+		rows = append(rows, *row)
+	}
+	slices.SortFunc(rows, func(a, b models.TimeReportRow) int { return strings.Compare(a.Key, b.Key) })
+	return rows, nil
+}
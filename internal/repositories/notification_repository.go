@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	BaseRepository[models.Notification]
+	// CreateOnce creates the notification unless one with the same DedupKey
+	// exists, and reports whether it did.
+	CreateOnce(ctx context.Context, notification *models.Notification) (bool, error)
+	// GetByUserID returns a page of the user's notifications, newest first,
+	// or only the unread ones.
+	GetByUserID(ctx context.Context, userID string, unread bool, offset, limit int) ([]models.Notification, error)
+	// CountUnread returns the number of the user's unread notifications.
+	CountUnread(ctx context.Context, userID string) (int, error)
+	// MarkAllRead marks the user's unread notifications read at the given
+	// time and returns how many there were.
+	MarkAllRead(ctx context.Context, userID string, at time.Time) (int, error)
+	// GetPreferences returns the preferences the user has set.
+	GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error)
+	// SavePreferences creates or replaces the preferences.
+	SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error
+	// MentionedUsers returns the IDs of the users named by mentions: email
+	// addresses, or usernames, the part of an address before the "@". A
+	// username shared by several users mentions none of them.
+	MentionedUsers(ctx context.Context, names []string) ([]uuid.UUID, error)
+}
+
+type notificationRepository struct {
+	*baseRepository[models.Notification]
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		baseRepository: NewBaseRepository[models.Notification](db).(*baseRepository[models.Notification]),
+		db:             db,
+	}
+}
+
+func (r *notificationRepository) CreateOnce(ctx context.Context, notification *models.Notification) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	if result.Error != nil {
+		return false, apperrors.FromDB(result.Error, "notification")
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *notificationRepository) GetByUserID(ctx context.Context, userID string, unread bool, offset, limit int) ([]models.Notification, error) {
+	notifications := []models.Notification{}
+	db := r.db.WithContext(ctx).Where("user_id=?", userID)
+	if unread {
+		db = db.Where("read_at IS NULL")
+	}
+	if err := db.Order("created_at DESC, id").Offset(offset).Limit(limit).Find(&notifications).Error; err != nil {
+		return nil, apperrors.FromDB(err, "notification")
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id=? AND read_at IS NULL", userID).Count(&count).Error; err != nil {
+		return 0, apperrors.FromDB(err, "notification")
+	}
+	return int(count), nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID string, at time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id=? AND read_at IS NULL", userID).Update("read_at", at)
+	if result.Error != nil {
+		return 0, apperrors.FromDB(result.Error, "notification")
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (r *notificationRepository) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	preferences := []models.NotificationPreference{}
+	if err := r.db.WithContext(ctx).Where("user_id=?", userID).Order("type, channel").
+		Find(&preferences).Error; err != nil {
+		return nil, apperrors.FromDB(err, "notification_preference")
+	}
+	return preferences, nil
+}
+
+func (r *notificationRepository) SavePreferences(ctx context.Context, preferences []models.NotificationPreference) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&preferences).Error
+	return apperrors.FromDB(err, "notification_preference")
+}
+
+func (r *notificationRepository) MentionedUsers(ctx context.Context, names []string) ([]uuid.UUID, error) {
+	var emails, usernames []string
+	for _, name := range names {
+		if strings.Contains(name, "@") {
+			emails = append(emails, name)
+		} else {
+			usernames = append(usernames, name)
+		}
+	}
+
+	ids := []uuid.UUID{}
+	if len(emails) > 0 {
+		if err := r.db.WithContext(ctx).Model(&models.User{}).Where("LOWER(email) IN ?", emails).
+			Order("id").Pluck("id", &ids).Error; err != nil {
+			return nil, apperrors.FromDB(err, "user")
+		}
+	}
+	for _, username := range usernames {
+		var found []uuid.UUID
+		if err := r.db.WithContext(ctx).Model(&models.User{}).
+			Where(`LOWER(email) LIKE ? ESCAPE '\'`, likeEscaper.Replace(username)+"@%").
+			Limit(2).Pluck("id", &found).Error; err != nil {
+			return nil, apperrors.FromDB(err, "user")
+		}
+		if len(found) == 1 {
+			ids = append(ids, found[0])
+		}
+	}
+	return ids, nil
+}
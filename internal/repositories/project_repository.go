@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProjectRepository stores projects and their members. Names are unique;
+// creating or renaming a project to a taken name is a conflict.
+type ProjectRepository interface {
+	BaseRepository[models.Project]
+	// Create also makes the owner a member of the project.
+	Create(ctx context.Context, project *models.Project) error
+	// AddMember makes the user a member of the project, if they aren't
+	// already.
+	AddMember(ctx context.Context, projectID, userID string) error
+	// RemoveMember ends the user's membership, or returns a not found
+	// error.
+	RemoveMember(ctx context.Context, projectID, userID string) error
+	// GetMembers returns the members of the project, earliest first.
+	GetMembers(ctx context.Context, projectID string) ([]models.ProjectMember, error)
+	// GetMemberProjects returns the IDs of the projects the user is a
+	// member of.
+	GetMemberProjects(ctx context.Context, userID string) ([]uuid.UUID, error)
+	// GetByMember returns a page of the projects the user is a member of,
+	// owners included, ordered by ID.
+	GetByMember(ctx context.Context, userID string, offset, limit int) ([]models.Project, error)
+}
+
+type projectRepository struct {
+	*baseRepository[models.Project]
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{
+		baseRepository: NewBaseRepository[models.Project](db).(*baseRepository[models.Project]),
+		db:             db,
+	}
+}
+
+func (r *projectRepository) Create(ctx context.Context, project *models.Project) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(project).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ProjectMember{ProjectID: project.ID, UserID: project.OwnerID, CreatedAt: project.CreatedAt}).Error
+	})
+	return apperrors.FromDB(err, "project")
+}
+
+func (r *projectRepository) AddMember(ctx context.Context, projectID, userID string) error {
+	row := map[string]interface{}{"project_id": projectID, "user_id": userID, "created_at": time.Now()}
+	err := r.db.WithContext(ctx).Model(&models.ProjectMember{}).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(row).Error
+	return apperrors.FromDB(err, "project_member")
+}
+
+func (r *projectRepository) RemoveMember(ctx context.Context, projectID, userID string) error {
+	result := r.db.WithContext(ctx).Delete(&models.ProjectMember{}, "project_id=? AND user_id=?", projectID, userID)
+	if result.Error != nil {
+		return apperrors.FromDB(result.Error, "project_member")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("project_member")
+	}
+	return nil
+}
+
+func (r *projectRepository) GetMembers(ctx context.Context, projectID string) ([]models.ProjectMember, error) {
+	members := []models.ProjectMember{}
+	if err := r.db.WithContext(ctx).Where("project_id=?", projectID).Order("created_at, user_id").
+		Find(&members).Error; err != nil {
+		return nil, apperrors.FromDB(err, "project_member")
+	}
+	return members, nil
+}
+
+func (r *projectRepository) GetMemberProjects(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	if err := r.db.WithContext(ctx).Model(&models.ProjectMember{}).Where("user_id=?", userID).
+		Order("project_id").Pluck("project_id", &ids).Error; err != nil {
+		return nil, apperrors.FromDB(err, "project_member")
+	}
+	return ids, nil
+}
+
+func (r *projectRepository) GetByMember(ctx context.Context, userID string, offset, limit int) ([]models.Project, error) {
+	projects := []models.Project{}
+	memberships := r.db.Model(&models.ProjectMember{}).Select("project_id").Where("user_id=?", userID)
+	if err := r.db.WithContext(ctx).Where("id IN (?)", memberships).Order("id").Offset(offset).Limit(limit).
+		Find(&projects).Error; err != nil {
+		return nil, apperrors.FromDB(err, "project")
+	}
+	return projects, nil
+}
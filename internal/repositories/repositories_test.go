@@ -0,0 +1,26 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/sampathreddy22/task-management-api/internal/repositories/repotest"
+)
+
+// The postgres subtests are skipped unless repotest.DSNEnv names a
+// database they may empty.
+func TestTaskRepository(t *testing.T) {
+	t.Run("memory", func(t *testing.T) { repotest.TaskRepository(t, repotest.Memory) })
+	t.Run("sqlite", func(t *testing.T) { repotest.TaskRepository(t, repotest.SQLite) })
+	t.Run("postgres", func(t *testing.T) { repotest.TaskRepository(t, repotest.Postgres) })
+}
+
+func TestSQLConstraints(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) { repotest.SQLConstraints(t, repotest.SQLite) })
+	t.Run("postgres", func(t *testing.T) { repotest.SQLConstraints(t, repotest.Postgres) })
+}
+
+func TestViewSharing(t *testing.T) {
+	t.Run("memory", func(t *testing.T) { repotest.ViewSharing(t, repotest.MemorySharing) })
+	t.Run("sqlite", func(t *testing.T) { repotest.ViewSharing(t, repotest.SQLiteSharing) })
+	t.Run("postgres", func(t *testing.T) { repotest.ViewSharing(t, repotest.PostgresSharing) })
+}
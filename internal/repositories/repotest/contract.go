@@ -0,0 +1,595 @@
+package repotest
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+// TaskRepository runs the task repository contract against the harnesses
+// returned by newHarness, one per subtest.
+func TaskRepository(t *testing.T, newHarness func(t *testing.T) TaskHarness) {
+	ctx := context.Background()
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("write contract")
+		task.Description = "shared by every backend"
+		mustCreate(t, h, &task)
+
+		got, err := h.Repo.GetByID(ctx, task.ID.String())
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.ID != task.ID || got.Title != task.Title || got.Description != task.Description ||
+			got.Status != task.Status || got.Priority != task.Priority || !got.CreatedAt.Equal(task.CreatedAt) {
+			t.Fatalf("GetByID = %+v, want %+v", got, task)
+		}
+	})
+
+	t.Run("CreateDuplicateConflicts", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("once")
+		mustCreate(t, h, &task)
+		wantKind(t, h.Repo.Create(ctx, &task), apperrors.KindConflict)
+	})
+
+	t.Run("GetMissingNotFound", func(t *testing.T) {
+		h := newHarness(t)
+		_, err := h.Repo.GetByID(ctx, uuid.NewString())
+		wantKind(t, err, apperrors.KindNotFound)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("before")
+		mustCreate(t, h, &task)
+
+		task.Title = "after"
+		task.Status = models.TaskStatusDone
+		if err := h.Repo.Update(ctx, &task); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, err := h.Repo.GetByID(ctx, task.ID.String())
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.Title != "after" || got.Status != models.TaskStatusDone {
+			t.Fatalf("GetByID after Update = %+v", got)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("doomed")
+		mustCreate(t, h, &task)
+
+		if err := h.Repo.Delete(ctx, task.ID.String()); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		_, err := h.Repo.GetByID(ctx, task.ID.String())
+		wantKind(t, err, apperrors.KindNotFound)
+		wantKind(t, h.Repo.Delete(ctx, task.ID.String()), apperrors.KindNotFound)
+	})
+
+	t.Run("GetByIDsSkipsUnknown", func(t *testing.T) {
+		h := newHarness(t)
+		a, b := newTask("a"), newTask("b")
+		mustCreate(t, h, &a)
+		mustCreate(t, h, &b)
+
+		tasks, err := h.Repo.GetByIDs(ctx, []string{b.ID.String(), uuid.NewString(), a.ID.String()})
+		if err != nil {
+			t.Fatalf("GetByIDs: %v", err)
+		}
+		wantIDs(t, tasks, sorted(a.ID.String(), b.ID.String()))
+
+		tasks, err = h.Repo.GetByIDs(ctx, nil)
+		if err != nil || len(tasks) != 0 {
+			t.Fatalf("GetByIDs(nil) = %v, %v, want no tasks", tasks, err)
+		}
+	})
+
+	t.Run("ListPaginatesByID", func(t *testing.T) {
+		h := newHarness(t)
+		var ids []string
+		for i := 0; i < 5; i++ {
+			task := newTask("task")
+			mustCreate(t, h, &task)
+			ids = append(ids, task.ID.String())
+		}
+		slices.Sort(ids)
+
+		first, err := h.Repo.List(ctx, 0, 2)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		rest, err := h.Repo.List(ctx, 2, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		wantIDs(t, first, ids[:2])
+		wantIDs(t, rest, ids[2:])
+
+		empty, err := h.Repo.List(ctx, 10, 10)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if empty == nil || len(empty) != 0 {
+			t.Fatalf("List past the end = %#v, want empty slice", empty)
+		}
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		h := newHarness(t)
+		owner := h.NewUser(t)
+
+		todo := newTask("Write the README")
+		todo.UserID = &owner
+		inProgress := newTask("Fix login")
+		inProgress.Status = models.TaskStatusInProgress
+		inProgress.Priority = 5
+		inProgress.Description = "users can't sign in with a readme_link"
+		done := newTask("Ship 100% coverage")
+		done.Status = models.TaskStatusDone
+		for _, task := range []*models.Task{&todo, &inProgress, &done} {
+			mustCreate(t, h, task)
+		}
+
+		byUser, err := h.Repo.GetByUserID(ctx, owner.String(), 0, 10)
+		if err != nil {
+			t.Fatalf("GetByUserID: %v", err)
+		}
+		wantIDs(t, byUser, []string{todo.ID.String()})
+
+		byStatus, err := h.Repo.GetByStatus(ctx, models.TaskStatusInProgress, 0, 10)
+		if err != nil {
+			t.Fatalf("GetByStatus: %v", err)
+		}
+		wantIDs(t, byStatus, []string{inProgress.ID.String()})
+
+		byPriority, err := h.Repo.GetByPriority(ctx, "5", 0, 10)
+		if err != nil {
+			t.Fatalf("GetByPriority: %v", err)
+		}
+		wantIDs(t, byPriority, []string{inProgress.ID.String()})
+
+		// Search is case-insensitive over title and description.
+		found, err := h.Repo.Search(ctx, "readme", 0, 10)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		wantIDs(t, found, sorted(todo.ID.String(), inProgress.ID.String()))
+
+		// LIKE wildcards in the query match literally.
+		found, err = h.Repo.Search(ctx, "100%", 0, 10)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		wantIDs(t, found, []string{done.ID.String()})
+		found, err = h.Repo.Search(ctx, "e_l", 0, 10)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		wantIDs(t, found, []string{inProgress.ID.String()})
+
+		page, err := h.Repo.Search(ctx, "readme", 1, 10)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		wantIDs(t, page, sorted(todo.ID.String(), inProgress.ID.String())[1:])
+	})
+
+	t.Run("FindSortsAndCounts", func(t *testing.T) {
+		h := newHarness(t)
+		soon, later := time.Now().Add(time.Hour).UTC(), time.Now().Add(48*time.Hour).UTC()
+		a, b, c, d := newTask("alpha"), newTask("bravo"), newTask("charlie"), newTask("delta")
+		a.Priority, a.DueDate = 2, &later
+		b.Priority = 4
+		c.Priority, c.DueDate = 2, &soon
+		d.Priority, d.Status = 5, models.TaskStatusDone
+		for _, task := range []*models.Task{&a, &b, &c, &d} {
+			mustCreate(t, h, task)
+		}
+
+		find := func(query models.TaskListQuery) []models.Task {
+			t.Helper()
+			if query.Page == 0 {
+				query.Page, query.Limit = 1, 10
+			}
+			tasks, err := h.Repo.Find(ctx, query)
+			if err != nil {
+				t.Fatalf("Find(%+v): %v", query, err)
+			}
+			return tasks
+		}
+		ties := sorted(a.ID.String(), c.ID.String())
+		wantIDs(t, find(models.TaskListQuery{Sort: "title"}),
+			[]string{a.ID.String(), b.ID.String(), c.ID.String(), d.ID.String()})
+		wantIDs(t, find(models.TaskListQuery{Sort: "-title"}),
+			[]string{d.ID.String(), c.ID.String(), b.ID.String(), a.ID.String()})
+		// Ties are ordered by ID whichever the direction.
+		wantIDs(t, find(models.TaskListQuery{Status: models.TaskStatusTodo, Sort: "-priority"}),
+			append([]string{b.ID.String()}, ties...))
+		// Tasks without a due date come last either way.
+		wantIDs(t, find(models.TaskListQuery{Status: models.TaskStatusTodo, Sort: "due_date"}),
+			[]string{c.ID.String(), a.ID.String(), b.ID.String()})
+		wantIDs(t, find(models.TaskListQuery{Status: models.TaskStatusTodo, Sort: "-due_date"}),
+			[]string{a.ID.String(), c.ID.String(), b.ID.String()})
+		wantIDs(t, find(models.TaskListQuery{Query: "A", Sort: "title", Page: 2, Limit: 2}),
+			[]string{c.ID.String(), d.ID.String()})
+
+		for query, want := range map[models.TaskListQuery]int{
+			{}:                               4,
+			{Status: models.TaskStatusTodo}:  3,
+			{Priority: 2, Sort: "-priority"}: 2,
+			{Query: "LTA"}:                   1,
+			{UserID: uuid.NewString()}:       0,
+		} {
+			if got, err := h.Repo.Count(ctx, query); err != nil || got != want {
+				t.Errorf("Count(%+v) = %d, %v, want %d", query, got, err, want)
+			}
+		}
+	})
+
+	t.Run("CreateBatchIsAtomic", func(t *testing.T) {
+		h := newHarness(t)
+		existing := newTask("existing")
+		mustCreate(t, h, &existing)
+
+		parent, child := newTask("parent"), newTask("child")
+		child.ParentID = &parent.ID
+		wantKind(t, h.Repo.CreateBatch(ctx, []models.Task{parent, child, existing}, nil), apperrors.KindConflict)
+		_, err := h.Repo.GetByID(ctx, parent.ID.String())
+		wantKind(t, err, apperrors.KindNotFound)
+
+		if err := h.Repo.CreateBatch(ctx, []models.Task{parent, child}, nil); err != nil {
+			t.Fatalf("CreateBatch: %v", err)
+		}
+		got, err := h.Repo.GetByID(ctx, child.ID.String())
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.ParentID == nil || *got.ParentID != parent.ID {
+			t.Fatalf("ParentID = %v, want %s", got.ParentID, parent.ID)
+		}
+	})
+
+	t.Run("CreateBatchRanksWithinWIPLimits", func(t *testing.T) {
+		h := newHarness(t)
+		a, b := newTask("a"), newTask("b")
+		if err := h.Repo.CreateBatch(ctx, []models.Task{a, b}, nil); err != nil {
+			t.Fatalf("CreateBatch: %v", err)
+		}
+		limits := map[string]int{models.TaskStatusTodo: 3}
+
+		c, d := newTask("c"), newTask("d")
+		err := h.Repo.CreateBatch(ctx, []models.Task{c, d}, limits)
+		wantKind(t, err, apperrors.KindConflict)
+		if appErr := apperrors.As(err); appErr == nil || appErr.Code != "wip_limit_reached" {
+			t.Fatalf("error = %v, want code wip_limit_reached", err)
+		}
+		_, err = h.Repo.GetByID(ctx, c.ID.String())
+		wantKind(t, err, apperrors.KindNotFound)
+
+		if err := h.Repo.CreateBatch(ctx, []models.Task{c}, limits); err != nil {
+			t.Fatalf("CreateBatch: %v", err)
+		}
+		var ranks []string
+		for _, id := range []uuid.UUID{a.ID, b.ID, c.ID} {
+			got, err := h.Repo.GetByID(ctx, id.String())
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			ranks = append(ranks, got.Rank)
+		}
+		if ranks[0] == "" || !slices.IsSorted(ranks) || ranks[1] == ranks[2] {
+			t.Fatalf("ranks = %q, want ascending in creation order", ranks)
+		}
+	})
+
+	t.Run("PatchLeavesPosition", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("draft")
+		task.Status = models.TaskStatusInProgress
+		if err := h.Repo.CreateBatch(ctx, []models.Task{task}, nil); err != nil {
+			t.Fatalf("CreateBatch: %v", err)
+		}
+		before, err := h.Repo.GetByID(ctx, task.ID.String())
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+
+		title, priority, labels := "final", 5, []string{"ux", "billable", "ux"}
+		got, err := h.Repo.Patch(ctx, task.ID.String(), models.UpdateTaskInput{Title: &title, Priority: &priority, Labels: &labels})
+		if err != nil {
+			t.Fatalf("Patch: %v", err)
+		}
+		if got.Title != title || got.Priority != priority || !slices.Equal(got.Labels, models.Labels{"billable", "ux"}) {
+			t.Fatalf("patched %+v", got)
+		}
+		if got.Status != before.Status || got.Rank != before.Rank || got.Description != before.Description {
+			t.Fatalf("Patch changed other fields: %+v, was %+v", got, before)
+		}
+
+		_, err = h.Repo.Patch(ctx, uuid.NewString(), models.UpdateTaskInput{Title: &title})
+		wantKind(t, err, apperrors.KindNotFound)
+	})
+
+	t.Run("MoveAndPatch", func(t *testing.T) {
+		h := newHarness(t)
+		done, task := newTask("done"), newTask("draft")
+		done.Status = models.TaskStatusDone
+		if err := h.Repo.CreateBatch(ctx, []models.Task{done, task}, nil); err != nil {
+			t.Fatalf("CreateBatch: %v", err)
+		}
+
+		title, limit := "final", 1
+		_, err := h.Repo.MoveAndPatch(ctx, task.ID.String(), models.TaskMove{Status: models.TaskStatusDone}, &limit,
+			models.UpdateTaskInput{Title: &title})
+		wantKind(t, err, apperrors.KindConflict)
+		if got, err := h.Repo.GetByID(ctx, task.ID.String()); err != nil || got.Title != task.Title {
+			t.Fatalf("a move over the WIP limit patched the task: %+v, %v", got, err)
+		}
+
+		got, err := h.Repo.MoveAndPatch(ctx, task.ID.String(), models.TaskMove{Status: models.TaskStatusDone}, nil,
+			models.UpdateTaskInput{Title: &title})
+		if err != nil {
+			t.Fatalf("MoveAndPatch: %v", err)
+		}
+		stored, err := h.Repo.GetByID(ctx, task.ID.String())
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		for _, got := range []*models.Task{got, stored} {
+			if got.Title != title || got.Status != models.TaskStatusDone || got.Rank == "" {
+				t.Fatalf("moved and patched to %+v", got)
+			}
+		}
+	})
+
+	t.Run("GetDueBetween", func(t *testing.T) {
+		h := newHarness(t)
+		owner := h.NewUser(t)
+		now := time.Now().UTC().Truncate(time.Second)
+		soon, sooner, later, past := now.Add(2*time.Hour), now.Add(time.Hour), now.Add(48*time.Hour), now.Add(-time.Hour)
+		a, b, c, d, e, f := newTask("a"), newTask("b"), newTask("c"), newTask("d"), newTask("e"), newTask("f")
+		a.UserID, a.DueDate = &owner, &soon
+		b.UserID, b.DueDate = &owner, &sooner
+		c.UserID, c.DueDate = &owner, &later
+		d.UserID, d.DueDate = &owner, &past
+		e.UserID, e.DueDate, e.Status = &owner, &soon, models.TaskStatusDone
+		f.DueDate = &soon
+		for _, task := range []*models.Task{&a, &b, &c, &d, &e, &f} {
+			mustCreate(t, h, task)
+		}
+
+		// Only assigned, unfinished tasks due in [from, to), soonest first.
+		tasks, err := h.Repo.GetDueBetween(ctx, now, now.Add(24*time.Hour))
+		if err != nil {
+			t.Fatalf("GetDueBetween: %v", err)
+		}
+		wantIDs(t, tasks, []string{b.ID.String(), a.ID.String()})
+	})
+}
+
+func mustCreate(t *testing.T, h TaskHarness, task *models.Task) {
+	t.Helper()
+	if err := h.Repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+}
+
+func wantKind(t *testing.T, err error, kind apperrors.Kind) {
+	t.Helper()
+	if !apperrors.Is(err, kind) {
+		t.Fatalf("error = %v, want kind %s", err, kind)
+	}
+}
+
+func wantIDs(t *testing.T, tasks []models.Task, want []string) {
+	t.Helper()
+	got := make([]string, len(tasks))
+	for i, task := range tasks {
+		got[i] = task.ID.String()
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("task IDs = [%s], want [%s]", strings.Join(got, " "), strings.Join(want, " "))
+	}
+}
+
+func sorted(ids ...string) []string {
+	slices.Sort(ids)
+	return ids
+}
+
+// SQLConstraints checks that the database backends enforce the schema's
+// constraints and report violations as the same domain errors. The
+// in-memory repository has no constraints and is not run against it.
+func SQLConstraints(t *testing.T, newHarness func(t *testing.T) TaskHarness) {
+	ctx := context.Background()
+
+	t.Run("PriorityCheck", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("too important")
+		task.Priority = 9
+		wantKind(t, h.Repo.Create(ctx, &task), apperrors.KindValidation)
+	})
+
+	t.Run("UnknownOwner", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("orphan")
+		owner := uuid.New()
+		task.UserID = &owner
+		wantKind(t, h.Repo.Create(ctx, &task), apperrors.KindConflict)
+	})
+
+	t.Run("MoveAndPatchIsAtomic", func(t *testing.T) {
+		h := newHarness(t)
+		task := newTask("draft")
+		mustCreate(t, h, &task)
+		before, err := h.Repo.GetByID(ctx, task.ID.String())
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+
+		owner := uuid.New()
+		_, err = h.Repo.MoveAndPatch(ctx, task.ID.String(), models.TaskMove{Status: models.TaskStatusDone}, nil,
+			models.UpdateTaskInput{UserID: &owner})
+		wantKind(t, err, apperrors.KindConflict)
+		got, err := h.Repo.GetByID(ctx, task.ID.String())
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.Status != before.Status || got.Rank != before.Rank {
+			t.Fatalf("a failed patch kept the move: %+v, was %+v", got, before)
+		}
+	})
+}
+
+// ViewSharing runs the contract of project membership and of the saved
+// views it shares against the harnesses returned by newHarness.
+func ViewSharing(t *testing.T, newHarness func(t *testing.T) SharingHarness) {
+	ctx := context.Background()
+
+	newProject := func(t *testing.T, h SharingHarness, name string, owner uuid.UUID) models.Project {
+		t.Helper()
+		project := models.ProjectInput{Name: name}.NewProject(owner)
+		if err := h.Projects.Create(ctx, &project); err != nil {
+			t.Fatalf("create project %s: %v", name, err)
+		}
+		return project
+	}
+	newView := func(t *testing.T, h SharingHarness, name string, owner uuid.UUID, project *uuid.UUID) {
+		t.Helper()
+		view := models.SavedViewInput{Name: name, Shared: project != nil, ProjectID: project}.NewSavedView(owner)
+		if err := h.Views.Create(ctx, &view); err != nil {
+			t.Fatalf("create view %s: %v", name, err)
+		}
+	}
+	wantMembers := func(t *testing.T, h SharingHarness, project models.Project, want ...uuid.UUID) {
+		t.Helper()
+		members, err := h.Projects.GetMembers(ctx, project.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []uuid.UUID
+		for _, m := range members {
+			got = append(got, m.UserID)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("members %v, want %v", got, want)
+		}
+	}
+
+	t.Run("Membership", func(t *testing.T) {
+		h := newHarness(t)
+		owner, member := h.NewUser(t), h.NewUser(t)
+		project := newProject(t, h, "Acme", owner)
+		wantMembers(t, h, project, owner)
+
+		for range 2 {
+			if err := h.Projects.AddMember(ctx, project.ID.String(), member.String()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		wantMembers(t, h, project, owner, member)
+		if projects, err := h.Projects.GetMemberProjects(ctx, member.String()); err != nil || !slices.Equal(projects, []uuid.UUID{project.ID}) {
+			t.Errorf("member of %v, %v", projects, err)
+		}
+
+		if err := h.Projects.RemoveMember(ctx, project.ID.String(), member.String()); err != nil {
+			t.Fatal(err)
+		}
+		wantKind(t, h.Projects.RemoveMember(ctx, project.ID.String(), member.String()), apperrors.KindNotFound)
+		wantMembers(t, h, project, owner)
+
+		if err := h.Projects.Delete(ctx, project.ID.String()); err != nil {
+			t.Fatal(err)
+		}
+		if projects, err := h.Projects.GetMemberProjects(ctx, owner.String()); err != nil || len(projects) != 0 {
+			t.Errorf("owner of a deleted project is still a member of %v, %v", projects, err)
+		}
+	})
+
+	t.Run("GetByMember", func(t *testing.T) {
+		h := newHarness(t)
+		ada, bob := h.NewUser(t), h.NewUser(t)
+		var adas []uuid.UUID
+		for _, name := range []string{"Apollo", "Gemini", "Mercury"} {
+			adas = append(adas, newProject(t, h, name, ada).ID)
+		}
+		shared := newProject(t, h, "Skylab", bob)
+		newProject(t, h, "Voyager", bob)
+		if err := h.Projects.AddMember(ctx, shared.ID.String(), ada.String()); err != nil {
+			t.Fatal(err)
+		}
+		want := append(adas, shared.ID)
+		slices.SortFunc(want, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+
+		var got []uuid.UUID
+		for offset := 0; ; offset += 3 {
+			page, err := h.Projects.GetByMember(ctx, ada.String(), offset, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, project := range page {
+				got = append(got, project.ID)
+			}
+			if len(page) < 3 {
+				break
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("projects %v, want %v", got, want)
+		}
+	})
+
+	t.Run("AddMemberToMissingProject", func(t *testing.T) {
+		h := newHarness(t)
+		wantKind(t, h.Projects.AddMember(ctx, uuid.NewString(), h.NewUser(t).String()), apperrors.KindConflict)
+	})
+
+	t.Run("GetVisibleSharesWithProjects", func(t *testing.T) {
+		h := newHarness(t)
+		ada, bob := h.NewUser(t), h.NewUser(t)
+		acme := newProject(t, h, "Acme", ada)
+		bobco := newProject(t, h, "Bobco", bob)
+		newView(t, h, "Ada's", ada, nil)
+		newView(t, h, "Acme board", ada, &acme.ID)
+		newView(t, h, "Bobco board", bob, &bobco.ID)
+		newView(t, h, "Bob's", bob, nil)
+
+		tests := []struct {
+			name     string
+			viewer   uuid.UUID
+			projects []uuid.UUID
+			want     []string
+		}{
+			{"NoProjects", bob, nil, []string{"Bob's", "Bobco board"}},
+			{"Member", bob, []uuid.UUID{acme.ID, bobco.ID}, []string{"Acme board", "Bob's", "Bobco board"}},
+			{"Owner", ada, []uuid.UUID{acme.ID}, []string{"Acme board", "Ada's"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				views, err := h.Views.GetVisible(ctx, tt.viewer.String(), tt.projects)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var got []string
+				for _, v := range views {
+					got = append(got, v.Name)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("visible %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,175 @@
+// Package repotest holds the contract every repositories implementation
+// must satisfy. Tests of a backend call TaskRepository and ViewSharing with
+// a constructor for that backend, so the in-memory, SQLite and Postgres
+// implementations are checked against the same expectations.
+package repotest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/migrations"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DSNEnv names the environment variable holding the DSN of the Postgres
+// database used by Postgres. Tests using it are skipped when it is unset.
+const DSNEnv = "TASKAPI_TEST_DATABASE_DSN"
+
+// TaskHarness is a fresh, empty task repository under test.
+type TaskHarness struct {
+	Repo repositories.TaskRepository
+	// NewUser returns the ID of a user tasks can be assigned to. Backends
+	// with foreign keys must create the user first.
+	NewUser func(t *testing.T) uuid.UUID
+}
+
+// SharingHarness is a fresh, empty pair of project and saved view
+// repositories under test.
+type SharingHarness struct {
+	Projects repositories.ProjectRepository
+	Views    repositories.SavedViewRepository
+	NewUser  func(t *testing.T) uuid.UUID
+}
+
+// Memory returns a harness for the in-memory task repository.
+func Memory(t *testing.T) TaskHarness {
+	return TaskHarness{
+		Repo:    repositories.NewMemoryTaskRepository(),
+		NewUser: func(*testing.T) uuid.UUID { return uuid.New() },
+	}
+}
+
+// MemorySharing returns a harness for the in-memory project and saved view
+// repositories.
+func MemorySharing(t *testing.T) SharingHarness {
+	return SharingHarness{
+		Projects: repositories.NewMemoryProjectRepository(),
+		Views:    repositories.NewMemorySavedViewRepository(),
+		NewUser:  func(*testing.T) uuid.UUID { return uuid.New() },
+	}
+}
+
+// Postgres returns a harness for the GORM task repository backed by the
+// database named in DSNEnv. The schema is migrated and emptied before and
+// after the test.
+func Postgres(t *testing.T) TaskHarness {
+	t.Helper()
+	return gormHarness(OpenPostgres(t))
+}
+
+// SQLite returns a harness for the GORM task repository backed by a fresh,
+// migrated SQLite file in a temporary directory.
+func SQLite(t *testing.T) TaskHarness {
+	t.Helper()
+	return gormHarness(OpenSQLite(t))
+}
+
+// PostgresSharing and SQLiteSharing return harnesses for the GORM project
+// and saved view repositories, on databases opened like those of Postgres
+// and SQLite.
+func PostgresSharing(t *testing.T) SharingHarness {
+	t.Helper()
+	return gormSharingHarness(OpenPostgres(t))
+}
+
+func SQLiteSharing(t *testing.T) SharingHarness {
+	t.Helper()
+	return gormSharingHarness(OpenSQLite(t))
+}
+
+func gormHarness(db *gorm.DB) TaskHarness {
+	return TaskHarness{Repo: repositories.NewTaskRepository(db), NewUser: userCreator(db)}
+}
+
+func gormSharingHarness(db *gorm.DB) SharingHarness {
+	return SharingHarness{
+		Projects: repositories.NewProjectRepository(db),
+		Views:    repositories.NewSavedViewRepository(db),
+		NewUser:  userCreator(db),
+	}
+}
+
+// userCreator returns a NewUser that inserts the user into db.
+func userCreator(db *gorm.DB) func(t *testing.T) uuid.UUID {
+	return func(t *testing.T) uuid.UUID {
+		t.Helper()
+		user := models.User{ID: uuid.New(), Email: uuid.NewString() + "@example.com", PasswordHash: "x"}
+		if err := db.Create(&user).Error; err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		return user.ID
+	}
+}
+
+// OpenPostgres connects to the database named in DSNEnv, migrates it and
+// truncates every table before and after the test.
+func OpenPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(DSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", DSNEnv)
+	}
+
+	db := open(t, postgres.Open(dsn))
+	truncate := func() {
+		if err := db.Exec("TRUNCATE attachments, comments, tasks, users CASCADE").Error; err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+	}
+	truncate()
+	t.Cleanup(truncate)
+	return db
+}
+
+// OpenSQLite creates a migrated SQLite database in a temporary directory.
+func OpenSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	dbConfig := config.DatabaseConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "tasks.db")}
+	return open(t, dbConfig.Dialector())
+}
+
+// open connects with the same GORM settings as the server, applies the
+// migrations and closes the pool when the test ends.
+func open(t *testing.T, dialector gorm.Dialector) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("connect to %s: %v", dialector.Name(), err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if _, err := migrations.Up(context.Background(), db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// newTask returns a valid task; times are truncated to what Postgres stores.
+func newTask(title string) models.Task {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return models.Task{
+		ID:        uuid.New(),
+		Title:     title,
+		Status:    models.TaskStatusTodo,
+		Priority:  models.DefaultTaskPriority,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SavedViewRepository interface {
+	BaseRepository[models.SavedView]
+	// GetVisible returns the user's views and the views others shared with
+	// the given projects, ordered by name.
+	GetVisible(ctx context.Context, userID string, projects []uuid.UUID) ([]models.SavedView, error)
+	// GetDefault returns the user's default view, or a not found error.
+	GetDefault(ctx context.Context, userID string) (*models.SavedView, error)
+	// SetDefault makes the view the user's default, replacing any other.
+	SetDefault(ctx context.Context, userID, viewID string) error
+	// ClearDefault removes the user's default view, if any.
+	ClearDefault(ctx context.Context, userID string) error
+}
+
+type savedViewRepository struct {
+	*baseRepository[models.SavedView]
+	db *gorm.DB
+}
+
+func NewSavedViewRepository(db *gorm.DB) SavedViewRepository {
+	return &savedViewRepository{
+		baseRepository: NewBaseRepository[models.SavedView](db).(*baseRepository[models.SavedView]),
+		db:             db,
+	}
+}
+
+func (r *savedViewRepository) GetVisible(ctx context.Context, userID string, projects []uuid.UUID) ([]models.SavedView, error) {
+	views := []models.SavedView{}
+	db := r.db.WithContext(ctx).Where("user_id=?", userID)
+	if len(projects) > 0 {
+		db = db.Or("shared AND project_id IN ?", projects)
+	}
+	if err := db.Order("name, id").Find(&views).Error; err != nil {
+		return nil, apperrors.FromDB(err, "saved_view")
+	}
+	return views, nil
+}
+
+func (r *savedViewRepository) GetDefault(ctx context.Context, userID string) (*models.SavedView, error) {
+	var view models.SavedView
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN saved_view_defaults ON saved_view_defaults.view_id = saved_views.id").
+		Take(&view, "saved_view_defaults.user_id=?", userID).Error; err != nil {
+		return nil, apperrors.FromDB(err, "default_view")
+	}
+	return &view, nil
+}
+
+func (r *savedViewRepository) SetDefault(ctx context.Context, userID, viewID string) error {
+	row := map[string]interface{}{"user_id": userID, "view_id": viewID, "updated_at": time.Now()}
+	err := r.db.WithContext(ctx).Model(&models.SavedViewDefault{}).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"view_id", "updated_at"}),
+	}).Create(row).Error
+	return apperrors.FromDB(err, "default_view")
+}
+
+func (r *savedViewRepository) ClearDefault(ctx context.Context, userID string) error {
+	err := r.db.WithContext(ctx).Delete(&models.SavedViewDefault{}, "user_id=?", userID).Error
+	return apperrors.FromDB(err, "default_view")
+}
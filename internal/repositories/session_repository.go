@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	BaseRepository[models.Session]
+	// CreateUser adds a user signing up. An email address already in use,
+	// ignoring case, is a conflict.
+	CreateUser(ctx context.Context, user *models.User) error
+	// GetUser returns the user with the ID.
+	GetUser(ctx context.Context, id uuid.UUID) (*models.User, error)
+	// GetUserByEmail returns the user with the email address, ignoring case.
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	// GetByTokenHash returns the session whose refresh token has the hash.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error)
+	// Rotate replaces the session's token hash and expiry, provided its
+	// token still has the old hash. A session refreshed concurrently, or
+	// ended, is reported as not found, so a refresh token works once.
+	Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt time.Time) error
+}
+
+type sessionRepository struct {
+	*baseRepository[models.Session]
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{
+		baseRepository: NewBaseRepository[models.Session](db).(*baseRepository[models.Session]),
+		db:             db,
+	}
+}
+
+func (r *sessionRepository) CreateUser(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.User{}).Where("LOWER(email) = LOWER(?)", user.Email).
+			Count(&count).Error; err != nil {
+			return apperrors.FromDB(err, "user")
+		}
+		if count > 0 {
+			return apperrors.FromDB(gorm.ErrDuplicatedKey, "user")
+		}
+		return apperrors.FromDB(tx.Create(user).Error, "user")
+	})
+}
+
+func (r *sessionRepository) GetUser(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "user")
+	}
+	return &user, nil
+}
+
+func (r *sessionRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, "LOWER(email) = LOWER(?)", email).Error; err != nil {
+		return nil, apperrors.FromDB(err, "user")
+	}
+	return &user, nil
+}
+
+func (r *sessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.WithContext(ctx).First(&session, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, apperrors.FromDB(err, r.resource)
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ? AND token_hash = ?", id, oldHash).
+		Updates(map[string]any{"token_hash": newHash, "expires_at": expiresAt})
+	if result.Error != nil {
+		return apperrors.FromDB(result.Error, r.resource)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound(r.resource)
+	}
+	return nil
+}
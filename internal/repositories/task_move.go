@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/rank"
+)
+
+// columnCursor reads one board column in rank order on behalf of Move,
+// skipping the task being moved.
+type columnCursor interface {
+	// get returns the task if it is in the column, or nil.
+	get(id uuid.UUID) (*models.Task, error)
+	// next and prev return the neighbours of t, or nil at either end.
+	next(t *models.Task) (*models.Task, error)
+	prev(t *models.Task) (*models.Task, error)
+	last() (*models.Task, error)
+	// rerank spreads the ranks of the column's tasks evenly, keeping their
+	// order. It is needed when two neighbours have no key between them.
+	rerank() error
+}
+
+// placeTask returns the rank that puts the task with the given ID where
+// move asks for.
+func placeTask(column columnCursor, id uuid.UUID, move models.TaskMove) (string, error) {
+	if move.AfterID != nil && *move.AfterID == id || move.BeforeID != nil && *move.BeforeID == id {
+		return "", apperrors.Validation("a task can't be moved next to itself")
+	}
+
+	for reranked := false; ; reranked = true {
+		lower, upper, err := neighbours(column, move)
+		if err != nil {
+			return "", err
+		}
+		key, err := between(lower, upper)
+		if err == nil {
+			return key, nil
+		}
+		if reranked {
+			return "", apperrors.Internal(fmt.Errorf("failed to rank task after re-ranking column %s: %w", move.Status, err))
+		}
+		if err := column.rerank(); err != nil {
+			return "", err
+		}
+	}
+}
+
+// neighbours returns the tasks the moved task goes between. Either is nil
+// at the ends of the column.
+func neighbours(column columnCursor, move models.TaskMove) (lower, upper *models.Task, err error) {
+	switch {
+	case move.AfterID != nil:
+		if lower, err = inColumn(column, *move.AfterID, move.Status); err != nil {
+			return nil, nil, err
+		}
+		if upper, err = column.next(lower); err != nil {
+			return nil, nil, err
+		}
+		if move.BeforeID != nil && (upper == nil || upper.ID != *move.BeforeID) {
+			return nil, nil, boardChanged(fmt.Sprintf("tasks %s and %s are no longer adjacent", *move.AfterID, *move.BeforeID))
+		}
+	case move.BeforeID != nil:
+		if upper, err = inColumn(column, *move.BeforeID, move.Status); err != nil {
+			return nil, nil, err
+		}
+		if lower, err = column.prev(upper); err != nil {
+			return nil, nil, err
+		}
+	default:
+		if lower, err = column.last(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return lower, upper, nil
+}
+
+func inColumn(column columnCursor, id uuid.UUID, status string) (*models.Task, error) {
+	task, err := column.get(id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, boardChanged(fmt.Sprintf("task %s is not in the %s column", id, status))
+	}
+	return task, nil
+}
+
+// between returns a rank between two neighbours. Tasks that were never
+// ranked have an empty rank, which only works as a lower bound.
+func between(lower, upper *models.Task) (string, error) {
+	var low, high string
+	if lower != nil {
+		low = lower.Rank
+	}
+	if upper != nil {
+		if upper.Rank == "" {
+			return "", rank.ErrNoRoom
+		}
+		high = upper.Rank
+	}
+	key, err := rank.Between(low, high)
+	if err != nil {
+		return "", err
+	}
+	if len(key) > rank.MaxLength {
+		return "", errors.New("rank too long")
+	}
+	return key, nil
+}
+
+// rankAtBottom ranks new tasks after the last task of their column, in
+// order. It fails if a column would end up with more tasks than its limit
+// in wipLimits. column returns the last rank and the number of tasks of a
+// column; it is called once per column, in status order, so that callers
+// taking a lock per column always take them in the same order.
+func rankAtBottom(tasks []models.Task, wipLimits map[string]int, column func(status string) (last string, count int, err error)) error {
+	byStatus := map[string][]*models.Task{}
+	for i := range tasks {
+		byStatus[tasks[i].Status] = append(byStatus[tasks[i].Status], &tasks[i])
+	}
+	statuses := make([]string, 0, len(byStatus))
+	for status := range byStatus {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+
+	for _, status := range statuses {
+		added := byStatus[status]
+		last, count, err := column(status)
+		if err != nil {
+			return err
+		}
+		if limit, ok := wipLimits[status]; ok && count+len(added) > limit {
+			return wipLimitReached(status, limit)
+		}
+		keys, err := rank.AfterN(last, len(added))
+		if err != nil {
+			return apperrors.Internal(fmt.Errorf("failed to rank task: %w", err))
+		}
+		for i, task := range added {
+			task.Rank = keys[i]
+		}
+	}
+	return nil
+}
+
+func boardChanged(message string) *apperrors.Error {
+	return apperrors.Conflict("board_changed", message+", reload the board and try again")
+}
+
+func wipLimitReached(status string, limit int) *apperrors.Error {
+	return apperrors.Conflict("wip_limit_reached",
+		"the "+status+" column is at its WIP limit of "+strconv.Itoa(limit)+" tasks")
+}
@@ -2,10 +2,16 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
 	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/rank"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository interface {
@@ -13,13 +19,52 @@ type TaskRepository interface {
 	GetByUserID(ctx context.Context, userID string, offset, limit int) ([]models.Task, error)
 	GetByStatus(ctx context.Context, status string, offset, limit int) ([]models.Task, error)
 	GetByPriority(ctx context.Context, priority string, offset, limit int) ([]models.Task, error)
-	search(
-		ctx context.Context,
-		query string,
-		offset, limit int,
-	) ([]models.Task, error)
+	// CreateBatch creates the tasks in one transaction, in order, so that
+	// either all of them or none exist. Each goes to the bottom of its
+	// column. Like moves, creations in a column are serialized, and fail if
+	// they would take the column past its limit in wipLimits; columns
+	// missing from it have none.
+	CreateBatch(ctx context.Context, tasks []models.Task, wipLimits map[string]int) error
+	// Find returns the page of tasks matching the single filter of query,
+	// in its sort order.
+	Find(ctx context.Context, query models.TaskListQuery) ([]models.Task, error)
+	// Count returns the number of tasks matching the single filter of query.
+	Count(ctx context.Context, query models.TaskListQuery) (int, error)
+	Search(ctx context.Context, query string, offset, limit int) ([]models.Task, error)
+	// GetAttachments returns the attachments of the given tasks, oldest first.
+	GetAttachments(ctx context.Context, taskIDs []string) ([]models.Attachment, error)
+	// GetColumn returns a page of the tasks with the given status in board
+	// order: by rank, then by ID.
+	GetColumn(ctx context.Context, status string, offset, limit int) ([]models.Task, error)
+	// CountByStatus returns the number of tasks with each status. Statuses
+	// without tasks are left out.
+	CountByStatus(ctx context.Context) (map[string]int, error)
+	// GetDueBetween returns the assigned tasks that aren't done and are due
+	// in [from, to), by due date.
+	GetDueBetween(ctx context.Context, from, to time.Time) ([]models.Task, error)
+	// LastRank returns the highest rank in the status column, or "" if the
+	// column is empty.
+	LastRank(ctx context.Context, status string) (string, error)
+	// Move places a task in the column and position described by move,
+	// writing only that task unless the column has to be re-ranked. Moves
+	// into the same column are serialized, and a move into a column
+	// already holding wipLimit tasks fails. A nil wipLimit means none.
+	Move(ctx context.Context, id string, move models.TaskMove, wipLimit *int) (*models.Task, error)
+	// Patch writes the fields set in input, except the status, which only
+	// Move changes, and returns the task. The other columns, the rank in
+	// particular, are left as they are.
+	Patch(ctx context.Context, id string, input models.UpdateTaskInput) (*models.Task, error)
+	// MoveAndPatch moves a task like Move and writes the fields set in
+	// input like Patch, in one transaction, so that either both or neither
+	// happen.
+	MoveAndPatch(ctx context.Context, id string, move models.TaskMove, wipLimit *int,
+		input models.UpdateTaskInput) (*models.Task, error)
 }
 
+// likeEscaper escapes LIKE wildcards so search terms match literally. The
+// queries declare the escape character, which SQLite has no default for.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type taskRepository struct {
 	*baseRepository[models.Task]
 	db *gorm.DB
@@ -34,39 +79,342 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 
 func (r *taskRepository) GetByUserID(ctx context.Context, userID string, offset, limit int) ([]models.Task, error) {
 	var tasks []models.Task
-	if err := r.db.WithContext(ctx).Where("user_id=?", userID).Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).Where("user_id=?", userID).Order("id").Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
 	}
 	return tasks, nil
 }
 
 func (r *taskRepository) GetByStatus(ctx context.Context, status string, offset, limit int) ([]models.Task, error) {
 	var tasks []models.Task
-	if err := r.db.WithContext(ctx).Where("status=?", status).Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).Where("status=?", status).Order("id").Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
 	}
 	return tasks, nil
 }
 
 func (r *taskRepository) GetByPriority(ctx context.Context, priority string, offset, limit int) ([]models.Task, error) {
 	var tasks []models.Task
-	if err := r.db.WithContext(ctx).Where("priority=?", priority).Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).Where("priority=?", priority).Order("id").Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
 	}
 	return tasks, nil
 }
 
-func (r *taskRepository) search(ctx context.Context, query string, offset, limit int) ([]models.Task, error) {
+func (r *taskRepository) Search(ctx context.Context, query string, offset, limit int) ([]models.Task, error) {
 	var tasks []models.Task
-	searchPattern := "%" + strings.ReplaceAll(strings.ReplaceAll(query, "%", "\\%"), "_", "\\_") + "%"
 	if err := r.db.WithContext(ctx).
-		Where("LOWER(title) LIKE LOWER(?) OR LOWER(description) LIKE LOWER(?)",
-			searchPattern,
-			searchPattern).
+		Scopes(matching(query)).
+		Order("id").
 		Offset(offset).
 		Limit(limit).
 		Find(&tasks).Error; err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err, "task")
+	}
+	return tasks, nil
+}
+
+// matching selects the tasks whose title or description contains query,
+// ignoring case.
+func matching(query string) func(*gorm.DB) *gorm.DB {
+	searchPattern := "%" + likeEscaper.Replace(query) + "%"
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`LOWER(title) LIKE LOWER(?) ESCAPE '\' OR LOWER(description) LIKE LOWER(?) ESCAPE '\'`,
+			searchPattern,
+			searchPattern)
+	}
+}
+
+func (r *taskRepository) CreateBatch(ctx context.Context, tasks []models.Task, wipLimits map[string]int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := rankAtBottom(tasks, wipLimits, func(status string) (string, int, error) {
+			if err := lockColumn(tx, status); err != nil {
+				return "", 0, err
+			}
+			var count int64
+			if err := tx.Model(&models.Task{}).Where("status=?", status).Count(&count).Error; err != nil {
+				return "", 0, apperrors.FromDB(err, "task")
+			}
+			last, err := lastRank(tx, status)
+			return last, int(count), err
+		})
+		if err != nil {
+			return err
+		}
+		// One insert per task, as subtasks reference the parents inserted
+		// before them.
+		for i := range tasks {
+			if err := tx.Create(&tasks[i]).Error; err != nil {
+				return apperrors.FromDB(err, "task")
+			}
+		}
+		return nil
+	})
+}
+
+func (r *taskRepository) Find(ctx context.Context, query models.TaskListQuery) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := r.db.WithContext(ctx).Scopes(filtered(query)).Order(taskOrder(query.Sort)).
+		Offset(query.Offset()).Limit(query.Limit).Find(&tasks).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) Count(ctx context.Context, query models.TaskListQuery) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Task{}).Scopes(filtered(query)).Count(&count).Error; err != nil {
+		return 0, apperrors.FromDB(err, "task")
+	}
+	return int(count), nil
+}
+
+// filtered applies the filter of query like the per-filter lookups do.
+func filtered(query models.TaskListQuery) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch {
+		case query.Status != "":
+			return db.Where("status=?", query.Status)
+		case query.Priority != 0:
+			return db.Where("priority=?", query.Priority)
+		case query.UserID != "":
+			return db.Where("user_id=?", query.UserID)
+		case query.Query != "":
+			return db.Scopes(matching(query.Query))
+		default:
+			return db
+		}
+	}
+}
+
+// taskOrder returns the ORDER BY clause for a TaskListQuery sort. Tasks
+// without a due date come last either way, as SQLite and Postgres disagree
+// on where NULLs go.
+func taskOrder(sort string) string {
+	field, direction := strings.TrimPrefix(sort, "-"), ""
+	if strings.HasPrefix(sort, "-") {
+		direction = " DESC"
+	}
+	switch field {
+	case "title", "priority", "created_at", "updated_at":
+		return field + direction + ", id"
+	case "due_date":
+		return "due_date IS NULL, due_date" + direction + ", id"
+	default:
+		return "id" + direction
+	}
+}
+
+func (r *taskRepository) GetAttachments(ctx context.Context, taskIDs []string) ([]models.Attachment, error) {
+	attachments := []models.Attachment{}
+	if len(taskIDs) == 0 {
+		return attachments, nil
+	}
+	if err := r.db.WithContext(ctx).Where("task_id IN ?", taskIDs).Order("uploaded_at, id").Find(&attachments).Error; err != nil {
+		return nil, apperrors.FromDB(err, "attachment")
+	}
+	return attachments, nil
+}
+
+func (r *taskRepository) GetColumn(ctx context.Context, status string, offset, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := r.db.WithContext(ctx).Where("status=?", status).Order("rank, id").Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Task{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *taskRepository) GetDueBetween(ctx context.Context, from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := r.db.WithContext(ctx).
+		Where("user_id IS NOT NULL AND status<>? AND due_date>=? AND due_date<?", models.TaskStatusDone, from, to).
+		Order("due_date, id").Find(&tasks).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
 	}
 	return tasks, nil
 }
+
+func (r *taskRepository) LastRank(ctx context.Context, status string) (string, error) {
+	return lastRank(r.db.WithContext(ctx), status)
+}
+
+func lastRank(db *gorm.DB, status string) (string, error) {
+	var ranks []string
+	if err := db.Model(&models.Task{}).Where("status=?", status).Order("rank DESC, id DESC").Limit(1).Pluck("rank", &ranks).Error; err != nil {
+		return "", apperrors.FromDB(err, "task")
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+// lockColumn locks the column's row until the transaction ends, which
+// serializes the writes into the column: the tasks counted and ranked
+// after it can't change before commit. SQLite ignores the lock but its
+// transactions take the write lock up front.
+func lockColumn(tx *gorm.DB, status string) error {
+	var column models.BoardColumn
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&column, "status=?", status).Error; err != nil {
+		return apperrors.FromDB(err, "board_column")
+	}
+	return nil
+}
+
+func (r *taskRepository) Move(ctx context.Context, id string, move models.TaskMove, wipLimit *int) (*models.Task, error) {
+	var task *models.Task
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		task, err = moveTask(tx, id, move, wipLimit)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *taskRepository) Patch(ctx context.Context, id string, input models.UpdateTaskInput) (*models.Task, error) {
+	if err := patchTask(r.db.WithContext(ctx), id, input); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *taskRepository) MoveAndPatch(ctx context.Context, id string, move models.TaskMove, wipLimit *int,
+	input models.UpdateTaskInput) (*models.Task, error) {
+	var task models.Task
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := moveTask(tx, id, move, wipLimit); err != nil {
+			return err
+		}
+		if err := patchTask(tx, id, input); err != nil {
+			return err
+		}
+		return apperrors.FromDB(tx.Take(&task, "id=?", id).Error, "task")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// moveTask does the work of Move in its transaction tx.
+func moveTask(tx *gorm.DB, id string, move models.TaskMove, wipLimit *int) (*models.Task, error) {
+	if err := lockColumn(tx, move.Status); err != nil {
+		return nil, err
+	}
+	var task models.Task
+	if err := tx.Take(&task, "id=?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "task")
+	}
+
+	if wipLimit != nil && task.Status != move.Status {
+		var count int64
+		if err := tx.Model(&models.Task{}).Where("status=?", move.Status).Count(&count).Error; err != nil {
+			return nil, apperrors.FromDB(err, "task")
+		}
+		if count >= int64(*wipLimit) {
+			return nil, wipLimitReached(move.Status, *wipLimit)
+		}
+	}
+
+	key, err := placeTask(gormColumn{tx: tx, status: move.Status, moved: task.ID}, task.ID, move)
+	if err != nil {
+		return nil, err
+	}
+
+	// The condition on the old position makes a concurrent move of the
+	// same task into another column fail rather than be overwritten.
+	now := time.Now()
+	result := tx.Model(&models.Task{}).
+		Where("id=? AND status=? AND rank=?", task.ID, task.Status, task.Rank).
+		Updates(map[string]interface{}{"status": move.Status, "rank": key, "updated_at": now})
+	if result.Error != nil {
+		return nil, apperrors.FromDB(result.Error, "task")
+	}
+	if result.RowsAffected == 0 {
+		return nil, boardChanged("the task was moved by another request")
+	}
+	task.Status, task.Rank, task.UpdatedAt = move.Status, key, now
+	return &task, nil
+}
+
+// patchTask writes the fields set in input, like Patch, with db.
+func patchTask(db *gorm.DB, id string, input models.UpdateTaskInput) error {
+	fields := input.Fields()
+	fields["updated_at"] = time.Now()
+	result := db.Model(&models.Task{}).Where("id=?", id).Updates(fields)
+	if result.Error != nil {
+		return apperrors.FromDB(result.Error, "task")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("task")
+	}
+	return nil
+}
+
+// gormColumn is a columnCursor reading inside the move's transaction.
+type gormColumn struct {
+	tx     *gorm.DB
+	status string
+	moved  uuid.UUID
+}
+
+func (c gormColumn) tasks() *gorm.DB {
+	return c.tx.Model(&models.Task{}).Where("status=? AND id<>?", c.status, c.moved)
+}
+
+func (c gormColumn) take(query *gorm.DB) (*models.Task, error) {
+	var task models.Task
+	if err := query.Take(&task).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.FromDB(err, "task")
+	}
+	return &task, nil
+}
+
+func (c gormColumn) get(id uuid.UUID) (*models.Task, error) {
+	return c.take(c.tasks().Where("id=?", id))
+}
+
+func (c gormColumn) next(t *models.Task) (*models.Task, error) {
+	return c.take(c.tasks().Where("(rank, id) > (?, ?)", t.Rank, t.ID).Order("rank, id"))
+}
+
+func (c gormColumn) prev(t *models.Task) (*models.Task, error) {
+	return c.take(c.tasks().Where("(rank, id) < (?, ?)", t.Rank, t.ID).Order("rank DESC, id DESC"))
+}
+
+func (c gormColumn) last() (*models.Task, error) {
+	return c.take(c.tasks().Order("rank DESC, id DESC"))
+}
+
+func (c gormColumn) rerank() error {
+	var ids []uuid.UUID
+	if err := c.tasks().Order("rank, id").Pluck("id", &ids).Error; err != nil {
+		return apperrors.FromDB(err, "task")
+	}
+	for i, key := range rank.Spread(len(ids)) {
+		if err := c.tx.Model(&models.Task{}).Where("id=?", ids[i]).UpdateColumn("rank", key).Error; err != nil {
+			return apperrors.FromDB(err, "task")
+		}
+	}
+	return nil
+}
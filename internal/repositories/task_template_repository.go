@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// TaskTemplateRepository stores templates. Names are unique; creating or
+// renaming a template to a taken name is a conflict.
+type TaskTemplateRepository interface {
+	BaseRepository[models.TaskTemplate]
+}
+
+func NewTaskTemplateRepository(db *gorm.DB) TaskTemplateRepository {
+	return NewBaseRepository[models.TaskTemplate](db)
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"gorm.io/gorm"
+)
+
+type TimeEntryRepository interface {
+	BaseRepository[models.TimeEntry]
+	// GetRunning returns the user's running timer, or a not found error.
+	GetRunning(ctx context.Context, userID string) (*models.TimeEntry, error)
+	// GetByTaskID returns a page of the task's entries, latest first.
+	GetByTaskID(ctx context.Context, taskID string, offset, limit int) ([]models.TimeEntry, error)
+	// Report sums the ended entries matching query per user, task or day,
+	// ordered by group key. Other groupings are left to the caller.
+	Report(ctx context.Context, query models.TimeReportQuery) ([]models.TimeReportRow, error)
+}
+
+type timeEntryRepository struct {
+	*baseRepository[models.TimeEntry]
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) TimeEntryRepository {
+	return &timeEntryRepository{
+		baseRepository: NewBaseRepository[models.TimeEntry](db).(*baseRepository[models.TimeEntry]),
+		db:             db,
+	}
+}
+
+func (r *timeEntryRepository) GetRunning(ctx context.Context, userID string) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	if err := r.db.WithContext(ctx).Take(&entry, "user_id=? AND ended_at IS NULL", userID).Error; err != nil {
+		return nil, apperrors.FromDB(err, "timer")
+	}
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) GetByTaskID(ctx context.Context, taskID string, offset, limit int) ([]models.TimeEntry, error) {
+	entries := []models.TimeEntry{}
+	if err := r.db.WithContext(ctx).Where("task_id=?", taskID).Order("started_at DESC, id").
+		Offset(offset).Limit(limit).Find(&entries).Error; err != nil {
+		return nil, apperrors.FromDB(err, "time_entry")
+	}
+	return entries, nil
+}
+
+func (r *timeEntryRepository) Report(ctx context.Context, query models.TimeReportQuery) ([]models.TimeReportRow, error) {
+	key := "user_id"
+	switch query.GroupBy {
+	case models.TimeReportByTask:
+		key = "task_id"
+	case models.TimeReportByDay:
+		key = r.utcDate("started_at")
+	}
+
+	db := r.db.WithContext(ctx).Model(&models.TimeEntry{}).
+		Where("ended_at IS NOT NULL AND started_at >= ? AND started_at < ?", query.From, query.To)
+	if query.UserID != "" {
+		db = db.Where("user_id=?", query.UserID)
+	}
+	if query.TaskID != "" {
+		db = db.Where("task_id=?", query.TaskID)
+	}
+
+	rows := []models.TimeReportRow{}
+	if err := db.Select(key + " AS group_key, COUNT(*) AS entries, SUM(duration_seconds) AS seconds").
+		Group(key).Order("group_key").Scan(&rows).Error; err != nil {
+		return nil, apperrors.FromDB(err, "time_entry")
+	}
+	return rows, nil
+}
+
+// utcDate returns the SQL expression formatting the timestamp column as a
+// UTC date.
+func (r *timeEntryRepository) utcDate(column string) string {
+	if r.db.Dialector.Name() == "sqlite" {
+		return "strftime('%Y-%m-%d', " + column + ")"
+	}
+	return "to_char(" + column + " AT TIME ZONE 'UTC', 'YYYY-MM-DD')"
+}
@@ -0,0 +1,240 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	gormschema "gorm.io/gorm/schema"
+)
+
+// IssueKind classifies a difference between the models and the database.
+type IssueKind string
+
+const (
+	MissingTable   IssueKind = "missing_table"
+	MissingColumn  IssueKind = "missing_column"
+	TypeMismatch   IssueKind = "type_mismatch"
+	NullMismatch   IssueKind = "nullability_mismatch"
+	MissingIndex   IssueKind = "missing_index"
+	UnmappedColumn IssueKind = "unmapped_column"
+)
+
+// Issue is a single drift finding.
+type Issue struct {
+	Kind   IssueKind
+	Table  string
+	Column string
+	Detail string
+}
+
+func (i Issue) String() string {
+	target := i.Table
+	if i.Column != "" {
+		target += "." + i.Column
+	}
+	if i.Detail == "" {
+		return fmt.Sprintf("%-22s %s", i.Kind, target)
+	}
+	return fmt.Sprintf("%-22s %s: %s", i.Kind, target, i.Detail)
+}
+
+// Check compares GORM's metadata for each model with the live database
+// schema, as reported by the dialect's migrator (information_schema on
+// Postgres), and returns every difference found.
+func Check(ctx context.Context, db *gorm.DB, models ...interface{}) ([]Issue, error) {
+	db = db.WithContext(ctx)
+	migrator := db.Migrator()
+	var issues []Issue
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		s := stmt.Schema
+
+		if !migrator.HasTable(model) {
+			issues = append(issues, Issue{Kind: MissingTable, Table: s.Table})
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", s.Table, err)
+		}
+		columns := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, ct := range columnTypes {
+			columns[ct.Name()] = ct
+		}
+
+		for _, field := range s.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			ct, ok := columns[field.DBName]
+			if !ok {
+				issues = append(issues, Issue{Kind: MissingColumn, Table: s.Table, Column: field.DBName})
+				continue
+			}
+			delete(columns, field.DBName)
+
+			expected := parseType(migrator.FullDataTypeOf(field).SQL)
+			actual := databaseType(ct)
+			if expected.family == "uuid" && db.Dialector.Name() == "sqlite" {
+				expected.family = "text" // SQLite has no UUID type, the migrations store them as text
+			}
+			if !expected.matches(actual, db.Dialector.Name() != "sqlite") {
+				issues = append(issues, Issue{
+					Kind:   TypeMismatch,
+					Table:  s.Table,
+					Column: field.DBName,
+					Detail: fmt.Sprintf("model is %s, database is %s", expected, actual),
+				})
+			}
+
+			if nullable, ok := ct.Nullable(); ok && field.NotNull && nullable {
+				issues = append(issues, Issue{
+					Kind:   NullMismatch,
+					Table:  s.Table,
+					Column: field.DBName,
+					Detail: "model is NOT NULL, database allows NULL",
+				})
+			}
+		}
+
+		unmapped := make([]string, 0, len(columns))
+		for name := range columns {
+			unmapped = append(unmapped, name)
+		}
+		sort.Strings(unmapped)
+		for _, name := range unmapped {
+			issues = append(issues, Issue{Kind: UnmappedColumn, Table: s.Table, Column: name})
+		}
+
+		issues = append(issues, missingIndexes(migrator, model, s)...)
+	}
+
+	return issues, nil
+}
+
+func missingIndexes(migrator gorm.Migrator, model interface{}, s *gormschema.Schema) []Issue {
+	indexes := s.ParseIndexes()
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []Issue
+	for _, name := range names {
+		if !migrator.HasIndex(model, name) {
+			var columns []string
+			for _, opt := range indexes[name].Fields {
+				columns = append(columns, opt.DBName)
+			}
+			issues = append(issues, Issue{
+				Kind:   MissingIndex,
+				Table:  s.Table,
+				Detail: fmt.Sprintf("%s on (%s)", name, strings.Join(columns, ", ")),
+			})
+		}
+	}
+	return issues
+}
+
+var typeLength = regexp.MustCompile(`\((.*?)\)`)
+
+// columnModifiers follow the type name in a column definition.
+var columnModifiers = []string{" not null", " null", " default", " primary key", " unique", " check", " references"}
+
+// columnType is a column type reduced to what the check compares.
+type columnType struct {
+	family   string
+	length   int64 // maximum length of character types, 0 when unbounded
+	timeZone bool  // whether a timestamp stores its time zone
+}
+
+func (t columnType) String() string {
+	switch {
+	case t.length > 0:
+		return fmt.Sprintf("%s(%d)", t.family, t.length)
+	case t.timeZone:
+		return t.family + " with time zone"
+	default:
+		return t.family
+	}
+}
+
+// matches reports whether a column of type actual can hold the values of
+// t. Length and time zone are only compared when strict, since SQLite
+// accepts but ignores them.
+func (t columnType) matches(actual columnType, strict bool) bool {
+	if t.family != actual.family {
+		return false
+	}
+	return !strict || (t.length == actual.length && t.timeZone == actual.timeZone)
+}
+
+// parseType reads a column type from its SQL definition, e.g.
+// "varchar(255) NOT NULL" or "timestamp with time zone".
+func parseType(sqlType string) columnType {
+	t := strings.ToLower(strings.TrimSpace(sqlType))
+	for _, modifier := range columnModifiers {
+		if i := strings.Index(t, modifier); i >= 0 {
+			t = t[:i]
+		}
+	}
+
+	ct := columnType{family: family(typeLength.ReplaceAllString(t, ""))}
+	switch ct.family {
+	case "text":
+		if m := typeLength.FindStringSubmatch(t); m != nil {
+			ct.length, _ = strconv.ParseInt(strings.TrimSpace(m[1]), 10, 64)
+		}
+	case "timestamp":
+		ct.timeZone = strings.HasSuffix(t, "tz") || strings.Contains(t, "with time zone")
+	}
+	return ct
+}
+
+// databaseType reads the type of a live column. Postgres reports character
+// columns without their length, which comes from the column metadata instead.
+func databaseType(ct gorm.ColumnType) columnType {
+	t := parseType(ct.DatabaseTypeName())
+	if t.family == "text" && t.length == 0 {
+		if length, ok := ct.Length(); ok && length > 0 {
+			t.length = length
+		}
+	}
+	return t
+}
+
+// family reduces a column type to a dialect neutral family so that aliases
+// such as int4/integer or varchar/character varying compare equal.
+func family(t string) string {
+	t = strings.TrimSpace(t)
+	switch {
+	case strings.HasPrefix(t, "uuid"):
+		return "uuid"
+	case strings.Contains(t, "int") || t == "serial" || t == "bigserial":
+		return "integer"
+	case strings.Contains(t, "char") || t == "text" || t == "string":
+		return "text"
+	case strings.HasPrefix(t, "timestamp") || t == "datetime" || t == "date":
+		return "timestamp"
+	case strings.HasPrefix(t, "bool"):
+		return "boolean"
+	case strings.HasPrefix(t, "numeric") || strings.HasPrefix(t, "decimal") || strings.HasPrefix(t, "float") ||
+		strings.HasPrefix(t, "double") || t == "real":
+		return "numeric"
+	case t == "bytea" || t == "blob":
+		return "binary"
+	default:
+		return t
+	}
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/internal/config"
+)
+
+const defaultTimeout = 30 * time.Second
+
+type hook struct {
+	name string
+	fn   func(context.Context) error
+}
+
+// Server wraps http.Server with configured timeouts and an ordered shutdown sequence.
+type Server struct {
+	http       *http.Server
+	log        *slog.Logger
+	timeout    time.Duration
+	drainDelay time.Duration
+	draining   []func()
+	hooks      []hook
+}
+
+func New(cfg config.ServerConfig, handler http.Handler, log *slog.Logger) *Server {
+	port := cfg.Port
+	if port == "" {
+		port = "8080"
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Server{
+		http: &http.Server{
+			Addr:              net.JoinHostPort(cfg.Host, port),
+			Handler:           handler,
+			ReadTimeout:       timeout,
+			ReadHeaderTimeout: timeout,
+			WriteTimeout:      timeout,
+			IdleTimeout:       2 * timeout,
+		},
+		log:        log,
+		timeout:    timeout,
+		drainDelay: cfg.DrainDelay,
+	}
+}
+
+// OnDrain registers a function to run as soon as shutdown begins, before
+// the drain delay, such as failing the readiness probe.
+func (s *Server) OnDrain(fn func()) {
+	s.draining = append(s.draining, fn)
+}
+
+// OnStop registers a function to run once the server stops accepting
+// connections, before it waits for in-flight requests, such as ending the
+// event streams that would otherwise hold up the drain until it times out.
+func (s *Server) OnStop(fn func()) {
+	s.http.RegisterOnShutdown(fn)
+}
+
+// OnShutdown registers a function to run after the HTTP server has drained,
+// with a context that expires after the server timeout. Hooks run in
+// reverse registration order, so resources registered first
+// (such as the database pool) are released last.
+func (s *Server) OnShutdown(name string, fn func(context.Context) error) {
+	s.hooks = append(s.hooks, hook{name: name, fn: fn})
+}
+
+// Run listens on the configured address and serves until ctx is
+// cancelled, as Serve describes.
+func (s *Server) Run(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return fmt.Errorf("server failed: %w", err)
+	}
+	return s.Serve(ctx, lis)
+}
+
+// Serve serves on lis until ctx is cancelled, then runs the OnDrain
+// functions, keeps serving for the drain delay, stops accepting
+// connections, waits for in-flight requests and runs the shutdown hooks.
+// Waiting for requests and each hook get their own server timeout, so that
+// a slow drain doesn't leave the hooks no time to release their resources.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	errCh := make(chan error, 1)
+	go func() {
+		s.log.Info("starting server", slog.String("addr", lis.Addr().String()))
+		if err := s.http.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	var serveErr error
+	select {
+	case serveErr = <-errCh:
+	case <-ctx.Done():
+		for _, fn := range s.draining {
+			fn()
+		}
+		if s.drainDelay > 0 {
+			s.log.Info("shutdown signal received, waiting for load balancers to stop routing",
+				slog.Duration("drain_delay", s.drainDelay))
+			select {
+			case serveErr = <-errCh:
+			case <-time.After(s.drainDelay):
+			}
+		}
+		s.log.Info("draining requests")
+	}
+
+	s.withTimeout(func(ctx context.Context) {
+		if err := s.http.Shutdown(ctx); err != nil {
+			s.log.Error("failed to drain http server", slog.Any("error", err))
+		}
+	})
+
+	for i := len(s.hooks) - 1; i >= 0; i-- {
+		h := s.hooks[i]
+		s.withTimeout(func(ctx context.Context) {
+			if err := h.fn(ctx); err != nil {
+				s.log.Error("shutdown hook failed", slog.String("hook", h.name), slog.Any("error", err))
+			}
+		})
+	}
+
+	if serveErr != nil {
+		return fmt.Errorf("server failed: %w", serveErr)
+	}
+	s.log.Info("server stopped")
+	return nil
+}
+
+// withTimeout runs fn with a context that expires after the server timeout.
+func (s *Server) withTimeout(fn func(context.Context)) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	fn(ctx)
+}
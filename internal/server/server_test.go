@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/health"
+)
+
+func TestShutdownDrains(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := health.NewChecker()
+	started, release := make(chan struct{}), make(chan struct{})
+	router := gin.New()
+	router.GET("/readyz", checker.Readiness)
+	router.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	router.GET("/slow", func(c *gin.Context) {
+		close(started)
+		<-release
+	})
+
+	const timeout = 200 * time.Millisecond
+	srv := New(config.ServerConfig{Timeout: timeout, DrainDelay: 500 * time.Millisecond}, router,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	srv.OnDrain(checker.SetShuttingDown)
+	var (
+		mu    sync.Mutex
+		hooks []string
+	)
+	for _, name := range []string{"database", "grpc"} {
+		srv.OnShutdown(name, func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if err := ctx.Err(); err != nil {
+				t.Errorf("hook %s started with an expired context: %v", name, err)
+			}
+			hooks = append(hooks, name)
+			return nil
+		})
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, lis) }()
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	get := func(path string) int {
+		t.Helper()
+		resp, err := client.Get("http://" + lis.Addr().String() + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := get("/readyz"); code != http.StatusOK {
+		t.Fatalf("/readyz = %d before shutdown", code)
+	}
+
+	// A request outliving the timeout holds up draining until it expires.
+	defer close(release)
+	go client.Get("http://" + lis.Addr().String() + "/slow")
+	<-started
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz = %d during the drain delay, want 503", code)
+	}
+	if code := get("/ping"); code != http.StatusOK {
+		t.Errorf("/ping = %d during the drain delay, want it still served", code)
+	}
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatalf("Serve: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve didn't return")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(hooks) != 2 || hooks[0] != "grpc" || hooks[1] != "database" {
+		t.Errorf("hooks ran as %v, want grpc then database", hooks)
+	}
+	if _, err := net.DialTimeout("tcp", lis.Addr().String(), time.Second); err == nil {
+		t.Error("connections are still accepted after shutdown")
+	}
+}
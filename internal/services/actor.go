@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type actorKey struct{}
+
+// WithActor returns a context recording the user making changes through
+// the services, so that they aren't notified of their own changes.
+func WithActor(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// actorFrom returns the user recorded by WithActor, or nil if the changes
+// are made anonymously.
+func actorFrom(ctx context.Context) *uuid.UUID {
+	if userID, ok := ctx.Value(actorKey{}).(uuid.UUID); ok {
+		return &userID
+	}
+	return nil
+}
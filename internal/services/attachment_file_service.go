@@ -0,0 +1,106 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/storage"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AttachmentFileService uploads and downloads the files of attachments.
+// AttachmentService only keeps their metadata.
+type AttachmentFileService struct {
+	attachments *AttachmentService
+	tasks       *TaskService
+	store       storage.Store
+}
+
+func NewAttachmentFileService(attachments *AttachmentService, tasks *TaskService, store storage.Store) *AttachmentFileService {
+	return &AttachmentFileService{attachments: attachments, tasks: tasks, store: store}
+}
+
+// Upload stores the file read from r and attaches it to the task. The
+// content type is the one given, unless it is missing or generic, then
+// the one the file name's extension or, failing that, the content
+// suggests. It must be one of validation.AllowedAttachmentTypes.
+func (s *AttachmentFileService) Upload(ctx context.Context, taskID uuid.UUID, name, contentType string, r io.Reader) (_ *models.Attachment, err error) {
+	ctx, span := startSpan(ctx, "AttachmentFileService.Upload", attribute.String("task.id", taskID.String()))
+	defer endSpan(span, &err)
+
+	if _, err := s.tasks.GetTaskByID(ctx, taskID.String()); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReaderSize(r, 512)
+	contentType = detectContentType(name, contentType, br)
+	if !slices.Contains(validation.AllowedAttachmentTypes, contentType) {
+		return nil, apperrors.Validation("file type is not accepted",
+			apperrors.FieldError{Field: "file", Message: contentType + " is not an accepted type"})
+	}
+
+	path, err := s.store.Save(ctx, name, br)
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+	attachment, err := s.attachments.CreateAttachment(models.AttachmentInput{
+		FileName:    filepath.Base(name),
+		FilePath:    path,
+		ContentType: contentType,
+		TaskID:      taskID,
+	})
+	if err != nil {
+		if err := s.store.Delete(ctx, path); err != nil {
+			logger.FromContext(ctx).WarnContext(ctx, "failed to delete stored file",
+				slog.String("path", path), slog.Any("error", err))
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// Open returns the attachment and its file's content, which the caller
+// must close. Attachments whose file isn't in the store, such as those
+// registered with a path elsewhere, are reported as not found.
+func (s *AttachmentFileService) Open(ctx context.Context, id uuid.UUID) (_ *models.Attachment, _ io.ReadCloser, err error) {
+	ctx, span := startSpan(ctx, "AttachmentFileService.Open", attribute.String("attachment.id", id.String()))
+	defer endSpan(span, &err)
+
+	attachment, err := s.attachments.GetAttachment(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.store.Open(ctx, attachment.FilePath)
+	if errors.Is(err, storage.ErrNotStored) {
+		return nil, nil, apperrors.NotFound("attachment_file").Wrap(err)
+	}
+	if err != nil {
+		return nil, nil, apperrors.Internal(err)
+	}
+	return attachment, content, nil
+}
+
+// detectContentType returns the media type of an uploaded file, peeking at
+// its content if need be.
+func detectContentType(name, declared string, content *bufio.Reader) string {
+	if mediaType, _, err := mime.ParseMediaType(declared); err == nil && mediaType != "application/octet-stream" {
+		return mediaType
+	}
+	if mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(name))); err == nil {
+		return mediaType
+	}
+	head, _ := content.Peek(512)
+	mediaType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	return mediaType
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/config"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RoleAdmin is the role of users who can administer the server.
+const RoleAdmin = "admin"
+
+// Caller is the user an access token was issued to.
+type Caller struct {
+	UserID    uuid.UUID
+	Role      string
+	SessionID uuid.UUID
+}
+
+// accessClaims are the claims of an access token. The subject is the user
+// and sid the session, which must still exist for the token to be valid.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	SessionID string `json:"sid"`
+	Role      string `json:"role"`
+}
+
+type AuthService struct {
+	repo repositories.SessionRepository
+	cfg  config.AuthConfig
+	now  func() time.Time
+}
+
+func NewAuthService(repo repositories.SessionRepository, cfg config.AuthConfig) *AuthService {
+	return &AuthService{repo: repo, cfg: cfg, now: time.Now}
+}
+
+// dummyHash is compared with the password of logins for unknown emails, so
+// that they take as long as those of users with a wrong password.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte(uuid.NewString()), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+// badCredentials doesn't say whether the email or the password was wrong,
+// so that logging in can't be used to find out who has an account.
+func badCredentials() error {
+	return apperrors.Unauthorized("invalid email or password")
+}
+
+// Signup creates a user with the credentials. The password is stored as a
+// bcrypt hash.
+func (s *AuthService) Signup(ctx context.Context, in models.Credentials) (_ *models.User, err error) {
+	ctx, span := startSpan(ctx, "AuthService.Signup")
+	defer endSpan(span, &err)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(in.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+	now := s.now()
+	user := &models.User{
+		ID:           uuid.New(),
+		Email:        strings.TrimSpace(in.Email),
+		PasswordHash: string(hash),
+		Role:         "user",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Login starts a session for the user with the credentials.
+func (s *AuthService) Login(ctx context.Context, in models.Credentials) (_ *models.TokenPair, err error) {
+	ctx, span := startSpan(ctx, "AuthService.Login")
+	defer endSpan(span, &err)
+
+	user, err := s.repo.GetUserByEmail(ctx, strings.TrimSpace(in.Email))
+	if apperrors.Is(err, apperrors.KindNotFound) {
+		bcrypt.CompareHashAndPassword(dummyHash(), []byte(in.Password))
+		return nil, badCredentials()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(in.Password)) != nil {
+		return nil, badCredentials()
+	}
+
+	refreshToken, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	session := &models.Session{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: now.Add(s.cfg.RefreshTTL),
+		CreatedAt: now,
+	}
+	if err := s.repo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.tokens(user, session.ID, refreshToken)
+}
+
+// Refresh issues new tokens for the session the refresh token belongs to.
+// The refresh token is replaced, so each one can be used once.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (_ *models.TokenPair, err error) {
+	ctx, span := startSpan(ctx, "AuthService.Refresh")
+	defer endSpan(span, &err)
+
+	session, err := s.session(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("user.id", session.UserID.String()))
+	user, err := s.repo.GetUser(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	next, hash, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.Rotate(ctx, session.ID, session.TokenHash, hash, s.now().Add(s.cfg.RefreshTTL))
+	if apperrors.Is(err, apperrors.KindNotFound) {
+		return nil, apperrors.Unauthorized("refresh token is invalid or expired")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.tokens(user, session.ID, next)
+}
+
+// Logout ends the session, revoking its access and refresh tokens.
+func (s *AuthService) Logout(ctx context.Context, sessionID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "AuthService.Logout", attribute.String("session.id", sessionID.String()))
+	defer endSpan(span, &err)
+
+	err = s.repo.Delete(ctx, sessionID.String())
+	if apperrors.Is(err, apperrors.KindNotFound) {
+		return nil
+	}
+	return err
+}
+
+// Authenticate returns the caller an access token was issued to. Tokens of
+// sessions that have ended are rejected.
+func (s *AuthService) Authenticate(ctx context.Context, accessToken string) (*Caller, error) {
+	claims := &accessClaims{}
+	_, err := jwt.ParseWithClaims(accessToken, claims, func(*jwt.Token) (any, error) {
+		return []byte(s.cfg.Secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now))
+	if err != nil {
+		return nil, apperrors.Unauthorized("access token is invalid or expired").Wrap(err)
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, apperrors.Unauthorized("access token is invalid or expired").Wrap(err)
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, apperrors.Unauthorized("access token is invalid or expired").Wrap(err)
+	}
+
+	session, err := s.repo.GetByID(ctx, sessionID.String())
+	if apperrors.Is(err, apperrors.KindNotFound) || err == nil && !session.ExpiresAt.After(s.now()) {
+		return nil, apperrors.Unauthorized("session has ended")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Caller{UserID: userID, Role: claims.Role, SessionID: sessionID}, nil
+}
+
+// session returns the live session the refresh token belongs to.
+func (s *AuthService) session(ctx context.Context, refreshToken string) (*models.Session, error) {
+	session, err := s.repo.GetByTokenHash(ctx, hashToken(refreshToken))
+	if apperrors.Is(err, apperrors.KindNotFound) || err == nil && !session.ExpiresAt.After(s.now()) {
+		return nil, apperrors.Unauthorized("refresh token is invalid or expired")
+	}
+	return session, err
+}
+
+func (s *AuthService) tokens(user *models.User, sessionID uuid.UUID, refreshToken string) (*models.TokenPair, error) {
+	now := s.now()
+	claims := accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.AccessTTL)),
+		},
+		SessionID: sessionID.String(),
+		Role:      user.Role,
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.Secret))
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+	return &models.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.cfg.AccessTTL / time.Second),
+	}, nil
+}
+
+// newRefreshToken returns a random refresh token and the hash stored for it.
+func newRefreshToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", apperrors.Internal(err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetBoard returns every column of the board with the page of its tasks
+// selected by query.
+func (s *TaskService) GetBoard(ctx context.Context, query models.BoardQuery) (_ *models.Board, err error) {
+	ctx, span := startSpan(ctx, "TaskService.GetBoard",
+		attribute.Int("page", query.Page), attribute.Int("limit", query.Limit))
+	defer endSpan(span, &err)
+
+	columns, err := s.boardRepo.GetColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]models.BoardColumn, len(columns))
+	for _, column := range columns {
+		settings[column.Status] = column
+	}
+	counts, err := s.taskRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &models.Board{Columns: make([]models.BoardColumnTasks, 0, len(models.TaskStatuses))}
+	for _, status := range models.TaskStatuses {
+		column, ok := settings[status]
+		if !ok {
+			column = models.BoardColumn{Status: status}
+		}
+		tasks, err := s.taskRepo.GetColumn(ctx, status, query.Offset(), query.Limit)
+		if err != nil {
+			return nil, err
+		}
+		board.Columns = append(board.Columns, models.BoardColumnTasks{BoardColumn: column, Total: counts[status], Tasks: tasks})
+	}
+	return board, nil
+}
+
+// GetBoardColumn returns one column of the board with a page of its tasks.
+func (s *TaskService) GetBoardColumn(ctx context.Context, status string, query models.BoardQuery) (_ *models.BoardColumnTasks, err error) {
+	ctx, span := startSpan(ctx, "TaskService.GetBoardColumn", attribute.String("task.status", status),
+		attribute.Int("page", query.Page), attribute.Int("limit", query.Limit))
+	defer endSpan(span, &err)
+
+	column, err := s.boardRepo.GetColumn(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.taskRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.taskRepo.GetColumn(ctx, status, query.Offset(), query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BoardColumnTasks{BoardColumn: *column, Total: counts[status], Tasks: tasks}, nil
+}
+
+// UpdateBoardColumn changes the settings of a column. Lowering the WIP limit
+// below the number of tasks in the column is allowed; it only stops tasks
+// from being added or moved in.
+func (s *TaskService) UpdateBoardColumn(ctx context.Context, status string, input models.BoardColumnInput) (_ *models.BoardColumn, err error) {
+	ctx, span := startSpan(ctx, "TaskService.UpdateBoardColumn", attribute.String("task.status", status))
+	defer endSpan(span, &err)
+
+	column := &models.BoardColumn{Status: status, WIPLimit: input.WIPLimit}
+	if err := s.boardRepo.UpdateColumn(ctx, column); err != nil {
+		return nil, err
+	}
+	return column, nil
+}
+
+// MoveTask places a task in a column, next to the neighbours given in move.
+// The target column's WIP limit applies when the task changes columns.
+func (s *TaskService) MoveTask(ctx context.Context, id string, move models.TaskMove) (_ *models.Task, err error) {
+	ctx, span := startSpan(ctx, "TaskService.MoveTask",
+		attribute.String("task.id", id), attribute.String("task.status", move.Status))
+	defer endSpan(span, &err)
+
+	column, err := s.boardRepo.GetColumn(ctx, move.Status)
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.previous(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.taskRepo.Move(ctx, id, move, column.WIPLimit)
+	if err != nil {
+		return nil, err
+	}
+	s.events.publish(TaskEvent{Type: TaskUpdated, Task: *task})
+	s.notify(ctx, before, *task)
+	return task, nil
+}
+
+// wipLimits returns the WIP limit of each column that has one.
+func (s *TaskService) wipLimits(ctx context.Context) (map[string]int, error) {
+	columns, err := s.boardRepo.GetColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limits := make(map[string]int, len(columns))
+	for _, column := range columns {
+		if column.WIPLimit != nil {
+			limits[column.Status] = *column.WIPLimit
+		}
+	}
+	return limits, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type CommentService struct {
+	commentRepo   repositories.CommentRepository
+	notifications *NotificationService
+}
+
+// NewCommentService returns a CommentService sending notifications about
+// new comments through notifications, if it isn't nil.
+func NewCommentService(commentRepo repositories.CommentRepository, notifications *NotificationService) *CommentService {
+	return &CommentService{commentRepo: commentRepo, notifications: notifications}
+}
+
+func (s *CommentService) CreateComment(ctx context.Context, comment *models.Comment) (err error) {
+	ctx, span := startSpan(ctx, "CommentService.CreateComment",
+		attribute.String("comment.id", comment.ID.String()), attribute.String("task.id", comment.TaskID.String()))
+	defer endSpan(span, &err)
+
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return err
+	}
+	if s.notifications != nil {
+		// The comment has been added, so failing to notify it is only logged.
+		if err := s.notifications.commentAdded(ctx, *comment); err != nil {
+			logger.FromContext(ctx).WarnContext(ctx, "failed to send comment notifications",
+				slog.String("comment_id", comment.ID.String()), slog.Any("error", err))
+		}
+	}
+	return nil
+}
+
+func (s *CommentService) GetComment(ctx context.Context, id string) (_ *models.Comment, err error) {
+	ctx, span := startSpan(ctx, "CommentService.GetComment", attribute.String("comment.id", id))
+	defer endSpan(span, &err)
+
+	return s.commentRepo.GetByID(ctx, id)
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "CommentService.DeleteComment", attribute.String("comment.id", id))
+	defer endSpan(span, &err)
+
+	return s.commentRepo.Delete(ctx, id)
+}
+
+// GetTaskComments returns the comments on the given tasks, oldest first.
+func (s *CommentService) GetTaskComments(ctx context.Context, taskIDs []string) (_ []models.Comment, err error) {
+	ctx, span := startSpan(ctx, "CommentService.GetTaskComments", attribute.Int("tasks", len(taskIDs)))
+	defer endSpan(span, &err)
+
+	return s.commentRepo.GetByTaskIDs(ctx, taskIDs)
+}
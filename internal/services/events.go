@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+// Task event types.
+const (
+	TaskCreated = "created"
+	TaskUpdated = "updated"
+	TaskDeleted = "deleted"
+)
+
+// TaskEvent describes a change made through a TaskService. Deleted tasks
+// only have their ID set.
+type TaskEvent struct {
+	Type string
+	Task models.Task
+}
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it.
+const subscriberBuffer = 64
+
+// broker fans events out to subscribers within this process.
+type broker[T any] struct {
+	mu   sync.Mutex
+	subs map[chan T]struct{}
+}
+
+func newBroker[T any]() *broker[T] {
+	return &broker[T]{subs: make(map[chan T]struct{})}
+}
+
+// subscribe returns a channel receiving every event published until ctx is
+// done, when the channel is closed.
+func (b *broker[T]) subscribe(ctx context.Context) <-chan T {
+	ch := make(chan T, subscriberBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+		close(ch)
+	}()
+	return ch
+}
+
+// publish never blocks: subscribers that aren't keeping up miss the event.
+func (b *broker[T]) publish(event T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/inbound"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/storage"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxInboundAttachments is the number of files stored per message; later
+// ones are skipped.
+const maxInboundAttachments = 20
+
+// Limits of the task and comment fields filled from a message, which is
+// cut to fit.
+const (
+	maxTitleLength   = 255
+	maxContentLength = 10000
+)
+
+// InboundEmailService is the email gateway. Messages sent to its address
+// by known users become tasks owned by the sender, and messages sent to a
+// task's reply address become comments on the task. The sender is only
+// trusted when a mail server it trusts vouches for the From domain.
+type InboundEmailService struct {
+	repo        repositories.InboundEmailRepository
+	tasks       *TaskService
+	comments    *CommentService
+	attachments *AttachmentService
+	store       storage.Store
+	// address is the gateway's address. Replies go to local+<task ID>@domain.
+	address, local, domain string
+	// authservID names the mail server whose Authentication-Results are
+	// trusted.
+	authservID string
+}
+
+// NewInboundEmailService returns the gateway for address, a plain address
+// such as "support@tasks.example.com", trusting the Authentication-Results
+// of the mail server named authservID, or the address's domain if empty.
+func NewInboundEmailService(repo repositories.InboundEmailRepository, tasks *TaskService, comments *CommentService,
+	attachments *AttachmentService, store storage.Store, address, authservID string) *InboundEmailService {
+	address = strings.ToLower(address)
+	at := strings.LastIndexByte(address, '@')
+	if authservID == "" {
+		authservID = address[at+1:]
+	}
+	return &InboundEmailService{
+		repo:        repo,
+		tasks:       tasks,
+		comments:    comments,
+		attachments: attachments,
+		store:       store,
+		address:     address,
+		local:       address[:at],
+		domain:      address[at+1:],
+		authservID:  strings.ToLower(authservID),
+	}
+}
+
+// ReplyAddress returns the address whose messages become comments on the
+// task.
+func (s *InboundEmailService) ReplyAddress(taskID uuid.UUID) string {
+	return s.local + "+" + taskID.String() + "@" + s.domain
+}
+
+// Accepts reports whether messages to the recipient are for the gateway.
+func (s *InboundEmailService) Accepts(rcpt string) bool {
+	_, ok := s.replyTask(rcpt)
+	return ok || strings.EqualFold(rcpt, s.address)
+}
+
+// replyTask returns the task whose reply address rcpt is.
+func (s *InboundEmailService) replyTask(rcpt string) (uuid.UUID, bool) {
+	local, domain, ok := cutLast(strings.ToLower(rcpt), "@")
+	if !ok || domain != s.domain {
+		return uuid.Nil, false
+	}
+	id, ok := strings.CutPrefix(local, s.local+"+")
+	if !ok {
+		return uuid.Nil, false
+	}
+	taskID, err := uuid.Parse(id)
+	return taskID, err == nil
+}
+
+// Receive turns a raw RFC 5322 message into a task, or into a comment if it
+// is sent to a task's reply address or replies to a message that made a
+// task. rcpts are the envelope recipients; the To and Cc addresses are used
+// without them. Messages without an SPF, DKIM or DMARC pass for their From
+// domain are rejected. A message received before isn't turned into
+// anything again.
+func (s *InboundEmailService) Receive(ctx context.Context, raw []byte, rcpts []string) (_ *models.InboundEmailResult, err error) {
+	ctx, span := startSpan(ctx, "InboundEmailService.Receive", attribute.Int("message.size", len(raw)))
+	defer endSpan(span, &err)
+
+	msg, err := inbound.Parse(raw)
+	if err != nil {
+		return nil, apperrors.Validation(err.Error())
+	}
+	if len(rcpts) == 0 {
+		rcpts = msg.To
+	}
+	var taskID *uuid.UUID
+	addressed := false
+	for _, rcpt := range rcpts {
+		if id, ok := s.replyTask(rcpt); ok {
+			taskID, addressed = &id, true
+			break
+		}
+		addressed = addressed || strings.EqualFold(rcpt, s.address)
+	}
+	if !addressed {
+		return nil, apperrors.Validation("the message isn't addressed to " + s.address)
+	}
+	if !msg.Authenticated(s.authservID) {
+		return nil, apperrors.Forbidden("the sender " + msg.From + " failed SPF, DKIM and DMARC checks")
+	}
+
+	sender, err := s.repo.GetSender(ctx, msg.From)
+	if apperrors.Is(err, apperrors.KindNotFound) {
+		return nil, apperrors.Forbidden("the sender " + msg.From + " is not a user")
+	}
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("user.id", sender.ID.String()))
+
+	if msg.MessageID != "" {
+		received, err := s.repo.GetByMessageIDs(ctx, []string{msg.MessageID})
+		if err != nil {
+			return nil, err
+		}
+		if len(received) > 0 {
+			return s.duplicate(ctx, received[0])
+		}
+	}
+	if taskID == nil && len(msg.References) > 0 {
+		thread, err := s.repo.GetByMessageIDs(ctx, msg.References)
+		if err != nil {
+			return nil, err
+		}
+		if len(thread) > 0 {
+			taskID = &thread[len(thread)-1].TaskID
+		}
+	}
+
+	ctx = WithActor(ctx, sender.ID)
+	result := &models.InboundEmailResult{Attachments: []models.Attachment{}, SkippedAttachments: []string{}}
+	files := msg.Attachments
+	if len(files) > maxInboundAttachments {
+		for _, file := range files[maxInboundAttachments:] {
+			result.SkippedAttachments = append(result.SkippedAttachments, file.FileName)
+		}
+		files = files[:maxInboundAttachments]
+	}
+	files = slices.DeleteFunc(files, func(file inbound.Attachment) bool {
+		if slices.Contains(validation.AllowedAttachmentTypes, file.ContentType) {
+			return false
+		}
+		result.SkippedAttachments = append(result.SkippedAttachments, file.FileName)
+		return true
+	})
+
+	if taskID != nil {
+		result.Task, err = s.tasks.GetTaskByID(ctx, taskID.String())
+		if err != nil {
+			return nil, err
+		}
+		content := inbound.Reply(msg.Text)
+		if content == "" && len(files) == 0 {
+			return nil, apperrors.Validation("the reply has no text or attachments")
+		}
+		if content == "" {
+			content = "Attached " + fileNames(files)
+		}
+		comment := models.CommentInput{Content: truncate(content, maxContentLength)}.NewComment(result.Task.ID, &sender.ID)
+		result.Comment = &comment
+	} else {
+		title := truncate(strings.Join(strings.Fields(msg.Subject), " "), maxTitleLength)
+		if title == "" {
+			title = "(no subject)"
+		}
+		task := models.CreateTaskInput{Title: title, Description: truncate(msg.Text, maxContentLength)}.NewTask(&sender.ID)
+		result.Task = &task
+	}
+	result.ReplyTo = s.ReplyAddress(result.Task.ID)
+
+	// Files are stored first so that a failure leaves nothing behind.
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		path, err := s.store.Save(ctx, file.FileName, bytes.NewReader(file.Data))
+		if err != nil {
+			s.discard(ctx, paths)
+			return nil, apperrors.Internal(err)
+		}
+		paths = append(paths, path)
+	}
+	if result.Comment != nil {
+		err = s.comments.CreateComment(ctx, result.Comment)
+	} else {
+		err = s.tasks.CreateTask(ctx, result.Task)
+	}
+	if err != nil {
+		s.discard(ctx, paths)
+		return nil, err
+	}
+
+	// The message has been handled, so failures from here on are only
+	// logged; reporting them would have the message sent again.
+	log := logger.FromContext(ctx)
+	if msg.MessageID != "" && len(msg.MessageID) <= 255 {
+		record := &models.InboundEmail{
+			ID:         uuid.New(),
+			MessageID:  msg.MessageID,
+			UserID:     sender.ID,
+			TaskID:     result.Task.ID,
+			ReceivedAt: time.Now(),
+		}
+		if result.Comment != nil {
+			record.CommentID = &result.Comment.ID
+		}
+		if err := s.repo.Create(ctx, record); err != nil {
+			log.WarnContext(ctx, "failed to record inbound email",
+				slog.String("message_id", msg.MessageID), slog.Any("error", err))
+		}
+	}
+	for i, file := range files {
+		attachment, err := s.attachments.CreateAttachment(models.AttachmentInput{
+			FileName:    file.FileName,
+			FilePath:    paths[i],
+			ContentType: file.ContentType,
+			TaskID:      result.Task.ID,
+		})
+		if err != nil {
+			log.WarnContext(ctx, "failed to add inbound email attachment",
+				slog.String("task_id", result.Task.ID.String()), slog.Any("error", err))
+			s.discard(ctx, paths[i:i+1])
+			result.SkippedAttachments = append(result.SkippedAttachments, file.FileName)
+			continue
+		}
+		result.Attachments = append(result.Attachments, *attachment)
+	}
+	return result, nil
+}
+
+// duplicate describes what was made of a message received before.
+func (s *InboundEmailService) duplicate(ctx context.Context, received models.InboundEmail) (*models.InboundEmailResult, error) {
+	task, err := s.tasks.GetTaskByID(ctx, received.TaskID.String())
+	if err != nil {
+		return nil, err
+	}
+	result := &models.InboundEmailResult{
+		Task:               task,
+		Attachments:        []models.Attachment{},
+		SkippedAttachments: []string{},
+		ReplyTo:            s.ReplyAddress(task.ID),
+		Duplicate:          true,
+	}
+	if received.CommentID != nil {
+		if result.Comment, err = s.comments.GetComment(ctx, received.CommentID.String()); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// discard deletes stored files that won't be attached after all.
+func (s *InboundEmailService) discard(ctx context.Context, paths []string) {
+	for _, path := range paths {
+		if err := s.store.Delete(ctx, path); err != nil {
+			logger.FromContext(ctx).WarnContext(ctx, "failed to delete stored file",
+				slog.String("path", path), slog.Any("error", err))
+		}
+	}
+}
+
+func fileNames(files []inbound.Attachment) string {
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.FileName
+	}
+	return strings.Join(names, ", ")
+}
+
+// truncate cuts s to at most n characters.
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,372 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type NotificationService struct {
+	repo        repositories.NotificationRepository
+	taskRepo    repositories.TaskRepository
+	commentRepo repositories.CommentRepository
+	push        *broker[models.Notification]
+
+	mu sync.Mutex
+	// pushed maps the DedupKey of notifications pushed without being
+	// stored, which can't be told apart from new ones otherwise, to the
+	// time they can be forgotten.
+	pushed map[string]time.Time
+}
+
+func NewNotificationService(repo repositories.NotificationRepository, taskRepo repositories.TaskRepository, commentRepo repositories.CommentRepository) *NotificationService {
+	return &NotificationService{
+		repo:        repo,
+		taskRepo:    taskRepo,
+		commentRepo: commentRepo,
+		push:        newBroker[models.Notification](),
+		pushed:      make(map[string]time.Time),
+	}
+}
+
+// Subscribe returns a channel receiving the user's push notifications until
+// ctx is done. Like task events, only notifications sent by this server
+// instance are seen, and they are dropped for subscribers that fall
+// behind; the inbox keeps them.
+func (s *NotificationService) Subscribe(ctx context.Context, userID uuid.UUID) <-chan models.Notification {
+	all := s.push.subscribe(ctx)
+	mine := make(chan models.Notification, subscriberBuffer)
+	go func() {
+		defer close(mine)
+		for n := range all {
+			if n.UserID != userID {
+				continue
+			}
+			select {
+			case mine <- n:
+			default:
+			}
+		}
+	}()
+	return mine
+}
+
+// ListNotifications returns a page of the user's notifications, newest
+// first.
+func (s *NotificationService) ListNotifications(ctx context.Context, userID uuid.UUID, query models.NotificationListQuery) (_ []models.Notification, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.ListNotifications", attribute.String("user.id", userID.String()),
+		attribute.Int("page", query.Page), attribute.Int("limit", query.Limit))
+	defer endSpan(span, &err)
+
+	return s.repo.GetByUserID(ctx, userID.String(), query.Unread, query.Offset(), query.Limit)
+}
+
+// CountUnread returns the number of the user's unread notifications.
+func (s *NotificationService) CountUnread(ctx context.Context, userID uuid.UUID) (_ int, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.CountUnread", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	return s.repo.CountUnread(ctx, userID.String())
+}
+
+func (s *NotificationService) GetNotification(ctx context.Context, id string) (_ *models.Notification, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.GetNotification", attribute.String("notification.id", id))
+	defer endSpan(span, &err)
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// MarkRead marks the notification read, unless it already is.
+func (s *NotificationService) MarkRead(ctx context.Context, notification *models.Notification) (err error) {
+	ctx, span := startSpan(ctx, "NotificationService.MarkRead", attribute.String("notification.id", notification.ID.String()))
+	defer endSpan(span, &err)
+
+	if notification.ReadAt != nil {
+		return nil
+	}
+	now := time.Now()
+	notification.ReadAt = &now
+	return s.repo.Update(ctx, notification)
+}
+
+// MarkAllRead marks the user's unread notifications read and returns how
+// many there were.
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) (_ int, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.MarkAllRead", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	return s.repo.MarkAllRead(ctx, userID.String(), time.Now())
+}
+
+// GetPreferences returns the user's setting for every notification type and
+// channel, in the order of NotificationTypes and NotificationChannels.
+func (s *NotificationService) GetPreferences(ctx context.Context, userID uuid.UUID) (_ []models.NotificationPreference, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.GetPreferences", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	return s.preferences(ctx, userID)
+}
+
+// SetPreferences changes the user's settings for the types and channels in
+// inputs, the last one winning for repeated ones, and returns every setting.
+func (s *NotificationService) SetPreferences(ctx context.Context, userID uuid.UUID, inputs []models.NotificationPreferenceInput) (_ []models.NotificationPreference, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.SetPreferences", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	now := time.Now()
+	changed := map[[2]string]models.NotificationPreference{}
+	for _, in := range inputs {
+		changed[[2]string{in.Type, in.Channel}] = models.NotificationPreference{
+			UserID:    userID,
+			Type:      in.Type,
+			Channel:   in.Channel,
+			Enabled:   *in.Enabled,
+			UpdatedAt: now,
+		}
+	}
+	preferences := make([]models.NotificationPreference, 0, len(changed))
+	for _, p := range changed {
+		preferences = append(preferences, p)
+	}
+	if err := s.repo.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+	return s.preferences(ctx, userID)
+}
+
+func (s *NotificationService) preferences(ctx context.Context, userID uuid.UUID) ([]models.NotificationPreference, error) {
+	stored, err := s.repo.GetPreferences(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	preferences := make([]models.NotificationPreference, 0, len(models.NotificationTypes)*len(models.NotificationChannels))
+	for _, kind := range models.NotificationTypes {
+		for _, channel := range models.NotificationChannels {
+			p := models.NotificationPreference{UserID: userID, Type: kind, Channel: channel, Enabled: true}
+			if i := slices.IndexFunc(stored, func(st models.NotificationPreference) bool {
+				return st.Type == kind && st.Channel == channel
+			}); i >= 0 {
+				p = stored[i]
+			}
+			preferences = append(preferences, p)
+		}
+	}
+	return preferences, nil
+}
+
+// RunDueSoon calls NotifyDueSoon every interval until ctx is done.
+func (s *NotificationService) RunDueSoon(ctx context.Context, window, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.NotifyDueSoon(ctx, window); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "failed to send due soon notifications", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NotifyDueSoon notifies the assignees of the tasks due within window, once
+// per task and due date, and returns how many were notified. Tasks already
+// overdue are left out. A notification that can't be sent is logged and
+// the others are still sent; it is tried again on the next call.
+func (s *NotificationService) NotifyDueSoon(ctx context.Context, window time.Duration) (_ int, err error) {
+	ctx, span := startSpan(ctx, "NotificationService.NotifyDueSoon")
+	defer endSpan(span, &err)
+
+	now := time.Now()
+	s.mu.Lock()
+	for key, until := range s.pushed {
+		if until.Before(now) {
+			delete(s.pushed, key)
+		}
+	}
+	s.mu.Unlock()
+
+	tasks, err := s.taskRepo.GetDueBetween(ctx, now, now.Add(window))
+	if err != nil {
+		return 0, err
+	}
+	notified := 0
+	for _, task := range tasks {
+		n := models.NewNotification(*task.UserID, models.NotificationDueSoon, task, nil)
+		key := fmt.Sprintf("%s:%s:%s:%d", models.NotificationDueSoon, task.ID, task.UserID, task.DueDate.Unix())
+		n.DedupKey = &key
+		sent, err := s.send(ctx, n, *task.DueDate)
+		if err != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "failed to send due soon notification",
+				slog.String("task_id", task.ID.String()), slog.Any("error", err))
+			continue
+		}
+		if sent {
+			notified++
+		}
+	}
+	span.SetAttributes(attribute.Int("notifications", notified))
+	return notified, nil
+}
+
+// taskChanged notifies the users concerned by a task created or updated by
+// the actor in ctx. before is nil for new tasks. Only the assignee hears of
+// status changes, and only newly added mentions are notified.
+func (s *NotificationService) taskChanged(ctx context.Context, before *models.Task, after models.Task) error {
+	r := newRecipients(actorFrom(ctx))
+	if after.UserID != nil {
+		switch {
+		case before == nil || before.UserID == nil || *before.UserID != *after.UserID:
+			r.add(*after.UserID, models.NotificationAssigned)
+		case before.Status != after.Status:
+			r.add(*after.UserID, models.NotificationStatusChanged)
+		}
+	}
+
+	var known []string
+	if before != nil {
+		known = models.Mentions(before.Description)
+	}
+	names := slices.DeleteFunc(models.Mentions(after.Description), func(name string) bool {
+		return slices.Contains(known, name)
+	})
+	if err := s.addMentioned(ctx, r, names); err != nil {
+		return err
+	}
+	return s.sendAll(ctx, r, after, nil)
+}
+
+// commentAdded notifies the users mentioned in a new comment, and the
+// assignee and earlier commenters of its task.
+func (s *NotificationService) commentAdded(ctx context.Context, comment models.Comment) error {
+	task, err := s.taskRepo.GetByID(ctx, comment.TaskID.String())
+	if err != nil {
+		return err
+	}
+	r := newRecipients(comment.UserID)
+	if err := s.addMentioned(ctx, r, models.Mentions(comment.Content)); err != nil {
+		return err
+	}
+
+	if task.UserID != nil {
+		r.add(*task.UserID, models.NotificationCommented)
+	}
+	comments, err := s.commentRepo.GetByTaskIDs(ctx, []string{task.ID.String()})
+	if err != nil {
+		return err
+	}
+	for _, earlier := range comments {
+		if earlier.UserID != nil {
+			r.add(*earlier.UserID, models.NotificationCommented)
+		}
+	}
+	return s.sendAll(ctx, r, *task, &comment.ID)
+}
+
+func (s *NotificationService) addMentioned(ctx context.Context, r *recipients, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	ids, err := s.repo.MentionedUsers(ctx, names)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		r.add(id, models.NotificationMentioned)
+	}
+	return nil
+}
+
+// sendAll notifies every recipient, logging those it fails to notify rather
+// than skipping the rest, and returns the failures joined.
+func (s *NotificationService) sendAll(ctx context.Context, r *recipients, task models.Task, commentID *uuid.UUID) error {
+	var errs []error
+	for _, to := range r.list {
+		n := models.NewNotification(to.userID, to.kind, task, r.actor)
+		n.CommentID = commentID
+		if _, err := s.send(ctx, n, time.Time{}); err != nil {
+			logger.FromContext(ctx).ErrorContext(ctx, "failed to send notification",
+				slog.String("task_id", task.ID.String()), slog.String("user_id", to.userID.String()),
+				slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("notifying %s: %w", to.userID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send delivers the notification on the channels the user has enabled for
+// its type and reports whether it was delivered on any. Notifications with
+// a DedupKey are delivered once; forget is when a push-only one's key may be
+// forgotten.
+func (s *NotificationService) send(ctx context.Context, n models.Notification, forget time.Time) (bool, error) {
+	preferences, err := s.preferences(ctx, n.UserID)
+	if err != nil {
+		return false, err
+	}
+	enabled := func(channel string) bool {
+		return slices.ContainsFunc(preferences, func(p models.NotificationPreference) bool {
+			return p.Type == n.Type && p.Channel == channel && p.Enabled
+		})
+	}
+	push := enabled(models.ChannelPush)
+
+	switch {
+	case enabled(models.ChannelInbox):
+		created, err := s.repo.CreateOnce(ctx, &n)
+		if err != nil || !created {
+			return false, err
+		}
+	case !push:
+		return false, nil
+	case n.DedupKey != nil:
+		s.mu.Lock()
+		_, seen := s.pushed[*n.DedupKey]
+		s.pushed[*n.DedupKey] = forget
+		s.mu.Unlock()
+		if seen {
+			return false, nil
+		}
+	}
+	if push {
+		s.push.publish(n)
+	}
+	return true, nil
+}
+
+// recipients collects the users to notify of a change, one notification
+// each, leaving out the actor who made it.
+type recipients struct {
+	actor *uuid.UUID
+	seen  map[uuid.UUID]bool
+	list  []recipient
+}
+
+type recipient struct {
+	userID uuid.UUID
+	kind   string
+}
+
+func newRecipients(actor *uuid.UUID) *recipients {
+	return &recipients{actor: actor, seen: map[uuid.UUID]bool{}}
+}
+
+// add notifies the user with the given type of notification unless they
+// already get one.
+func (r *recipients) add(userID uuid.UUID, kind string) {
+	if r.seen[userID] || r.actor != nil && *r.actor == userID {
+		return
+	}
+	r.seen[userID] = true
+	r.list = append(r.list, recipient{userID: userID, kind: kind})
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ProjectService struct {
+	projectRepo repositories.ProjectRepository
+}
+
+func NewProjectService(projectRepo repositories.ProjectRepository) *ProjectService {
+	return &ProjectService{projectRepo: projectRepo}
+}
+
+func (s *ProjectService) CreateProject(ctx context.Context, project *models.Project) (err error) {
+	ctx, span := startSpan(ctx, "ProjectService.CreateProject", attribute.String("project.id", project.ID.String()))
+	defer endSpan(span, &err)
+
+	return s.projectRepo.Create(ctx, project)
+}
+
+func (s *ProjectService) GetProject(ctx context.Context, id string) (_ *models.Project, err error) {
+	ctx, span := startSpan(ctx, "ProjectService.GetProject", attribute.String("project.id", id))
+	defer endSpan(span, &err)
+
+	return s.projectRepo.GetByID(ctx, id)
+}
+
+// ListProjects returns a page of the projects the caller owns or is a
+// member of, ordered by ID.
+func (s *ProjectService) ListProjects(ctx context.Context, query models.ProjectListQuery, caller uuid.UUID) (_ []models.Project, err error) {
+	ctx, span := startSpan(ctx, "ProjectService.ListProjects",
+		attribute.Int("page", query.Page), attribute.Int("limit", query.Limit))
+	defer endSpan(span, &err)
+
+	return s.projectRepo.GetByMember(ctx, caller.String(), query.Offset(), query.Limit)
+}
+
+// RenameProject renames the project, which only its owner can do.
+func (s *ProjectService) RenameProject(ctx context.Context, id string, input models.ProjectInput, caller uuid.UUID) (_ *models.Project, err error) {
+	ctx, span := startSpan(ctx, "ProjectService.RenameProject", attribute.String("project.id", id))
+	defer endSpan(span, &err)
+
+	project, err := s.ownedProject(ctx, id, caller)
+	if err != nil {
+		return nil, err
+	}
+	input.Apply(project)
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject deletes the project, which only its owner can do. Its
+// tasks are kept without a project.
+func (s *ProjectService) DeleteProject(ctx context.Context, id string, caller uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "ProjectService.DeleteProject", attribute.String("project.id", id))
+	defer endSpan(span, &err)
+
+	if _, err := s.ownedProject(ctx, id, caller); err != nil {
+		return err
+	}
+	return s.projectRepo.Delete(ctx, id)
+}
+
+// ListMembers returns the members of the project, earliest first.
+func (s *ProjectService) ListMembers(ctx context.Context, id string) (_ []models.ProjectMember, err error) {
+	ctx, span := startSpan(ctx, "ProjectService.ListMembers", attribute.String("project.id", id))
+	defer endSpan(span, &err)
+
+	if _, err := s.projectRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.projectRepo.GetMembers(ctx, id)
+}
+
+// AddMember makes the user a member of the project, which only its owner
+// can do.
+func (s *ProjectService) AddMember(ctx context.Context, id string, userID, caller uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "ProjectService.AddMember",
+		attribute.String("project.id", id), attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	if _, err := s.ownedProject(ctx, id, caller); err != nil {
+		return err
+	}
+	return s.projectRepo.AddMember(ctx, id, userID.String())
+}
+
+// RemoveMember ends the user's membership. The owner can remove anyone but
+// themselves, and members can leave.
+func (s *ProjectService) RemoveMember(ctx context.Context, id string, userID, caller uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "ProjectService.RemoveMember",
+		attribute.String("project.id", id), attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if userID == project.OwnerID {
+		return apperrors.Conflict("project_owner", "the owner can't leave the project")
+	}
+	if caller != project.OwnerID && caller != userID {
+		return apperrors.Forbidden("only the project's owner can remove other members")
+	}
+	return s.projectRepo.RemoveMember(ctx, id, userID.String())
+}
+
+func (s *ProjectService) ownedProject(ctx context.Context, id string, caller uuid.UUID) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.OwnerID != caller {
+		return nil, apperrors.Forbidden("only the project's owner can change it")
+	}
+	return project, nil
+}
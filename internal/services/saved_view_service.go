@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/validation"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type SavedViewService struct {
+	viewRepo    repositories.SavedViewRepository
+	projectRepo repositories.ProjectRepository
+	tasks       *TaskService
+}
+
+func NewSavedViewService(viewRepo repositories.SavedViewRepository, projectRepo repositories.ProjectRepository, tasks *TaskService) *SavedViewService {
+	return &SavedViewService{viewRepo: viewRepo, projectRepo: projectRepo, tasks: tasks}
+}
+
+// CreateView saves a new view. Its query must be one GET /tasks accepts,
+// and a shared view must name a project its owner is a member of.
+func (s *SavedViewService) CreateView(ctx context.Context, view *models.SavedView) (err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.CreateView",
+		attribute.String("saved_view.id", view.ID.String()), attribute.String("user.id", view.UserID.String()))
+	defer endSpan(span, &err)
+
+	if err := s.checkView(ctx, view); err != nil {
+		return err
+	}
+	return s.viewRepo.Create(ctx, view)
+}
+
+// GetView returns the view if the viewer can see it. Views the viewer
+// can't see are reported as not found.
+func (s *SavedViewService) GetView(ctx context.Context, id string, viewer uuid.UUID) (_ *models.SavedView, err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.GetView",
+		attribute.String("saved_view.id", id), attribute.String("user.id", viewer.String()))
+	defer endSpan(span, &err)
+
+	view, err := s.viewRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.projectRepo.GetMemberProjects(ctx, viewer.String())
+	if err != nil {
+		return nil, err
+	}
+	if !view.VisibleTo(viewer, projects) {
+		return nil, apperrors.NotFound("saved_view")
+	}
+	return view, nil
+}
+
+// ListViews returns the viewer's views and those other users shared with
+// the viewer's projects.
+func (s *SavedViewService) ListViews(ctx context.Context, viewer uuid.UUID) (_ []models.SavedView, err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.ListViews", attribute.String("user.id", viewer.String()))
+	defer endSpan(span, &err)
+
+	return s.visibleViews(ctx, viewer)
+}
+
+// UpdateView saves an edited view, whose query and sharing must still be
+// valid.
+func (s *SavedViewService) UpdateView(ctx context.Context, view *models.SavedView) (err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.UpdateView", attribute.String("saved_view.id", view.ID.String()))
+	defer endSpan(span, &err)
+
+	if err := s.checkView(ctx, view); err != nil {
+		return err
+	}
+	return s.viewRepo.Update(ctx, view)
+}
+
+// DeleteView deletes the view. Users who had it as their default are left
+// without one.
+func (s *SavedViewService) DeleteView(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.DeleteView", attribute.String("saved_view.id", id))
+	defer endSpan(span, &err)
+
+	return s.viewRepo.Delete(ctx, id)
+}
+
+// RunView returns the page of tasks the view currently matches.
+func (s *SavedViewService) RunView(ctx context.Context, view *models.SavedView, page models.SavedViewRunQuery) (_ []models.Task, err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.RunView", attribute.String("saved_view.id", view.ID.String()),
+		attribute.Int("page", page.Page), attribute.Int("limit", page.Limit))
+	defer endSpan(span, &err)
+
+	query, err := validation.ParseTaskListQuery("query", view.Query)
+	if err != nil {
+		return nil, err
+	}
+	query.Page, query.Limit = page.Page, page.Limit
+	return s.tasks.ListTasks(ctx, query)
+}
+
+// CountViews returns the number of tasks each view visible to the viewer
+// matches, in the order of ListViews.
+func (s *SavedViewService) CountViews(ctx context.Context, viewer uuid.UUID) (_ []models.SavedViewCount, err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.CountViews", attribute.String("user.id", viewer.String()))
+	defer endSpan(span, &err)
+
+	views, err := s.visibleViews(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]models.SavedViewCount, len(views))
+	for i, view := range views {
+		query, err := validation.ParseTaskListQuery("query", view.Query)
+		if err != nil {
+			return nil, err
+		}
+		counts[i] = models.SavedViewCount{ViewID: view.ID, Name: view.Name}
+		if counts[i].Count, err = s.tasks.CountTasks(ctx, query); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// GetDefaultView returns the user's default view, or a not found error when
+// they haven't chosen one or it has since stopped being shared with them.
+func (s *SavedViewService) GetDefaultView(ctx context.Context, userID uuid.UUID) (_ *models.SavedView, err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.GetDefaultView", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	view, err := s.viewRepo.GetDefault(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	projects, err := s.projectRepo.GetMemberProjects(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !view.VisibleTo(userID, projects) {
+		return nil, apperrors.NotFound("default_view")
+	}
+	return view, nil
+}
+
+// SetDefaultView makes a view the user can see their default.
+func (s *SavedViewService) SetDefaultView(ctx context.Context, userID, viewID uuid.UUID) (_ *models.SavedView, err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.SetDefaultView",
+		attribute.String("user.id", userID.String()), attribute.String("saved_view.id", viewID.String()))
+	defer endSpan(span, &err)
+
+	view, err := s.GetView(ctx, viewID.String(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.viewRepo.SetDefault(ctx, userID.String(), viewID.String()); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (s *SavedViewService) ClearDefaultView(ctx context.Context, userID uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "SavedViewService.ClearDefaultView", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	return s.viewRepo.ClearDefault(ctx, userID.String())
+}
+
+func (s *SavedViewService) visibleViews(ctx context.Context, viewer uuid.UUID) ([]models.SavedView, error) {
+	projects, err := s.projectRepo.GetMemberProjects(ctx, viewer.String())
+	if err != nil {
+		return nil, err
+	}
+	return s.viewRepo.GetVisible(ctx, viewer.String(), projects)
+}
+
+// checkView rejects views with an invalid query, and shared views without
+// a project or with one their owner isn't a member of.
+func (s *SavedViewService) checkView(ctx context.Context, view *models.SavedView) error {
+	if _, err := validation.ParseTaskListQuery("query", view.Query); err != nil {
+		return err
+	}
+	if view.ProjectID == nil {
+		if view.Shared {
+			return apperrors.Validation("shared views need a project",
+				apperrors.FieldError{Field: "project_id", Message: "is required for shared views"})
+		}
+		return nil
+	}
+	projects, err := s.projectRepo.GetMemberProjects(ctx, view.UserID.String())
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(projects, *view.ProjectID) {
+		return apperrors.Validation("views can only belong to your projects",
+			apperrors.FieldError{Field: "project_id", Message: "must be a project you are a member of"})
+	}
+	return nil
+}
@@ -2,33 +2,253 @@ package services
 
 import (
 	"context"
+	"log/slog"
+	"slices"
+	"strconv"
 
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/logger"
 	"github.com/sampathreddy22/task-management-api/internal/models"
 	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"github.com/sampathreddy22/task-management-api/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type TaskService struct {
-	taskRepo repositories.TaskRepository
+	taskRepo      repositories.TaskRepository
+	boardRepo     repositories.BoardRepository
+	projectRepo   repositories.ProjectRepository
+	notifications *NotificationService
+	events        *broker[TaskEvent]
 }
 
-func NewTaskService(taskRepo repositories.TaskRepository) *TaskService {
+// NewTaskService returns a TaskService sending notifications about the
+// changes it makes through notifications, if it isn't nil.
+func NewTaskService(taskRepo repositories.TaskRepository, boardRepo repositories.BoardRepository,
+	projectRepo repositories.ProjectRepository, notifications *NotificationService) *TaskService {
 	return &TaskService{
-		taskRepo: taskRepo,
+		taskRepo:      taskRepo,
+		boardRepo:     boardRepo,
+		projectRepo:   projectRepo,
+		notifications: notifications,
+		events:        newBroker[TaskEvent](),
 	}
 }
 
-func (s *TaskService) CreateTask(ctx context.Context, task *models.Task) error {
-	return s.taskRepo.Create(ctx, task)
+// Subscribe returns a channel receiving the changes made through this
+// service until ctx is done. Changes made by other server instances aren't
+// seen, and events are dropped for subscribers that fall behind.
+func (s *TaskService) Subscribe(ctx context.Context) <-chan TaskEvent {
+	return s.events.subscribe(ctx)
 }
 
-func (s *TaskService) GetTaskByID(ctx context.Context, id string) (*models.Task, error) {
+// CreateTask creates the task at the bottom of its column, which must have
+// room for it under its WIP limit.
+func (s *TaskService) CreateTask(ctx context.Context, task *models.Task) (err error) {
+	ctx, span := startSpan(ctx, "TaskService.CreateTask", attribute.String("task.id", task.ID.String()))
+	defer endSpan(span, &err)
+
+	tasks := []models.Task{*task}
+	if err := s.createTasks(ctx, tasks); err != nil {
+		return err
+	}
+	*task = tasks[0]
+	return nil
+}
+
+// CreateTasks creates the tasks together, each at the bottom of its
+// column in the given order. Subtasks must follow their parents. Either
+// all of them fit in their columns' WIP limits or none is created.
+func (s *TaskService) CreateTasks(ctx context.Context, tasks []models.Task) (err error) {
+	ctx, span := startSpan(ctx, "TaskService.CreateTasks", attribute.Int("tasks", len(tasks)))
+	defer endSpan(span, &err)
+
+	return s.createTasks(ctx, tasks)
+}
+
+func (s *TaskService) createTasks(ctx context.Context, tasks []models.Task) error {
+	var projects []uuid.UUID
+	for _, task := range tasks {
+		if task.ProjectID != nil {
+			projects = append(projects, *task.ProjectID)
+		}
+	}
+	if err := s.checkProjects(ctx, projects...); err != nil {
+		return err
+	}
+	limits, err := s.wipLimits(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.taskRepo.CreateBatch(ctx, tasks, limits); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		s.events.publish(TaskEvent{Type: TaskCreated, Task: task})
+		s.notify(ctx, nil, task)
+	}
+	return nil
+}
+
+func (s *TaskService) GetTaskByID(ctx context.Context, id string) (_ *models.Task, err error) {
+	ctx, span := startSpan(ctx, "TaskService.GetTaskByID", attribute.String("task.id", id))
+	defer endSpan(span, &err)
+
+	return s.taskRepo.GetByID(ctx, id)
+}
+
+// GetTasksByIDs returns the tasks with the given IDs, skipping unknown ones.
+func (s *TaskService) GetTasksByIDs(ctx context.Context, ids []string) (_ []models.Task, err error) {
+	ctx, span := startSpan(ctx, "TaskService.GetTasksByIDs", attribute.Int("tasks", len(ids)))
+	defer endSpan(span, &err)
+
+	return s.taskRepo.GetByIDs(ctx, ids)
+}
+
+// GetAttachments returns the attachments of the given tasks.
+func (s *TaskService) GetAttachments(ctx context.Context, taskIDs []string) (_ []models.Attachment, err error) {
+	ctx, span := startSpan(ctx, "TaskService.GetAttachments", attribute.Int("tasks", len(taskIDs)))
+	defer endSpan(span, &err)
+
+	return s.taskRepo.GetAttachments(ctx, taskIDs)
+}
+
+// UpdateTask changes the fields set in input. A status change is a move to
+// the bottom of the new column, subject to its WIP limit, made in the same
+// transaction as the other fields. Without one the fields are written on
+// their own, so a concurrent move isn't undone.
+func (s *TaskService) UpdateTask(ctx context.Context, id string, input models.UpdateTaskInput) (_ *models.Task, err error) {
+	ctx, span := startSpan(ctx, "TaskService.UpdateTask", attribute.String("task.id", id))
+	defer endSpan(span, &err)
+
+	before, err := s.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.ProjectID != nil {
+		if err := s.checkProjects(ctx, *input.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	var task *models.Task
+	if input.Status != nil && *input.Status != before.Status {
+		column, err := s.boardRepo.GetColumn(ctx, *input.Status)
+		if err != nil {
+			return nil, err
+		}
+		task, err = s.taskRepo.MoveAndPatch(ctx, id, models.TaskMove{Status: *input.Status}, column.WIPLimit, input)
+		if err != nil {
+			return nil, err
+		}
+	} else if task, err = s.taskRepo.Patch(ctx, id, input); err != nil {
+		return nil, err
+	}
+	s.events.publish(TaskEvent{Type: TaskUpdated, Task: *task})
+	s.notify(ctx, before, *task)
+	return task, nil
+}
+
+// checkProjects reports the first of the projects that doesn't exist.
+func (s *TaskService) checkProjects(ctx context.Context, ids ...uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.String()
+	}
+	found, err := s.projectRepo.GetByIDs(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !slices.ContainsFunc(found, func(p models.Project) bool { return p.ID == id }) {
+			return apperrors.Validation("project not found",
+				apperrors.FieldError{Field: "project_id", Message: "no project has the ID " + id.String()})
+		}
+	}
+	return nil
+}
+
+func (s *TaskService) DeleteTask(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "TaskService.DeleteTask", attribute.String("task.id", id))
+	defer endSpan(span, &err)
+
+	if err := s.taskRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	deleted := TaskEvent{Type: TaskDeleted}
+	deleted.Task.ID, _ = uuid.Parse(id)
+	s.events.publish(deleted)
+	return nil
+}
+
+// ListTasks returns a page of tasks matching the single filter set in query,
+// or of all tasks when there is none, in the query's sort order.
+func (s *TaskService) ListTasks(ctx context.Context, query models.TaskListQuery) (_ []models.Task, err error) {
+	ctx, span := startSpan(ctx, "TaskService.ListTasks",
+		attribute.Int("page", query.Page), attribute.Int("limit", query.Limit))
+	defer endSpan(span, &err)
+
+	if query.Sort != "" {
+		return s.taskRepo.Find(ctx, query)
+	}
+	offset, limit := query.Offset(), query.Limit
+	switch {
+	case query.Status != "":
+		return s.taskRepo.GetByStatus(ctx, query.Status, offset, limit)
+	case query.Priority != 0:
+		return s.taskRepo.GetByPriority(ctx, strconv.Itoa(query.Priority), offset, limit)
+	case query.UserID != "":
+		return s.taskRepo.GetByUserID(ctx, query.UserID, offset, limit)
+	case query.Query != "":
+		return s.taskRepo.Search(ctx, query.Query, offset, limit)
+	default:
+		return s.taskRepo.List(ctx, offset, limit)
+	}
+}
+
+// CountTasks returns the number of tasks matching the filter of query.
+func (s *TaskService) CountTasks(ctx context.Context, query models.TaskListQuery) (_ int, err error) {
+	ctx, span := startSpan(ctx, "TaskService.CountTasks")
+	defer endSpan(span, &err)
+
+	return s.taskRepo.Count(ctx, query)
+}
+
+// previous returns the stored task about to be changed, for notifying the
+// change, or nil if notifications are off.
+func (s *TaskService) previous(ctx context.Context, id string) (*models.Task, error) {
+	if s.notifications == nil {
+		return nil, nil
+	}
 	return s.taskRepo.GetByID(ctx, id)
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, task *models.Task) error {
-	return s.taskRepo.Update(ctx, task)
+// notify sends the notifications about a change that has been made, so
+// failing to send them is logged rather than returned.
+func (s *TaskService) notify(ctx context.Context, before *models.Task, after models.Task) {
+	if s.notifications == nil {
+		return
+	}
+	if err := s.notifications.taskChanged(ctx, before, after); err != nil {
+		logger.FromContext(ctx).WarnContext(ctx, "failed to send task notifications",
+			slog.String("task_id", after.ID.String()), slog.Any("error", err))
+	}
+}
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
 }
 
-func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
-	return s.taskRepo.Delete(ctx, id)
+// endSpan records the error returned by the traced method, if any, and ends the span.
+func endSpan(span trace.Span, err *error) {
+	if *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
 }
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type TaskTemplateService struct {
+	templateRepo repositories.TaskTemplateRepository
+	tasks        *TaskService
+}
+
+func NewTaskTemplateService(templateRepo repositories.TaskTemplateRepository, tasks *TaskService) *TaskTemplateService {
+	return &TaskTemplateService{templateRepo: templateRepo, tasks: tasks}
+}
+
+// CreateTemplate saves a new template. Its task tree is limited to
+// MaxTemplateTasks tasks, MaxTemplateDepth levels deep.
+func (s *TaskTemplateService) CreateTemplate(ctx context.Context, template *models.TaskTemplate) (err error) {
+	ctx, span := startSpan(ctx, "TaskTemplateService.CreateTemplate", attribute.String("template.id", template.ID.String()))
+	defer endSpan(span, &err)
+
+	if err := checkTemplate(template); err != nil {
+		return err
+	}
+	return s.templateRepo.Create(ctx, template)
+}
+
+func (s *TaskTemplateService) GetTemplate(ctx context.Context, id string) (_ *models.TaskTemplate, err error) {
+	ctx, span := startSpan(ctx, "TaskTemplateService.GetTemplate", attribute.String("template.id", id))
+	defer endSpan(span, &err)
+
+	return s.templateRepo.GetByID(ctx, id)
+}
+
+// ListTemplates returns a page of templates ordered by ID.
+func (s *TaskTemplateService) ListTemplates(ctx context.Context, query models.TemplateListQuery) (_ []models.TaskTemplate, err error) {
+	ctx, span := startSpan(ctx, "TaskTemplateService.ListTemplates",
+		attribute.Int("page", query.Page), attribute.Int("limit", query.Limit))
+	defer endSpan(span, &err)
+
+	return s.templateRepo.List(ctx, query.Offset(), query.Limit)
+}
+
+func (s *TaskTemplateService) UpdateTemplate(ctx context.Context, template *models.TaskTemplate) (err error) {
+	ctx, span := startSpan(ctx, "TaskTemplateService.UpdateTemplate", attribute.String("template.id", template.ID.String()))
+	defer endSpan(span, &err)
+
+	if err := checkTemplate(template); err != nil {
+		return err
+	}
+	return s.templateRepo.Update(ctx, template)
+}
+
+// DeleteTemplate deletes the template. Tasks created from it are kept.
+func (s *TaskTemplateService) DeleteTemplate(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "TaskTemplateService.DeleteTemplate", attribute.String("template.id", id))
+	defer endSpan(span, &err)
+
+	return s.templateRepo.Delete(ctx, id)
+}
+
+// Instantiate creates the template's task tree, all of it or none, owned by
+// owner if it isn't nil. It returns the tasks with parents before their
+// subtasks.
+func (s *TaskTemplateService) Instantiate(ctx context.Context, id string, input models.InstantiateTemplateInput, owner *uuid.UUID) (_ []models.Task, err error) {
+	ctx, span := startSpan(ctx, "TaskTemplateService.Instantiate", attribute.String("template.id", id))
+	defer endSpan(span, &err)
+
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []apperrors.FieldError
+	for _, name := range template.Task.Variables() {
+		if _, ok := input.Variables[name]; !ok {
+			missing = append(missing, apperrors.FieldError{Field: "variables." + name, Message: "is required"})
+		}
+	}
+	if len(missing) > 0 {
+		return nil, apperrors.Validation("the template's variables must all be given", missing...)
+	}
+
+	anchor := time.Now()
+	if input.Anchor != nil {
+		anchor = *input.Anchor
+	}
+	refs := template.Task.Instantiate(input.Variables, anchor, owner)
+	tasks := make([]models.Task, len(refs))
+	for i, ref := range refs {
+		if err := checkInstance(ref); err != nil {
+			return nil, err
+		}
+		tasks[i] = ref.Task
+	}
+	span.SetAttributes(attribute.Int("tasks", len(tasks)))
+
+	if err := s.tasks.CreateTasks(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// checkTemplate enforces the limits on the task tree that binding can't
+// express.
+func checkTemplate(template *models.TaskTemplate) error {
+	tasks, depth := template.Task.Size()
+	if tasks > models.MaxTemplateTasks {
+		return apperrors.Validation("the template has too many tasks", apperrors.FieldError{
+			Field: "task", Message: fmt.Sprintf("must have at most %d tasks, has %d", models.MaxTemplateTasks, tasks),
+		})
+	}
+	if depth > models.MaxTemplateDepth {
+		return apperrors.Validation("the template's subtasks are nested too deeply", apperrors.FieldError{
+			Field: "task", Message: fmt.Sprintf("must be at most %d levels deep, is %d", models.MaxTemplateDepth, depth),
+		})
+	}
+	return nil
+}
+
+// checkInstance applies the rules of POST /tasks to a task built from a
+// template, whose title and due date depend on the instantiation.
+func checkInstance(ref models.TemplateTaskRef) error {
+	switch task := ref.Task; {
+	case task.Title == "":
+		return apperrors.Validation("a task title is empty once the variables are filled in",
+			apperrors.FieldError{Field: ref.Path + ".title", Message: "must not be empty"})
+	case len(task.Title) > 255:
+		return apperrors.Validation("a task title is too long once the variables are filled in",
+			apperrors.FieldError{Field: ref.Path + ".title", Message: "must be at most 255 characters long"})
+	case len(task.Description) > 10000:
+		return apperrors.Validation("a task description is too long once the variables are filled in",
+			apperrors.FieldError{Field: ref.Path + ".description", Message: "must be at most 10000 characters long"})
+	case task.DueDate != nil && !task.DueDate.After(time.Now()):
+		return apperrors.Validation("a task would be due in the past",
+			apperrors.FieldError{Field: ref.Path + ".due_offset_days", Message: "must put the due date after now, given the anchor"})
+	}
+	return nil
+}
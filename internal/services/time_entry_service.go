@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+	"github.com/sampathreddy22/task-management-api/internal/repositories"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type TimeEntryService struct {
+	entryRepo repositories.TimeEntryRepository
+	taskRepo  repositories.TaskRepository
+}
+
+func NewTimeEntryService(entryRepo repositories.TimeEntryRepository, taskRepo repositories.TaskRepository) *TimeEntryService {
+	return &TimeEntryService{entryRepo: entryRepo, taskRepo: taskRepo}
+}
+
+// StartTimer starts a timer for the user on the task. It fails with a
+// timer_running conflict while the user has another timer running.
+func (s *TimeEntryService) StartTimer(ctx context.Context, taskID string, userID uuid.UUID, note string) (_ *models.TimeEntry, err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.StartTimer",
+		attribute.String("task.id", taskID), attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	task, err := s.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	running, err := s.entryRepo.GetRunning(ctx, userID.String())
+	if err == nil {
+		return nil, timerRunning(running)
+	}
+	if !apperrors.Is(err, apperrors.KindNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry := &models.TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TaskID:    task.ID,
+		StartedAt: now,
+		Note:      note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.entryRepo.Create(ctx, entry); err != nil {
+		// A concurrent start won the race for the running timer index.
+		if apperrors.Is(err, apperrors.KindConflict) {
+			return nil, timerRunning(nil)
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// StopTimer stops the user's running timer.
+func (s *TimeEntryService) StopTimer(ctx context.Context, userID uuid.UUID) (_ *models.TimeEntry, err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.StopTimer", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	entry, err := s.entryRepo.GetRunning(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	entry.End(time.Now())
+	if err := s.entryRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetRunningTimer returns the user's running timer.
+func (s *TimeEntryService) GetRunningTimer(ctx context.Context, userID uuid.UUID) (_ *models.TimeEntry, err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.GetRunningTimer", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	return s.entryRepo.GetRunning(ctx, userID.String())
+}
+
+// CreateTimeEntry records time entered by hand on an existing task.
+func (s *TimeEntryService) CreateTimeEntry(ctx context.Context, entry *models.TimeEntry) (err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.CreateTimeEntry",
+		attribute.String("time_entry.id", entry.ID.String()), attribute.String("task.id", entry.TaskID.String()))
+	defer endSpan(span, &err)
+
+	if _, err := s.taskRepo.GetByID(ctx, entry.TaskID.String()); err != nil {
+		return err
+	}
+	if err := checkTimeEntry(entry); err != nil {
+		return err
+	}
+	return s.entryRepo.Create(ctx, entry)
+}
+
+func (s *TimeEntryService) GetTimeEntry(ctx context.Context, id string) (_ *models.TimeEntry, err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.GetTimeEntry", attribute.String("time_entry.id", id))
+	defer endSpan(span, &err)
+
+	return s.entryRepo.GetByID(ctx, id)
+}
+
+// UpdateTimeEntry saves an edited entry, which must still end after it
+// starts.
+func (s *TimeEntryService) UpdateTimeEntry(ctx context.Context, entry *models.TimeEntry) (err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.UpdateTimeEntry", attribute.String("time_entry.id", entry.ID.String()))
+	defer endSpan(span, &err)
+
+	if err := checkTimeEntry(entry); err != nil {
+		return err
+	}
+	return s.entryRepo.Update(ctx, entry)
+}
+
+func (s *TimeEntryService) DeleteTimeEntry(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.DeleteTimeEntry", attribute.String("time_entry.id", id))
+	defer endSpan(span, &err)
+
+	return s.entryRepo.Delete(ctx, id)
+}
+
+// ListTaskTimeEntries returns a page of the task's entries, latest first.
+func (s *TimeEntryService) ListTaskTimeEntries(ctx context.Context, taskID string, query models.TimeEntryListQuery) (_ []models.TimeEntry, err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.ListTaskTimeEntries", attribute.String("task.id", taskID),
+		attribute.Int("page", query.Page), attribute.Int("limit", query.Limit))
+	defer endSpan(span, &err)
+
+	if _, err := s.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.entryRepo.GetByTaskID(ctx, taskID, query.Offset(), query.Limit)
+}
+
+// Report sums the time logged in the query's date range per group. Running
+// timers are left out until they are stopped. Project and label reports
+// are built from the time per task, grouped by the tasks' current project
+// and labels.
+func (s *TimeEntryService) Report(ctx context.Context, query models.TimeReportQuery) (_ *models.TimeReport, err error) {
+	ctx, span := startSpan(ctx, "TimeEntryService.Report", attribute.String("report.group_by", query.GroupBy))
+	defer endSpan(span, &err)
+
+	byTask := query.GroupBy == models.TimeReportByProject || query.GroupBy == models.TimeReportByLabel
+	repoQuery := query
+	if byTask {
+		repoQuery.GroupBy = models.TimeReportByTask
+	}
+	rows, err := s.entryRepo.Report(ctx, repoQuery)
+	if err != nil {
+		return nil, err
+	}
+	report := &models.TimeReport{GroupBy: query.GroupBy, From: query.From, To: query.To, Rows: rows}
+	for _, row := range rows {
+		report.TotalSeconds += row.Seconds
+	}
+	if byTask {
+		if report.Rows, err = s.groupTaskRows(ctx, rows, query.GroupBy); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// groupTaskRows sums the rows of a task report per project or label of the
+// tasks, ordered by key.
+func (s *TimeEntryService) groupTaskRows(ctx context.Context, rows []models.TimeReportRow, groupBy string) ([]models.TimeReportRow, error) {
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.Key
+	}
+	tasks, err := s.taskRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID.String()] = task
+	}
+
+	groups := make(map[string]*models.TimeReportRow)
+	for _, row := range rows {
+		task := byID[row.Key]
+		keys := []string{""}
+		switch {
+		case groupBy == models.TimeReportByProject && task.ProjectID != nil:
+			keys = []string{task.ProjectID.String()}
+		case groupBy == models.TimeReportByLabel && len(task.Labels) > 0:
+			keys = task.Labels
+		}
+		for _, key := range keys {
+			group, ok := groups[key]
+			if !ok {
+				group = &models.TimeReportRow{Key: key}
+				groups[key] = group
+			}
+			group.Entries += row.Entries
+			group.Seconds += row.Seconds
+		}
+	}
+
+	grouped := make([]models.TimeReportRow, 0, len(groups))
+	for _, group := range groups {
+		grouped = append(grouped, *group)
+	}
+	slices.SortFunc(grouped, func(a, b models.TimeReportRow) int { return strings.Compare(a.Key, b.Key) })
+	return grouped, nil
+}
+
+// checkTimeEntry rejects entries ending before they start, and running
+// timers that start in the future.
+func checkTimeEntry(entry *models.TimeEntry) error {
+	if entry.Running() {
+		if entry.StartedAt.After(time.Now()) {
+			return apperrors.Validation("a running timer can't start in the future",
+				apperrors.FieldError{Field: "started_at", Message: "must not be in the future"})
+		}
+		return nil
+	}
+	if entry.EndedAt.Before(entry.StartedAt) {
+		return apperrors.Validation("a time entry can't end before it starts",
+			apperrors.FieldError{Field: "ended_at", Message: "must be after started_at"})
+	}
+	return nil
+}
+
+// timerRunning reports that the user already has a running timer. running
+// is nil when it isn't known.
+func timerRunning(running *models.TimeEntry) *apperrors.Error {
+	message := "a timer is already running, stop it first"
+	if running != nil {
+		message = "a timer is already running on task " + running.TaskID.String() + ", stop it first"
+	}
+	return apperrors.Conflict("timer_running", message)
+}
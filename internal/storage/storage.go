@@ -0,0 +1,115 @@
+// Package storage keeps the files of attachments. Only local storage, a
+// directory on the server's disk, is supported.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Store saves attachment files and removes them again.
+type Store interface {
+	// Save writes the file read from r and returns the path it is stored
+	// at. name is the file's name as given by its uploader.
+	Save(ctx context.Context, name string, r io.Reader) (string, error)
+	// Open returns the content of a file saved earlier. Paths the store
+	// didn't save, or whose file is gone, are reported as ErrNotStored.
+	Open(ctx context.Context, path string) (io.ReadCloser, error)
+	// Delete removes a file saved earlier.
+	Delete(ctx context.Context, path string) error
+}
+
+// ErrNotStored reports that a path doesn't name a stored file.
+var ErrNotStored = errors.New("file is not stored")
+
+// Local stores files in a directory, each in a subdirectory of its own so
+// that files with the same name don't overwrite each other.
+type Local struct {
+	dir string
+}
+
+func NewLocal(dir string) *Local {
+	return &Local{dir: dir}
+}
+
+func (l *Local) Save(ctx context.Context, name string, r io.Reader) (string, error) {
+	dir := filepath.Join(l.dir, uuid.NewString())
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create storage directory: %w", err)
+	}
+	path := filepath.Join(dir, fileName(name))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("create stored file: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("write stored file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("write stored file: %w", err)
+	}
+	return path, nil
+}
+
+func (l *Local) Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	if !l.stored(path) {
+		return nil, ErrNotStored
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotStored
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open stored file: %w", err)
+	}
+	return f, nil
+}
+
+func (l *Local) Delete(ctx context.Context, path string) error {
+	if !l.stored(path) {
+		return fmt.Errorf("%s is not a stored file", path)
+	}
+	dir := filepath.Dir(path)
+	if err := os.RemoveAll(dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete stored file: %w", err)
+	}
+	return nil
+}
+
+// stored reports whether path is where Save would put a file.
+func (l *Local) stored(path string) bool {
+	return filepath.Dir(filepath.Dir(path)) == filepath.Clean(l.dir)
+}
+
+// fileName makes an uploaded file name safe to use as the last element of
+// a path.
+func fileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < 0x20 || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimLeft(strings.TrimSpace(name), ".")
+	if len(name) > 200 {
+		ext := filepath.Ext(name)
+		if len(ext) > 20 {
+			ext = ""
+		}
+		name = strings.ToValidUTF8(name[:200-len(ext)], "") + ext
+	}
+	if name == "" {
+		return "file"
+	}
+	return name
+}
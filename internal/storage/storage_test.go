@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalOpensSavedFiles(t *testing.T) {
+	store := NewLocal(t.TempDir())
+	ctx := context.Background()
+
+	path, err := store.Save(ctx, "notes.txt", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := store.Open(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "hello" {
+		t.Errorf("content = %q, want hello", content)
+	}
+
+	if err := store.Delete(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Open(ctx, path); !errors.Is(err, ErrNotStored) {
+		t.Errorf("Open after Delete: err = %v, want ErrNotStored", err)
+	}
+}
+
+func TestLocalOnlyOpensItsOwnFiles(t *testing.T) {
+	root := t.TempDir()
+	outside := filepath.Join(root, "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store := NewLocal(filepath.Join(root, "uploads"))
+
+	for _, path := range []string{
+		outside,
+		filepath.Join(root, "uploads", "secret.txt"),
+		filepath.Join(root, "uploads", "x", "..", "..", "secret.txt"),
+		"https://example.com/file.pdf",
+	} {
+		if _, err := store.Open(context.Background(), path); !errors.Is(err, ErrNotStored) {
+			t.Errorf("Open(%q): err = %v, want ErrNotStored", path, err)
+		}
+	}
+}
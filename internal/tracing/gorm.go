@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+// InstrumentDB creates a client span for every GORM operation, parented to
+// the span carried by the statement context.
+func InstrumentDB(db *gorm.DB, system string) error {
+	cb := db.Callback()
+	hooks := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, h := range hooks {
+		if err := h.before("tracing:before_"+h.operation, startSpan(h.operation, system)); err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", h.operation, err)
+		}
+		if err := h.after("tracing:after_"+h.operation, endSpan); err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", h.operation, err)
+		}
+	}
+	return nil
+}
+
+// AnnotatePool records on each read's span which connection pool served
+// it, as named by name, e.g. "primary" or a replica's address. It must be
+// called after InstrumentDB and after the replica router is registered.
+func AnnotatePool(db *gorm.DB, name func(gorm.ConnPool) string) error {
+	cb := db.Callback()
+	hooks := []struct {
+		operation string
+		register  func(string, func(*gorm.DB)) error
+	}{
+		{"query", cb.Query().After("gorm:query").Before("tracing:after_query").Register},
+		{"row", cb.Row().After("gorm:row").Before("tracing:after_row").Register},
+		{"raw", cb.Raw().After("gorm:raw").Before("tracing:after_raw").Register},
+	}
+	for _, h := range hooks {
+		err := h.register("tracing:pool_"+h.operation, func(db *gorm.DB) {
+			if span, ok := spanOf(db); ok {
+				span.SetAttributes(attribute.String("db.pool", name(db.Statement.ConnPool)))
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", h.operation, err)
+		}
+	}
+	return nil
+}
+
+func startSpan(operation, system string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Don't start root spans for queries issued outside a request,
+			// such as metric scrapes.
+			return
+		}
+
+		name := "db." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		_, span := Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", system),
+				attribute.String("db.operation.name", operation),
+				attribute.String("db.collection.name", db.Statement.Table),
+			),
+		)
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func spanOf(db *gorm.DB) (trace.Span, bool) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return nil, false
+	}
+	span, ok := value.(trace.Span)
+	return span, ok
+}
+
+func endSpan(db *gorm.DB) {
+	span, ok := spanOf(db)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	// Only the parameterized statement is recorded; bound values may be secrets.
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
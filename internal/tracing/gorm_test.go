@@ -0,0 +1,84 @@
+package tracing_test
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sampathreddy22/task-management-api/internal/replica"
+	"github.com/sampathreddy22/task-management-api/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openSQLite(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := conn.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	return conn
+}
+
+func TestAnnotatePoolNamesTheServingPool(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	dir := t.TempDir()
+	primary := openSQLite(t, filepath.Join(dir, "primary.db"))
+	replicaConn := openSQLite(t, filepath.Join(dir, "replica.db"))
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: primary}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gorm open: %v", err)
+	}
+	if err := tracing.InstrumentDB(db, "sqlite"); err != nil {
+		t.Fatalf("instrument: %v", err)
+	}
+	router, err := replica.Setup(db, []replica.Replica{{Name: "replica-1", Conn: replicaConn}},
+		replica.Options{ReadYourWrites: time.Second, CheckInterval: time.Minute}, slog.Default())
+	if err != nil {
+		t.Fatalf("replica setup: %v", err)
+	}
+	if err := tracing.AnnotatePool(db, router.PoolName); err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	var ids []int
+	if err := db.WithContext(ctx).Table("items").Pluck("id", &ids).Error; err != nil {
+		t.Fatalf("replica read: %v", err)
+	}
+	if err := db.WithContext(replica.WithPrimary(ctx)).Table("items").Pluck("id", &ids).Error; err != nil {
+		t.Fatalf("primary read: %v", err)
+	}
+	parent.End()
+
+	var pools []string
+	for _, span := range recorder.Ended() {
+		for _, attr := range span.Attributes() {
+			if attr.Key == attribute.Key("db.pool") {
+				pools = append(pools, attr.Value.AsString())
+			}
+		}
+	}
+	if len(pools) != 2 || pools[0] != "replica-1" || pools[1] != "primary" {
+		t.Fatalf("db.pool attributes = %v, want [replica-1 primary]", pools)
+	}
+}
@@ -0,0 +1,198 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/sampathreddy22/task-management-api/internal/apperrors"
+	"github.com/sampathreddy22/task-management-api/internal/models"
+)
+
+// AllowedAttachmentTypes are the MIME types accepted for attachments.
+var AllowedAttachmentTypes = []string{
+	"application/pdf",
+	"application/zip",
+	"application/json",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"image/gif",
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+	"text/csv",
+	"text/plain",
+}
+
+// Register installs the custom validators on gin's validator engine and
+// makes field errors report JSON field names.
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("unexpected validator engine")
+	}
+
+	v.RegisterTagNameFunc(jsonFieldName)
+
+	validators := map[string]validator.Func{
+		"task_status":     taskStatus,
+		"task_sort":       taskSort,
+		"future":          future,
+		"attachment_mime": attachmentMIME,
+	}
+	for tag, fn := range validators {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return fmt.Errorf("failed to register %s validator: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+func taskStatus(fl validator.FieldLevel) bool {
+	return slices.Contains(models.TaskStatuses, fl.Field().String())
+}
+
+func taskSort(fl validator.FieldLevel) bool {
+	return slices.Contains(models.TaskSorts, strings.TrimPrefix(fl.Field().String(), "-"))
+}
+
+func future(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && t.After(time.Now())
+}
+
+func attachmentMIME(fl validator.FieldLevel) bool {
+	mediaType, _, err := mime.ParseMediaType(fl.Field().String())
+	return err == nil && slices.Contains(AllowedAttachmentTypes, mediaType)
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// BindError converts an error from gin's ShouldBind* into a validation error
+// listing every rejected field.
+func BindError(err error) error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]apperrors.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, apperrors.FieldError{Field: fieldPath(fe), Message: message(fe)})
+		}
+		return apperrors.Validation("request validation failed", fields...).Wrap(err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return apperrors.Validation("request validation failed", apperrors.FieldError{
+			Field:   typeErr.Field,
+			Message: "must be of type " + typeErr.Type.String(),
+		}).Wrap(err)
+	}
+
+	var timeErr *time.ParseError
+	if errors.As(err, &timeErr) {
+		return apperrors.Validation("timestamps must be in RFC 3339 format").Wrap(err)
+	}
+
+	return apperrors.Validation("request body is not valid JSON").Wrap(err)
+}
+
+// fieldPath strips the top level struct name from the validator namespace.
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if fe.Kind() == reflect.String {
+			return "must be at least " + fe.Param() + " characters long"
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return "must be at most " + fe.Param() + " characters long"
+		}
+		return "must be at most " + fe.Param()
+	case "task_status":
+		return "must be one of " + strings.Join(models.TaskStatuses, ", ")
+	case "task_sort":
+		return "must be one of " + strings.Join(models.TaskSorts, ", ") + ", optionally prefixed with -"
+	case "future":
+		return "must be in the future"
+	case "attachment_mime":
+		return "must be one of " + strings.Join(AllowedAttachmentTypes, ", ")
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
+
+// taskViewKeys are the GET /tasks parameters a saved view may store. Paging
+// is chosen when the view is run.
+var taskViewKeys = []string{"status", "priority", "user_id", "q", "sort"}
+
+// ParseTaskListQuery parses the filter and sort of a saved view, written as
+// a GET /tasks query string such as "status=todo&sort=-priority". It
+// accepts what the list endpoint accepts, reporting problems on field.
+func ParseTaskListQuery(field, raw string) (models.TaskListQuery, error) {
+	var query models.TaskListQuery
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return query, apperrors.Validation(field+" must be a URL query string",
+			apperrors.FieldError{Field: field, Message: "must be a URL query string"}).Wrap(err)
+	}
+	for key, vals := range values {
+		if !slices.Contains(taskViewKeys, key) {
+			return query, apperrors.Validation(field+" has an unknown parameter",
+				apperrors.FieldError{Field: field, Message: key + " is not one of " + strings.Join(taskViewKeys, ", ")})
+		}
+		if len(vals) > 1 {
+			return query, apperrors.Validation(field+" repeats a parameter",
+				apperrors.FieldError{Field: field, Message: key + " may only be given once"})
+		}
+	}
+
+	req := &http.Request{URL: &url.URL{RawQuery: raw}}
+	if err := binding.Query.Bind(req, &query); err != nil {
+		bindErr := BindError(err).(*apperrors.Error)
+		if len(bindErr.Fields) == 0 {
+			// Values that don't parse, such as a priority that isn't a number.
+			return query, apperrors.Validation(field+" has an invalid value",
+				apperrors.FieldError{Field: field, Message: "is not a valid task list query"}).Wrap(err)
+		}
+		for i := range bindErr.Fields {
+			bindErr.Fields[i].Field = field + "." + bindErr.Fields[i].Field
+		}
+		return query, bindErr
+	}
+	if filters := query.Filters(); len(filters) > 1 {
+		return query, apperrors.Validation("only one of status, priority, user_id and q may be given",
+			apperrors.FieldError{Field: field + "." + filters[1], Message: "can't be combined with " + filters[0]})
+	}
+	return query, nil
+}
@@ -0,0 +1,107 @@
+// Package migrations embeds the SQL migrations for each supported database
+// dialect and applies them. Versions are tracked in a schema_migrations
+// table compatible with golang-migrate, so databases migrated with that
+// tool are picked up where they left off.
+//
+// Both dialects share one version sequence: a version means the same schema
+// change everywhere, and a dialect with nothing to do for it gets a no-op
+// migration.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// Migration is a single up migration.
+type Migration struct {
+	Version int64
+	Name    string
+	SQL     string
+}
+
+// Load returns the up migrations for dialect ("postgres" or "sqlite") in
+// version order.
+func Load(dialect string) ([]Migration, error) {
+	paths, err := fs.Glob(files, dialect+"/*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no migrations for dialect %q", dialect)
+	}
+
+	migrations := make([]Migration, 0, len(paths))
+	for _, p := range paths {
+		name := strings.TrimSuffix(path.Base(p), ".up.sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no numeric version", p)
+		}
+		sql, err := fs.ReadFile(files, p)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(sql)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies the migrations newer than the database's current version,
+// each in its own transaction, and returns the ones it applied.
+func Up(ctx context.Context, db *gorm.DB) ([]Migration, error) {
+	pending, err := Load(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	conn := db.WithContext(ctx)
+	if err := conn.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)").Error; err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var state struct {
+		Version int64
+		Dirty   bool
+	}
+	if err := conn.Table("schema_migrations").Select("version, dirty").Limit(1).Scan(&state).Error; err != nil {
+		return nil, fmt.Errorf("failed to read migration state: %w", err)
+	}
+	if state.Dirty {
+		return nil, fmt.Errorf("migration %d is dirty, fix the schema and reset the version by hand", state.Version)
+	}
+
+	var applied []Migration
+	for _, m := range pending {
+		if m.Version <= state.Version {
+			continue
+		}
+		err := conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.SQL).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", m.Version, false).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %s failed: %w", m.Name, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
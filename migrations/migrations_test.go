@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestDialectsShareVersions(t *testing.T) {
+	postgres, err := Load("postgres")
+	if err != nil {
+		t.Fatalf("load postgres: %v", err)
+	}
+	sqlite, err := Load("sqlite")
+	if err != nil {
+		t.Fatalf("load sqlite: %v", err)
+	}
+
+	if len(postgres) != len(sqlite) {
+		t.Fatalf("postgres has %d migrations, sqlite %d", len(postgres), len(sqlite))
+	}
+	for i := range postgres {
+		if postgres[i].Name != sqlite[i].Name {
+			t.Errorf("migration %d is %s on postgres and %s on sqlite", i+1, postgres[i].Name, sqlite[i].Name)
+		}
+		if postgres[i].Version != int64(i+1) {
+			t.Errorf("migration %s breaks the version sequence, want version %d", postgres[i].Name, i+1)
+		}
+	}
+}
+
+func TestUpSQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "tasks.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	ctx := context.Background()
+
+	all, _ := Load("sqlite")
+	applied, err := Up(ctx, db)
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if len(applied) != len(all) {
+		t.Errorf("applied %d migrations, want %d", len(applied), len(all))
+	}
+
+	applied, err = Up(ctx, db)
+	if err != nil {
+		t.Fatalf("second up: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("second up applied %d migrations, want none", len(applied))
+	}
+
+	var version int64
+	db.Raw("SELECT version FROM schema_migrations").Scan(&version)
+	if version != all[len(all)-1].Version {
+		t.Errorf("schema_migrations version = %d, want %d", version, all[len(all)-1].Version)
+	}
+}
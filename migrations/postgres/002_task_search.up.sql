@@ -0,0 +1,6 @@
+-- Trigram indexes let the case-insensitive substring search on tasks use an
+-- index instead of scanning the table.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_tasks_title_trgm ON tasks USING gin (LOWER(title) gin_trgm_ops);
+CREATE INDEX idx_tasks_description_trgm ON tasks USING gin (LOWER(description) gin_trgm_ops);
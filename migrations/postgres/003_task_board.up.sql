@@ -0,0 +1,22 @@
+-- Tasks are ordered within their status column by a fractional index key
+-- (see internal/rank), which must compare bytewise whatever the database
+-- collation. Existing tasks are ranked by creation time.
+ALTER TABLE tasks ADD COLUMN rank VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+
+UPDATE tasks SET rank = ranked.rank
+FROM (
+    SELECT id, lpad(row_number() OVER (PARTITION BY status ORDER BY created_at, id)::text, 10, '0') || 'V' AS rank
+    FROM tasks
+) ranked
+WHERE tasks.id = ranked.id;
+
+CREATE INDEX idx_tasks_status_rank ON tasks(status, rank, id);
+
+-- One row per task status. Moves into a column lock its row.
+CREATE TABLE board_columns (
+    status VARCHAR(50) PRIMARY KEY,
+    wip_limit INTEGER CHECK (wip_limit > 0),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+INSERT INTO board_columns (status) VALUES ('todo'), ('in_progress'), ('done');
@@ -0,0 +1,20 @@
+CREATE TABLE saved_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_saved_views_user_id ON saved_views(user_id);
+CREATE UNIQUE INDEX idx_saved_views_user_name ON saved_views(user_id, name);
+
+CREATE TABLE saved_view_defaults (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    view_id UUID NOT NULL REFERENCES saved_views(id) ON DELETE CASCADE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_saved_view_defaults_view_id ON saved_view_defaults(view_id);
@@ -0,0 +1,27 @@
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('assigned', 'mentioned', 'commented', 'status_changed', 'due_soon')),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    message VARCHAR(500) NOT NULL,
+    dedup_key VARCHAR(150),
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at);
+CREATE INDEX idx_notifications_task_id ON notifications(task_id);
+-- Notifications such as due soon reminders are sent once per key.
+CREATE UNIQUE INDEX idx_notifications_dedup_key ON notifications(dedup_key);
+
+-- Types and channels without a row are on.
+CREATE TABLE notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('inbox', 'push')),
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, type, channel)
+);
@@ -0,0 +1,13 @@
+-- Messages received by the email gateway, by Message-ID, so redelivered
+-- messages aren't turned into tasks twice and replies can be threaded.
+CREATE TABLE inbound_emails (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    received_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_inbound_emails_message_id ON inbound_emails(message_id);
+CREATE INDEX idx_inbound_emails_task_id ON inbound_emails(task_id);
@@ -0,0 +1,3 @@
+-- Attachments keep the MIME type they were uploaded with. Existing rows
+-- predate it and get the generic binary type.
+ALTER TABLE attachments ADD COLUMN content_type VARCHAR(255) NOT NULL DEFAULT 'application/octet-stream';
@@ -0,0 +1,15 @@
+-- Views are shared with the members of a project rather than with every
+-- user. Views shared before have no project, so they become private until
+-- their owner picks one.
+CREATE TABLE project_members (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
+INSERT INTO project_members (project_id, user_id) SELECT id, owner_id FROM projects;
+
+ALTER TABLE saved_views ADD COLUMN project_id UUID REFERENCES projects(id) ON DELETE SET NULL;
+CREATE INDEX idx_saved_views_project_id ON saved_views(project_id);
@@ -2,4 +2,3 @@ DROP TABLE IF EXISTS attachments;
 DROP TABLE IF EXISTS comments;
 DROP TABLE IF EXISTS tasks;
 DROP TABLE IF EXISTS users;
-DROP EXTENSION IF EXISTS "uuid-ossp"; 
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Nothing to undo, see 002_task_search.up.sql.
+SELECT 1;
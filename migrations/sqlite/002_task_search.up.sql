@@ -0,0 +1,4 @@
+-- The trigram indexes of the Postgres search migration have no SQLite
+-- equivalent; substring search scans the table. Kept so that both dialects
+-- share one version sequence.
+SELECT 1;
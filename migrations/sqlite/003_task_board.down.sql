@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS board_columns;
+DROP INDEX IF EXISTS idx_tasks_status_rank;
+ALTER TABLE tasks DROP COLUMN rank;
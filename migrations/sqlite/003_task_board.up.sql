@@ -0,0 +1,21 @@
+-- Tasks are ordered within their status column by a fractional index key
+-- (see internal/rank). Existing tasks are ranked by creation time.
+ALTER TABLE tasks ADD COLUMN rank VARCHAR(255) NOT NULL DEFAULT '';
+
+UPDATE tasks SET rank = ranked.rank
+FROM (
+    SELECT id, printf('%010d', row_number() OVER (PARTITION BY status ORDER BY created_at, id)) || 'V' AS rank
+    FROM tasks
+) AS ranked
+WHERE tasks.id = ranked.id;
+
+CREATE INDEX idx_tasks_status_rank ON tasks(status, rank, id);
+
+-- One row per task status.
+CREATE TABLE board_columns (
+    status VARCHAR(50) PRIMARY KEY,
+    wip_limit INTEGER CHECK (wip_limit > 0),
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO board_columns (status) VALUES ('todo'), ('in_progress'), ('done');
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS time_entries;
+ALTER TABLE tasks DROP COLUMN estimate_minutes;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS saved_view_defaults;
+DROP TABLE IF EXISTS saved_views;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_templates;
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP COLUMN parent_id;
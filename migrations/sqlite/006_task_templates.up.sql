@@ -0,0 +1,14 @@
+-- Subtasks created from templates point at their parent task. Deleting the
+-- parent keeps them as standalone tasks.
+ALTER TABLE tasks ADD COLUMN parent_id TEXT REFERENCES tasks(id) ON DELETE SET NULL;
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+
+CREATE TABLE task_templates (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    name VARCHAR(100) NOT NULL,
+    task TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_task_templates_name ON task_templates(name);
@@ -0,0 +1,27 @@
+CREATE TABLE notifications (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('assigned', 'mentioned', 'commented', 'status_changed', 'due_soon')),
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    comment_id TEXT REFERENCES comments(id) ON DELETE CASCADE,
+    actor_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    message VARCHAR(500) NOT NULL,
+    dedup_key VARCHAR(150),
+    read_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at);
+CREATE INDEX idx_notifications_task_id ON notifications(task_id);
+-- Notifications such as due soon reminders are sent once per key.
+CREATE UNIQUE INDEX idx_notifications_dedup_key ON notifications(dedup_key);
+
+-- Types and channels without a row are on.
+CREATE TABLE notification_preferences (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('inbox', 'push')),
+    enabled BOOLEAN NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, type, channel)
+);
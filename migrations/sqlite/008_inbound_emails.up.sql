@@ -0,0 +1,13 @@
+-- Messages received by the email gateway, by Message-ID, so redelivered
+-- messages aren't turned into tasks twice and replies can be threaded.
+CREATE TABLE inbound_emails (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    message_id VARCHAR(255) NOT NULL,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    comment_id TEXT REFERENCES comments(id) ON DELETE CASCADE,
+    received_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_inbound_emails_message_id ON inbound_emails(message_id);
+CREATE INDEX idx_inbound_emails_task_id ON inbound_emails(task_id);
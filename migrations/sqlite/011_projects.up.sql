@@ -0,0 +1,16 @@
+-- Projects group tasks, so that time can be reported per project. Deleting
+-- a project keeps its tasks. Labels are a JSON array of strings.
+CREATE TABLE projects (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    name VARCHAR(100) NOT NULL,
+    owner_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_projects_name ON projects(name);
+CREATE INDEX idx_projects_owner_id ON projects(owner_id);
+
+ALTER TABLE tasks ADD COLUMN project_id TEXT REFERENCES projects(id) ON DELETE SET NULL;
+CREATE INDEX idx_tasks_project_id ON tasks(project_id);
+ALTER TABLE tasks ADD COLUMN labels TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_saved_views_project_id;
+ALTER TABLE saved_views DROP COLUMN project_id;
+DROP TABLE IF EXISTS project_members;
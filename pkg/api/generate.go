@@ -0,0 +1,6 @@
+// Package api holds the protobuf definitions of the gRPC API, generated from
+// the files in proto/. Regenerate them with go generate after editing those
+// files; protoc, protoc-gen-go and protoc-gen-go-grpc must be on PATH.
+package api
+
+//go:generate protoc -I ../../proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative taskapi/v1/tasks.proto taskapi/v1/users.proto taskapi/v1/comments.proto taskapi/v1/attachments.proto
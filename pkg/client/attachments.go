@@ -0,0 +1,106 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path/filepath"
+)
+
+// AttachmentsService calls the attachment endpoints.
+type AttachmentsService struct{ c *Client }
+
+// Create registers metadata for a file stored elsewhere.
+func (s *AttachmentsService) Create(ctx context.Context, input AttachmentInput) (*Attachment, error) {
+	var attachment Attachment
+	if err := s.c.doJSON(ctx, http.MethodPost, "/attachments/", nil, input, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (s *AttachmentsService) Get(ctx context.Context, id string) (*Attachment, error) {
+	var attachment Attachment
+	if err := s.c.doJSON(ctx, http.MethodGet, "/attachments/"+url.PathEscape(id), nil, nil, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// ListByTask returns the attachments of a task.
+func (s *AttachmentsService) ListByTask(ctx context.Context, taskID string) ([]Attachment, error) {
+	var attachments []Attachment
+	if err := s.c.doJSON(ctx, http.MethodGet, "/attachments/task/"+url.PathEscape(taskID), nil, nil, &attachments); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentsService) Update(ctx context.Context, id string, input AttachmentInput) (*Attachment, error) {
+	var attachment Attachment
+	if err := s.c.doJSON(ctx, http.MethodPut, "/attachments/"+url.PathEscape(id), nil, input, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (s *AttachmentsService) Delete(ctx context.Context, id string) error {
+	return s.c.doJSON(ctx, http.MethodDelete, "/attachments/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// Upload sends the content of r as a file named fileName attached to the
+// task. The content is buffered in memory so it can be resent on retries.
+func (s *AttachmentsService) Upload(ctx context.Context, taskID, fileName string, r io.Reader) (*Attachment, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", filepath.Base(fileName))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	req := request{
+		method:      http.MethodPost,
+		path:        "/tasks/" + url.PathEscape(taskID) + "/attachments",
+		body:        buf.Bytes(),
+		contentType: w.FormDataContentType(),
+		accept:      "application/json",
+	}
+	var attachment Attachment
+	if err := s.c.decode(ctx, req, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Download writes the attachment's content to w and returns the file name
+// the server suggested, if any.
+func (s *AttachmentsService) Download(ctx context.Context, id string, w io.Writer) (string, error) {
+	resp, err := s.c.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/attachments/" + url.PathEscape(id) + "/content",
+		accept: "*/*",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return "", err
+	}
+	var name string
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		name = params["filename"]
+	}
+	return name, nil
+}
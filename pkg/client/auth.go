@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// AuthService signs users up and in and manages the client's token.
+type AuthService struct{ c *Client }
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // seconds
+}
+
+// Signup registers a new user.
+func (s *AuthService) Signup(ctx context.Context, input CreateUserInput) (*User, error) {
+	req, err := jsonRequest(http.MethodPost, "/signup", nil, input)
+	if err != nil {
+		return nil, err
+	}
+	req.anonymous = true
+
+	var user User
+	if err := s.c.decode(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Login exchanges credentials for a token, which the client uses from then on.
+func (s *AuthService) Login(ctx context.Context, email, password string) (*Token, error) {
+	return s.exchange(ctx, "/login", map[string]string{"email": email, "password": password}, "")
+}
+
+// Logout invalidates the session on the server and forgets the token.
+func (s *AuthService) Logout(ctx context.Context) error {
+	if err := s.c.doJSON(ctx, http.MethodPost, "/logout", nil, nil, nil); err != nil {
+		return err
+	}
+	s.c.mu.Lock()
+	s.c.token = nil
+	s.c.mu.Unlock()
+	return nil
+}
+
+// Refresh renews the access token with the refresh token.
+func (s *AuthService) Refresh(ctx context.Context) (*Token, error) {
+	token, ok := s.c.Token()
+	if !ok || token.RefreshToken == "" {
+		return nil, &Error{Status: http.StatusUnauthorized, Detail: "no refresh token"}
+	}
+	return s.exchange(ctx, "/refresh", map[string]string{"refresh_token": token.RefreshToken}, token.RefreshToken)
+}
+
+// refresh renews the token unless another request already replaced stale.
+func (s *AuthService) refresh(ctx context.Context, stale string) error {
+	s.c.refreshMu.Lock()
+	defer s.c.refreshMu.Unlock()
+
+	if token, ok := s.c.Token(); ok && token.AccessToken != stale && token.valid() {
+		return nil
+	}
+	_, err := s.Refresh(ctx)
+	return err
+}
+
+func (s *AuthService) exchange(ctx context.Context, path string, body any, refreshToken string) (*Token, error) {
+	req, err := jsonRequest(http.MethodPost, path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	req.anonymous = true
+
+	var resp tokenResponse
+	if err := s.c.decode(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	token := Token{AccessToken: resp.AccessToken, RefreshToken: resp.RefreshToken}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken // the server kept the old one
+	}
+	if resp.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	s.c.setToken(token)
+	return &token, nil
+}
@@ -0,0 +1,338 @@
+// Package client is a typed Go client for the Task Management API.
+//
+//	c, err := client.New("http://localhost:8080")
+//	if err != nil { ... }
+//	if _, err := c.Auth.Login(ctx, "me@example.com", password); err != nil { ... }
+//	for task, err := range c.Tasks.All(ctx, client.ListTasksOptions{Status: client.StatusTodo}) {
+//		...
+//	}
+//
+// Requests rejected with 429 are retried with backoff, honouring
+// Retry-After; 5xx responses are retried for idempotent methods only. An
+// expired access token is refreshed automatically when a refresh token is
+// known.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	userAgent  string
+
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu        sync.Mutex // guards token
+	token     *Token
+	onRefresh func(Token)
+	refreshMu sync.Mutex // serializes refreshes
+
+	Tasks       *TasksService
+	Users       *UsersService
+	Attachments *AttachmentsService
+	Comments    *CommentsService
+	Auth        *AuthService
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithToken authenticates requests with a previously obtained token.
+func WithToken(token Token) Option {
+	return func(c *Client) { c.token = &token }
+}
+
+// WithTokenRefreshed registers fn to be called with every new token, from
+// a login or an automatic refresh, e.g. to persist it.
+func WithTokenRefreshed(fn func(Token)) Option {
+	return func(c *Client) { c.onRefresh = fn }
+}
+
+// WithRetries sets how often a request is retried and the bounds of the
+// exponential backoff between attempts. The default is 3 retries between
+// 200ms and 5s.
+func WithRetries(max int, minBackoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries, c.minBackoff, c.maxBackoff = max, minBackoff, maxBackoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New returns a client for the API served at baseURL, e.g.
+// "https://tasks.example.com". The /api/v1 prefix is added by the client.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		userAgent:  "task-management-api-go-client",
+		maxRetries: 3,
+		minBackoff: 200 * time.Millisecond,
+		maxBackoff: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Tasks = &TasksService{c}
+	c.Users = &UsersService{c}
+	c.Attachments = &AttachmentsService{c}
+	c.Comments = &CommentsService{c}
+	c.Auth = &AuthService{c}
+	return c, nil
+}
+
+// Token returns the current token, if any.
+func (c *Client) Token() (Token, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == nil {
+		return Token{}, false
+	}
+	return *c.token, true
+}
+
+// request is one API call. The body is kept as bytes so the request can be
+// replayed on retries and after a token refresh.
+type request struct {
+	method      string
+	path        string // relative to /api/v1
+	query       url.Values
+	body        []byte
+	contentType string
+	accept      string
+	anonymous   bool // no credentials, no refresh
+}
+
+func jsonRequest(method, path string, query url.Values, in any) (request, error) {
+	req := request{method: method, path: path, query: query, accept: "application/json"}
+	if in != nil {
+		body, err := json.Marshal(in)
+		if err != nil {
+			return req, err
+		}
+		req.body, req.contentType = body, "application/json"
+	}
+	return req, nil
+}
+
+// doJSON performs a JSON request and decodes the response into out, if
+// not nil.
+func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	req, err := jsonRequest(method, path, query, in)
+	if err != nil {
+		return err
+	}
+	return c.decode(ctx, req, out)
+}
+
+// decode performs req and decodes the JSON response into out, if not nil.
+func (c *Client) decode(ctx context.Context, req request, out any) error {
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// do sends req, refreshing the token and retrying as needed, and returns
+// a successful response. The caller closes the body.
+func (c *Client) do(ctx context.Context, req request) (*http.Response, error) {
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		token, err := c.accessToken(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.send(ctx, req, token)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= c.maxRetries || !idempotent(req.method) {
+				return nil, err
+			}
+			if err := c.sleep(ctx, attempt, 0); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		switch {
+		case resp.StatusCode < http.StatusBadRequest:
+			return resp, nil
+		case resp.StatusCode == http.StatusUnauthorized && !req.anonymous && !refreshed && c.canRefresh():
+			resp.Body.Close()
+			if err := c.Auth.refresh(ctx, token); err != nil {
+				return nil, err
+			}
+			refreshed = true
+			attempt-- // a refresh isn't a retry
+			continue
+		case retryable(req.method, resp.StatusCode) && attempt < c.maxRetries:
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+			if err := c.sleep(ctx, attempt, retryAfter); err != nil {
+				return nil, err
+			}
+			continue
+		default:
+			defer resp.Body.Close()
+			return nil, decodeError(resp)
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req request, token string) (*http.Response, error) {
+	u := c.baseURL.JoinPath("api", "v1")
+	u.Path += req.path
+	u.RawQuery = req.query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), bytes.NewReader(req.body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if req.accept != "" {
+		httpReq.Header.Set("Accept", req.accept)
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	return c.httpClient.Do(httpReq)
+}
+
+// accessToken returns the token to send with req, refreshing it first when
+// it is about to expire.
+func (c *Client) accessToken(ctx context.Context, req request) (string, error) {
+	if req.anonymous {
+		return "", nil
+	}
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+
+	if token == nil {
+		return "", nil
+	}
+	if !token.valid() && token.RefreshToken != "" {
+		if err := c.Auth.refresh(ctx, token.AccessToken); err != nil {
+			return "", err
+		}
+		c.mu.Lock()
+		token = c.token
+		c.mu.Unlock()
+	}
+	return token.AccessToken, nil
+}
+
+func (c *Client) canRefresh() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token != nil && c.token.RefreshToken != ""
+}
+
+func (c *Client) setToken(token Token) {
+	c.mu.Lock()
+	c.token = &token
+	c.mu.Unlock()
+	if c.onRefresh != nil {
+		c.onRefresh(token)
+	}
+}
+
+// sleep waits before retry attempt+1: retryAfter when the server asked for
+// it, otherwise exponential backoff with jitter.
+func (c *Client) sleep(ctx context.Context, attempt int, retryAfter time.Duration) error {
+	wait := retryAfter
+	if wait <= 0 {
+		backoff := c.minBackoff << attempt
+		if backoff <= 0 || backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+		if backoff > 0 {
+			wait = backoff/2 + rand.N(backoff/2+1)
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryable reports whether a response may be retried. 429 means the
+// request wasn't processed; a 5xx might have been, so only requests that
+// are safe to repeat are retried.
+func retryable(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= http.StatusInternalServerError && status != http.StatusNotImplemented && idempotent(method)
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
@@ -0,0 +1,235 @@
+// Package clienttest is an integration suite for package client. Callers
+// provide a function starting a fresh API server, typically setupRouter
+// with in-memory repositories behind httptest.NewServer, and Tasks checks
+// the client against it end to end.
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sampathreddy22/task-management-api/pkg/client"
+)
+
+// Password is the password of the users the suite signs up.
+const Password = "correct horse battery"
+
+// Login signs up a user with the email address and logs the client in as
+// them.
+func Login(t *testing.T, c *client.Client, email string) *client.Token {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := c.Auth.Signup(ctx, client.CreateUserInput{Email: email, Password: Password}); err != nil {
+		t.Fatalf("Signup: %v", err)
+	}
+	token, err := c.Auth.Login(ctx, email, Password)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	return token
+}
+
+// Tasks runs the task, comment and authentication endpoint suite.
+// newServer returns the base URL of an empty server for each subtest.
+func Tasks(t *testing.T, newServer func(t *testing.T) string) {
+	ctx := context.Background()
+	newAnonymousClient := func(t *testing.T, url string, opts ...client.Option) *client.Client {
+		t.Helper()
+		c, err := client.New(url, append([]client.Option{client.WithRetries(0, 0, 0)}, opts...)...)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		return c
+	}
+	newClient := func(t *testing.T) *client.Client {
+		t.Helper()
+		c := newAnonymousClient(t, newServer(t))
+		Login(t, c, "alice@example.com")
+		return c
+	}
+
+	t.Run("CRUD", func(t *testing.T) {
+		c := newClient(t)
+		due := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+		created, err := c.Tasks.Create(ctx, client.CreateTaskInput{Title: "Write SDK", DueDate: &due})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.ID == "" || created.Status != client.StatusTodo || created.Priority != 3 || !created.DueDate.Equal(due) {
+			t.Fatalf("Create = %+v, want server defaults and the due date", created)
+		}
+
+		got, err := c.Tasks.Get(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.Title != "Write SDK" {
+			t.Fatalf("Get = %+v", got)
+		}
+
+		done := client.StatusDone
+		updated, err := c.Tasks.Update(ctx, created.ID, client.UpdateTaskInput{Status: &done})
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if updated.Status != done || updated.Title != "Write SDK" {
+			t.Fatalf("Update = %+v, want only the status changed", updated)
+		}
+
+		if err := c.Tasks.Delete(ctx, created.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := c.Tasks.Get(ctx, created.ID); !client.IsNotFound(err) {
+			t.Fatalf("Get after Delete: error = %v, want not found", err)
+		}
+	})
+
+	t.Run("ValidationErrors", func(t *testing.T) {
+		c := newClient(t)
+		_, err := c.Tasks.Create(ctx, client.CreateTaskInput{Title: "", Priority: 9})
+		var apiErr *client.Error
+		if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnprocessableEntity {
+			t.Fatalf("Create invalid task: error = %v, want 422", err)
+		}
+		var fields []string
+		for _, f := range apiErr.Errors {
+			fields = append(fields, f.Field)
+		}
+		slices.Sort(fields)
+		if !slices.Equal(fields, []string{"priority", "title"}) {
+			t.Fatalf("invalid fields = %v, want [priority title]", fields)
+		}
+		if apiErr.RequestID == "" {
+			t.Error("problem response has no request_id")
+		}
+	})
+
+	t.Run("ListAndIterate", func(t *testing.T) {
+		c := newClient(t)
+		for i := 0; i < 7; i++ {
+			input := client.CreateTaskInput{Title: "task", Priority: 1 + i%2}
+			if _, err := c.Tasks.Create(ctx, input); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		page, err := c.Tasks.List(ctx, client.ListTasksOptions{Limit: 5})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page) != 5 {
+			t.Fatalf("List returned %d tasks, want 5", len(page))
+		}
+
+		seen := map[string]bool{}
+		for task, err := range c.Tasks.All(ctx, client.ListTasksOptions{Limit: 3}) {
+			if err != nil {
+				t.Fatalf("All: %v", err)
+			}
+			seen[task.ID] = true
+		}
+		if len(seen) != 7 {
+			t.Fatalf("All yielded %d distinct tasks, want 7", len(seen))
+		}
+
+		high := 0
+		for task, err := range c.Tasks.All(ctx, client.ListTasksOptions{Priority: 1, Limit: 2}) {
+			if err != nil {
+				t.Fatalf("All: %v", err)
+			}
+			if task.Priority != 1 {
+				t.Fatalf("All(priority=1) yielded priority %d", task.Priority)
+			}
+			high++
+		}
+		if high != 4 {
+			t.Fatalf("All(priority=1) yielded %d tasks, want 4", high)
+		}
+
+		// Stopping early must not fetch further pages or fail.
+		for range c.Tasks.All(ctx, client.ListTasksOptions{Limit: 1}) {
+			break
+		}
+	})
+	t.Run("Comments", func(t *testing.T) {
+		c := newClient(t)
+		task, err := c.Tasks.Create(ctx, client.CreateTaskInput{Title: "Review"})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		comment, err := c.Comments.Create(ctx, task.ID, "Looks good")
+		if err != nil {
+			t.Fatalf("Comments.Create: %v", err)
+		}
+		if comment.Content != "Looks good" || comment.TaskID != task.ID {
+			t.Fatalf("Comments.Create = %+v", comment)
+		}
+		comments, err := c.Comments.List(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("Comments.List: %v", err)
+		}
+		if len(comments) != 1 || comments[0].ID != comment.ID {
+			t.Fatalf("Comments.List = %+v, want the new comment", comments)
+		}
+
+		if err := c.Comments.Delete(ctx, comment.ID); err != nil {
+			t.Fatalf("Comments.Delete: %v", err)
+		}
+		if comments, err := c.Comments.List(ctx, task.ID); err != nil || len(comments) != 0 {
+			t.Fatalf("Comments.List after Delete = %+v, %v, want none", comments, err)
+		}
+		if _, err := c.Comments.Create(ctx, "00000000-0000-4000-8000-000000000000", "Hello"); !client.IsNotFound(err) {
+			t.Fatalf("Comments.Create on a missing task: error = %v, want not found", err)
+		}
+	})
+
+	t.Run("Auth", func(t *testing.T) {
+		url := newServer(t)
+		c := newAnonymousClient(t, url)
+		if _, err := c.Tasks.List(ctx, client.ListTasksOptions{}); client.StatusCode(err) != http.StatusUnauthorized {
+			t.Fatalf("List without a token: error = %v, want 401", err)
+		}
+
+		token := Login(t, c, "alice@example.com")
+		if _, err := c.Auth.Signup(ctx, client.CreateUserInput{Email: "Alice@example.com", Password: Password}); client.StatusCode(err) != http.StatusConflict {
+			t.Fatalf("Signup with a used email: error = %v, want 409", err)
+		}
+		if _, err := c.Auth.Login(ctx, "alice@example.com", "wrong password"); client.StatusCode(err) != http.StatusUnauthorized {
+			t.Fatalf("Login with a wrong password: error = %v, want 401", err)
+		}
+
+		// A rejected access token is refreshed once and the request repeated.
+		var refreshed []client.Token
+		stale := newAnonymousClient(t, url,
+			client.WithToken(client.Token{AccessToken: "stale", RefreshToken: token.RefreshToken}),
+			client.WithTokenRefreshed(func(token client.Token) { refreshed = append(refreshed, token) }))
+		if _, err := stale.Tasks.List(ctx, client.ListTasksOptions{}); err != nil {
+			t.Fatalf("List with a stale access token: %v", err)
+		}
+		if len(refreshed) != 1 || refreshed[0].RefreshToken == token.RefreshToken {
+			t.Fatalf("refreshed tokens = %+v, want one with a new refresh token", refreshed)
+		}
+
+		// The refresh token was replaced, so it can't be used again.
+		reused := newAnonymousClient(t, url, client.WithToken(*token))
+		if _, err := reused.Auth.Refresh(ctx); client.StatusCode(err) != http.StatusUnauthorized {
+			t.Fatalf("Refresh with a used refresh token: error = %v, want 401", err)
+		}
+
+		if err := stale.Auth.Logout(ctx); err != nil {
+			t.Fatalf("Logout: %v", err)
+		}
+		if _, ok := stale.Token(); ok {
+			t.Fatal("Logout kept the token")
+		}
+		loggedOut := newAnonymousClient(t, url, client.WithToken(refreshed[0]))
+		if _, err := loggedOut.Tasks.List(ctx, client.ListTasksOptions{}); client.StatusCode(err) != http.StatusUnauthorized {
+			t.Fatalf("List after Logout: error = %v, want 401", err)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CommentsService calls the comment endpoints.
+type CommentsService struct{ c *Client }
+
+// Create adds a comment to a task.
+func (s *CommentsService) Create(ctx context.Context, taskID, content string) (*Comment, error) {
+	var comment Comment
+	body := map[string]string{"content": content}
+	if err := s.c.doJSON(ctx, http.MethodPost, "/tasks/"+url.PathEscape(taskID)+"/comments", nil, body, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// List returns the comments on a task.
+func (s *CommentsService) List(ctx context.Context, taskID string) ([]Comment, error) {
+	var comments []Comment
+	if err := s.c.doJSON(ctx, http.MethodGet, "/tasks/"+url.PathEscape(taskID)+"/comments", nil, nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *CommentsService) Delete(ctx context.Context, id string) error {
+	return s.c.doJSON(ctx, http.MethodDelete, "/comments/"+url.PathEscape(id), nil, nil, nil)
+}
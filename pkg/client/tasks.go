@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TasksService calls the /tasks endpoints.
+type TasksService struct{ c *Client }
+
+func (s *TasksService) Create(ctx context.Context, input CreateTaskInput) (*Task, error) {
+	var task Task
+	if err := s.c.doJSON(ctx, http.MethodPost, "/tasks/", nil, input, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *TasksService) Get(ctx context.Context, id string) (*Task, error) {
+	var task Task
+	if err := s.c.doJSON(ctx, http.MethodGet, "/tasks/"+url.PathEscape(id), nil, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *TasksService) Update(ctx context.Context, id string, input UpdateTaskInput) (*Task, error) {
+	var task Task
+	if err := s.c.doJSON(ctx, http.MethodPut, "/tasks/"+url.PathEscape(id), nil, input, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (s *TasksService) Delete(ctx context.Context, id string) error {
+	return s.c.doJSON(ctx, http.MethodDelete, "/tasks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// List returns one page of tasks.
+func (s *TasksService) List(ctx context.Context, opts ListTasksOptions) ([]Task, error) {
+	var tasks []Task
+	if err := s.c.doJSON(ctx, http.MethodGet, "/tasks/", opts.values(), nil, &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// All iterates over every task matching opts, fetching pages of opts.Limit
+// tasks starting at opts.Page as it goes. Iteration stops after the first
+// error, which is yielded with a zero Task.
+func (s *TasksService) All(ctx context.Context, opts ListTasksOptions) iter.Seq2[Task, error] {
+	return func(yield func(Task, error) bool) {
+		if opts.Page < 1 {
+			opts.Page = 1
+		}
+		if opts.Limit < 1 {
+			opts.Limit = 100
+		}
+		for {
+			tasks, err := s.List(ctx, opts)
+			if err != nil {
+				yield(Task{}, err)
+				return
+			}
+			for _, task := range tasks {
+				if !yield(task, nil) {
+					return
+				}
+			}
+			if len(tasks) < opts.Limit {
+				return
+			}
+			opts.Page++
+		}
+	}
+}
+
+func (o ListTasksOptions) values() url.Values {
+	v := url.Values{}
+	if o.Status != "" {
+		v.Set("status", o.Status)
+	}
+	if o.Priority != 0 {
+		v.Set("priority", strconv.Itoa(o.Priority))
+	}
+	if o.UserID != "" {
+		v.Set("user_id", o.UserID)
+	}
+	if o.Query != "" {
+		v.Set("q", o.Query)
+	}
+	if o.Sort != "" {
+		v.Set("sort", o.Sort)
+	}
+	if o.Page != 0 {
+		v.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Limit != 0 {
+		v.Set("limit", strconv.Itoa(o.Limit))
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package client
+
+import "time"
+
+// Task statuses.
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+)
+
+type Task struct {
+	ID          string       `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Status      string       `json:"status"`
+	Priority    int          `json:"priority"`
+	DueDate     *time.Time   `json:"due_date,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	UserID      *string      `json:"user_id,omitempty"`
+	Comments    []Comment    `json:"comments,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// CreateTaskInput is the body of a create request. Status and Priority
+// default to "todo" and 3 on the server when left empty.
+type CreateTaskInput struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Priority    int        `json:"priority,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+// UpdateTaskInput changes the fields that are set and leaves the others.
+type UpdateTaskInput struct {
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Status      *string    `json:"status,omitempty"`
+	Priority    *int       `json:"priority,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+}
+
+// ListTasksOptions are the GET /tasks query parameters. The API accepts at
+// most one of Status, Priority, UserID and Query.
+type ListTasksOptions struct {
+	Status   string
+	Priority int
+	UserID   string
+	Query    string // searches titles and descriptions
+	Sort     string // a field such as "due_date", descending with a "-" prefix
+	Page     int    // starts at 1
+	Limit    int    // 1-100, server default 20
+}
+
+type User struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateUserInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type Comment struct {
+	ID        string    `json:"id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	TaskID    string    `json:"task_id"`
+	UserID    string    `json:"user_id"`
+}
+
+type Attachment struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"file_name"`
+	FilePath    string    `json:"file_path"`
+	ContentType string    `json:"content_type"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+	TaskID      string    `json:"task_id"`
+}
+
+// AttachmentInput registers or updates attachment metadata.
+type AttachmentInput struct {
+	FileName    string `json:"file_name"`
+	FilePath    string `json:"file_path"`
+	ContentType string `json:"content_type"`
+	TaskID      string `json:"task_id"`
+}
+
+// Token is an access token and the refresh token used to renew it.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"-"` // zero when the server gave no lifetime
+}
+
+// valid reports whether the access token can be used for a little longer.
+func (t *Token) valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Until(t.Expiry) > 30*time.Second)
+}
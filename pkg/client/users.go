@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// UsersService calls the /users endpoints.
+type UsersService struct{ c *Client }
+
+func (s *UsersService) Create(ctx context.Context, input CreateUserInput) (*User, error) {
+	var user User
+	if err := s.c.doJSON(ctx, http.MethodPost, "/users/", nil, input, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *UsersService) Get(ctx context.Context, id string) (*User, error) {
+	var user User
+	if err := s.c.doJSON(ctx, http.MethodGet, "/users/"+url.PathEscape(id), nil, nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}